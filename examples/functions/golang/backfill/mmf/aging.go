@@ -0,0 +1,122 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	agingKey = "aging"
+
+	agingCurveLinear    = "linear"
+	agingCurveQuadratic = "quadratic"
+)
+
+// aging raises the priority of tickets as they wait, so that tickets which
+// keep losing out to newer arrivals are eventually matched first.
+type aging struct {
+	// priorityArg is the double arg holding a ticket's base priority, tickets
+	// without it, or without a priorityArg, start at zero.
+	priorityArg string
+	curve       string
+	// rate scales the boost, per second of waiting for the linear curve and
+	// per second squared for the quadratic curve.
+	rate float64
+	// grace is how long tickets wait before they start aging.
+	grace time.Duration
+}
+
+// getAging reads the aging from the profile's "aging" extension, a Struct
+// with a "curve" string, "linear" or "quadratic", a non-negative "rate"
+// number, and optionally a "priorityArg" string and a "graceSeconds" number.
+// A profile without the extension returns nil.
+func getAging(profile *pb.MatchProfile) (*aging, error) {
+	a, ok := profile.GetExtensions()[agingKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var val structpb.Struct
+	if err := ptypes.UnmarshalAny(a, &val); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal aging: %w", err)
+	}
+
+	curve := val.GetFields()["curve"].GetStringValue()
+	if curve != agingCurveLinear && curve != agingCurveQuadratic {
+		return nil, fmt.Errorf("aging curve must be %q or %q", agingCurveLinear, agingCurveQuadratic)
+	}
+	rate, ok := val.GetFields()["rate"].GetKind().(*structpb.Value_NumberValue)
+	if !ok || rate.NumberValue < 0 {
+		return nil, fmt.Errorf("aging requires a non-negative rate")
+	}
+	var grace float64
+	if v, ok := val.GetFields()["graceSeconds"]; ok {
+		n, ok := v.GetKind().(*structpb.Value_NumberValue)
+		if !ok || n.NumberValue < 0 {
+			return nil, fmt.Errorf("aging graceSeconds must be a non-negative number")
+		}
+		grace = n.NumberValue
+	}
+
+	return &aging{
+		priorityArg: val.GetFields()["priorityArg"].GetStringValue(),
+		curve:       curve,
+		rate:        rate.NumberValue,
+		grace:       time.Duration(grace * float64(time.Second)),
+	}, nil
+}
+
+// priority returns the ticket's base priority plus the boost for the time it
+// has waited at now.  Tickets without a valid create time get no boost.
+func (a *aging) priority(t *pb.Ticket, now time.Time) float64 {
+	p := t.GetSearchFields().GetDoubleArgs()[a.priorityArg]
+
+	created, err := ptypes.Timestamp(t.GetCreateTime())
+	if err != nil {
+		return p
+	}
+	waited := now.Sub(created) - a.grace
+	if waited <= 0 {
+		return p
+	}
+
+	s := waited.Seconds()
+	if a.curve == agingCurveQuadratic {
+		return p + a.rate*s*s
+	}
+	return p + a.rate*s
+}
+
+// sort returns the tickets ordered by descending priority at now, keeping
+// the query order of tickets with the same priority.
+func (a *aging) sort(tickets []*pb.Ticket, now time.Time) []*pb.Ticket {
+	priorities := make(map[*pb.Ticket]float64, len(tickets))
+	for _, t := range tickets {
+		priorities[t] = a.priority(t, now)
+	}
+
+	sorted := append([]*pb.Ticket(nil), tickets...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return priorities[sorted[i]] > priorities[sorted[j]]
+	})
+	return sorted
+}
@@ -0,0 +1,110 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestAgingPriority(t *testing.T) {
+	now := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+	ticket := func(waited time.Duration, priority float64) *pb.Ticket {
+		created, err := ptypes.TimestampProto(now.Add(-waited))
+		require.NoError(t, err)
+		return &pb.Ticket{
+			CreateTime:   created,
+			SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"priority": priority}},
+		}
+	}
+
+	linear := &aging{priorityArg: "priority", curve: agingCurveLinear, rate: 2, grace: 10 * time.Second}
+	require.Equal(t, 5.0, linear.priority(ticket(5*time.Second, 5), now))
+	require.Equal(t, 25.0, linear.priority(ticket(20*time.Second, 5), now))
+	require.Equal(t, 5.0, linear.priority(&pb.Ticket{SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"priority": 5}}}, now))
+
+	quadratic := &aging{curve: agingCurveQuadratic, rate: 2}
+	require.Equal(t, 200.0, quadratic.priority(ticket(10*time.Second, 5), now))
+}
+
+func TestMakeMatchesAgesStarvingTickets(t *testing.T) {
+	profile := agingProfile(t, "linear", 1)
+	start := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+	ticket := func(id string, created time.Time, priority float64) *pb.Ticket {
+		ts, err := ptypes.TimestampProto(created)
+		require.NoError(t, err)
+		return &pb.Ticket{
+			Id:           id,
+			CreateTime:   ts,
+			SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"priority": priority}},
+		}
+	}
+	old := ticket("old", start, 0)
+
+	// Newer tickets in a denser band keep arriving with a higher priority,
+	// until the old ticket has waited long enough to be matched first.
+	for round := 1; round <= 5; round++ {
+		now := start.Add(time.Duration(round) * 30 * time.Second)
+		tickets := []*pb.Ticket{
+			ticket(fmt.Sprintf("new-%d-a", round), now, 100),
+			ticket(fmt.Sprintf("new-%d-b", round), now, 100),
+			old,
+		}
+
+		matches, err := makeMatches(profile, profile.Pools[0], tickets, nil, partialMatchPolicy{minSize: defaultPlayersPerMatch}, now)
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+
+		matched := ticketIDs(matches[0].Tickets)
+		if round*30 <= 100 {
+			require.NotContains(t, matched, "old", "round %d", round)
+		} else {
+			require.Equal(t, "old", matched[0], "round %d", round)
+			return
+		}
+	}
+	require.Fail(t, "the old ticket was never matched")
+}
+
+func TestGetAgingInvalid(t *testing.T) {
+	require.NoError(t, validateProfile(agingProfile(t, "linear", 1)))
+	require.NoError(t, validateProfile(agingProfile(t, "quadratic", 0)))
+	require.Error(t, validateProfile(agingProfile(t, "exponential", 1)))
+	require.Error(t, validateProfile(agingProfile(t, "linear", -1)))
+}
+
+// agingProfile returns a profile aging tickets with the curve and rate, on
+// top of their "priority" double arg.
+func agingProfile(t *testing.T, curve string, rate float64) *pb.MatchProfile {
+	a, err := ptypes.MarshalAny(&structpb.Struct{Fields: map[string]*structpb.Value{
+		"priorityArg": {Kind: &structpb.Value_StringValue{StringValue: "priority"}},
+		"curve":       {Kind: &structpb.Value_StringValue{StringValue: curve}},
+		"rate":        {Kind: &structpb.Value_NumberValue{NumberValue: rate}},
+	}})
+	require.Nil(t, err)
+
+	return &pb.MatchProfile{
+		Name:       "matchProfile",
+		Pools:      []*pb.Pool{{Name: "pool"}},
+		Extensions: map[string]*any.Any{agingKey: a},
+	}
+}
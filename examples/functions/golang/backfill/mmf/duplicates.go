@@ -0,0 +1,47 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const duplicateTicketsKey = "duplicate-tickets"
+
+// getDropDuplicates reads whether proposals reusing a ticket are dropped, see
+// dedupMatches, from the profile's "duplicate-tickets" extension, a Struct
+// with a "drop" bool.  A profile without the extension has
+// dropDuplicateTickets.
+func getDropDuplicates(profile *pb.MatchProfile) (bool, error) {
+	a, ok := profile.GetExtensions()[duplicateTicketsKey]
+	if !ok {
+		return dropDuplicateTickets, nil
+	}
+
+	var val structpb.Struct
+	if err := ptypes.UnmarshalAny(a, &val); err != nil {
+		return false, fmt.Errorf("failed to unmarshal duplicate tickets: %w", err)
+	}
+
+	drop, ok := val.GetFields()["drop"].GetKind().(*structpb.Value_BoolValue)
+	if !ok {
+		return false, fmt.Errorf("duplicate tickets requires a drop bool")
+	}
+	return drop.BoolValue, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const latencyKey = "latency"
+
+// latencyGrouping groups tickets whose latency, a double arg such as the
+// latency to a datacenter, is within maxSpread of each other.  Pools can
+// already bound each ticket's latency with a DoubleRangeFilter, the grouping
+// keeps players with very different latencies out of the same match.
+type latencyGrouping struct {
+	doubleArg string
+	maxSpread float64
+}
+
+// getLatencyGrouping reads the grouping from the profile's "latency"
+// extension, a Struct with a "doubleArg" string and a "maxSpread" number.  A
+// profile without the extension returns nil.
+func getLatencyGrouping(profile *pb.MatchProfile) (*latencyGrouping, error) {
+	a, ok := profile.GetExtensions()[latencyKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var val structpb.Struct
+	if err := ptypes.UnmarshalAny(a, &val); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal latency grouping: %w", err)
+	}
+
+	doubleArg := val.GetFields()["doubleArg"].GetStringValue()
+	if doubleArg == "" {
+		return nil, fmt.Errorf("latency grouping requires a doubleArg")
+	}
+	spread, ok := val.GetFields()["maxSpread"].GetKind().(*structpb.Value_NumberValue)
+	if !ok || spread.NumberValue < 0 {
+		return nil, fmt.Errorf("latency grouping requires a non-negative maxSpread")
+	}
+
+	return &latencyGrouping{doubleArg: doubleArg, maxSpread: spread.NumberValue}, nil
+}
+
+// makeLatencyMatches is makeFullMatches for profiles with a latency grouping.
+// Tickets are sorted by latency, so the lowest latency groups are formed
+// first, and each match takes the next players tickets if they are within
+// maxSpread of each other.  Otherwise the lowest ticket can't be grouped with
+// its neighbours and is left over, as are tickets without the latency double
+// arg.
+func makeLatencyMatches(profile *pb.MatchProfile, g *latencyGrouping, players int, tickets []*pb.Ticket, lastMatchId int, now time.Time) ([]*pb.Match, []*pb.Ticket) {
+	var sorted, remaining []*pb.Ticket
+	for _, t := range tickets {
+		if _, ok := t.GetSearchFields().GetDoubleArgs()[g.doubleArg]; ok {
+			sorted = append(sorted, t)
+		} else {
+			remaining = append(remaining, t)
+		}
+	}
+
+	latency := func(t *pb.Ticket) float64 {
+		return t.GetSearchFields().GetDoubleArgs()[g.doubleArg]
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return latency(sorted[i]) < latency(sorted[j])
+	})
+
+	matchId := lastMatchId
+	var matches []*pb.Match
+	for len(sorted) >= players {
+		group := sorted[:players]
+		if latency(group[len(group)-1])-latency(group[0]) > g.maxSpread {
+			remaining = append(remaining, sorted[0])
+			sorted = sorted[1:]
+			continue
+		}
+
+		matchId++
+		match := newMatch(matchId, profile, group, nil, now)
+		matches = append(matches, match)
+		sorted = sorted[players:]
+	}
+
+	return matches, append(remaining, sorted...)
+}
@@ -0,0 +1,112 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestMakeLatencyMatches(t *testing.T) {
+	ticket := func(id string, latency float64) *pb.Ticket {
+		return &pb.Ticket{Id: id, SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"latency-us-east": latency}}}
+	}
+
+	for _, testCase := range []struct {
+		name              string
+		tickets           []*pb.Ticket
+		expectedMatches   [][]string
+		expectedRemaining []string
+	}{
+		{
+			name:              "groups low latency tickets and leaves the outlier",
+			tickets:           []*pb.Ticket{ticket("outlier", 200), ticket("c", 32), ticket("a", 20), ticket("d", 28), ticket("b", 25)},
+			expectedMatches:   [][]string{{"a", "b"}, {"d", "c"}},
+			expectedRemaining: []string{"outlier"},
+		},
+		{
+			name:              "skips a low latency ticket too far from the rest",
+			tickets:           []*pb.Ticket{ticket("a", 20), ticket("b", 90), ticket("c", 95)},
+			expectedMatches:   [][]string{{"b", "c"}},
+			expectedRemaining: []string{"a"},
+		},
+		{
+			name:              "leaves tickets without the latency arg",
+			tickets:           []*pb.Ticket{{Id: "unknown"}, ticket("a", 20), ticket("b", 25)},
+			expectedMatches:   [][]string{{"a", "b"}},
+			expectedRemaining: []string{"unknown"},
+		},
+	} {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			profile := latencyProfile(t, "latency-us-east", 10)
+			g, err := getLatencyGrouping(profile)
+			require.Nil(t, err)
+
+			matches, remaining := makeLatencyMatches(profile, g, defaultPlayersPerMatch, testCase.tickets, 0, time.Now())
+
+			var matchIDs [][]string
+			for _, m := range matches {
+				require.Nil(t, m.Backfill)
+				matchIDs = append(matchIDs, ticketIDs(m.Tickets))
+			}
+			require.Equal(t, testCase.expectedMatches, matchIDs)
+			require.Equal(t, testCase.expectedRemaining, ticketIDs(remaining))
+		})
+	}
+}
+
+func TestGetLatencyGroupingRejectsInvalid(t *testing.T) {
+	_, err := getLatencyGrouping(latencyProfile(t, "", 10))
+	require.NotNil(t, err)
+
+	_, err = getLatencyGrouping(latencyProfile(t, "latency-us-east", -1))
+	require.NotNil(t, err)
+
+	g, err := getLatencyGrouping(&pb.MatchProfile{Name: "matchProfile"})
+	require.Nil(t, err)
+	require.Nil(t, g)
+}
+
+// latencyProfile returns a profile grouping tickets on doubleArg.
+func latencyProfile(t *testing.T, doubleArg string, maxSpread float64) *pb.MatchProfile {
+	a, err := ptypes.MarshalAny(&structpb.Struct{Fields: map[string]*structpb.Value{
+		"doubleArg": {Kind: &structpb.Value_StringValue{StringValue: doubleArg}},
+		"maxSpread": {Kind: &structpb.Value_NumberValue{NumberValue: maxSpread}},
+	}})
+	require.Nil(t, err)
+
+	return &pb.MatchProfile{
+		Name:       "matchProfile",
+		Pools:      []*pb.Pool{{Name: "pool"}},
+		Extensions: map[string]*any.Any{latencyKey: a},
+	}
+}
+
+func ticketIDs(tickets []*pb.Ticket) []string {
+	var ids []string
+	for _, t := range tickets {
+		ids = append(ids, t.Id)
+	}
+	return ids
+}
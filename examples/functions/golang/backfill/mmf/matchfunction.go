@@ -15,38 +15,177 @@
 package mmf
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"log"
 
 	"github.com/golang/protobuf/ptypes"
-	"github.com/golang/protobuf/ptypes/any"
-	"github.com/golang/protobuf/ptypes/wrappers"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/internal/util"
+	"open-match.dev/open-match/pkg/backfill"
 	"open-match.dev/open-match/pkg/matchfunction"
 	"open-match.dev/open-match/pkg/pb"
 )
 
 const (
-	playersPerMatch = 2
-	openSlotsKey    = "open-slots"
-	matchName       = "backfill-matchfunction"
+	rosterKey = "roster"
+	matchName = "backfill-matchfunction"
+
+	// defaultPlayersPerMatch is the size of a full match for profiles without
+	// a players per match, see getPlayersPerMatch.
+	defaultPlayersPerMatch = 2
+
+	// avoidBackfillsKey is the ticket extension listing the ids of backfills
+	// the ticket must not join, such as that of a session the player left.
+	avoidBackfillsKey = "avoid-backfills"
+
+	// minViableMatchSize is the fewest tickets an under-full match with a
+	// backfill may start with, and partialMatchWait is how long the oldest of
+	// those tickets must have waited first, for profiles which don't set them,
+	// see getPartialMatchPolicy.  Raising them trades latency for fuller
+	// matches.
+	minViableMatchSize = 1
+	partialMatchWait   = 0 * time.Second
+
+	// maxTicketsPerMatch is a sanity cap on the size of any proposal for
+	// profiles which don't set one, see getMaxTickets, so a misconfigured
+	// profile, such as one with a huge roster, fails instead of proposing an
+	// absurdly large match.
+	maxTicketsPerMatch = 100
+
+	// dropDuplicateTickets drops proposals reusing a ticket already proposed
+	// in the same Run, rather than only reporting them, for profiles which
+	// don't choose, see getDropDuplicates.
+	dropDuplicateTickets = true
+)
+
+var (
+	mDuplicateTickets = telemetry.Counter("backfill_mmf_duplicate_tickets", "tickets proposed more than once in a run")
 )
 
 type matchFunctionService struct {
 	grpc               *grpc.Server
 	queryServiceClient pb.QueryServiceClient
 	port               int
+	clock              util.Clock
+}
+
+// checkMatchSizes fails if any of the matches has more tickets than the
+// profile's maximum.
+func checkMatchSizes(profile *pb.MatchProfile, matches []*pb.Match) error {
+	maxTickets, err := getMaxTickets(profile)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	for _, m := range matches {
+		if len(m.GetTickets()) > maxTickets {
+			return status.Errorf(codes.InvalidArgument, "profile %q produced match %s with %d tickets, more than the maximum of %d", profile.GetName(), m.GetMatchId(), len(m.GetTickets()), maxTickets)
+		}
+	}
+	return nil
+}
+
+// dedupMatches checks that no ticket of the matches was already proposed in
+// the Run, tracked by seen, which maps ticket ids to the match proposing them.
+// A ticket in several proposals points to a bug in the matching logic, as
+// only one of them can succeed.  Reused tickets are logged and counted, and
+// the proposals reusing them are dropped if drop is set.
+func dedupMatches(ctx context.Context, seen map[string]string, matches []*pb.Match, drop bool) []*pb.Match {
+	kept := matches[:0]
+	for _, m := range matches {
+		var duplicates int64
+		for _, t := range m.GetTickets() {
+			if prev, ok := seen[t.GetId()]; ok {
+				log.Printf("Ticket %s of match %s was already proposed in match %s", t.GetId(), m.GetMatchId(), prev)
+				duplicates++
+			}
+		}
+		if duplicates > 0 {
+			telemetry.RecordNUnitMeasurement(ctx, mDuplicateTickets, duplicates)
+			if drop {
+				log.Printf("Dropping match %s, which reuses %d tickets", m.GetMatchId(), duplicates)
+				continue
+			}
+		}
+
+		for _, t := range m.GetTickets() {
+			if _, ok := seen[t.GetId()]; !ok {
+				seen[t.GetId()] = m.GetMatchId()
+			}
+		}
+		kept = append(kept, m)
+	}
+	return kept
+}
+
+// partialMatchPolicy decides when tickets which can't fill a match on their
+// own are proposed in an under-full match with a backfill.
+type partialMatchPolicy struct {
+	minSize int
+	wait    time.Duration
+}
+
+// allows reports whether the tickets may form an under-full match at now.
+func (p partialMatchPolicy) allows(tickets []*pb.Ticket, now time.Time) bool {
+	if len(tickets) < p.minSize {
+		return false
+	}
+
+	oldest := now
+	for _, t := range tickets {
+		// Tickets without a valid create time are treated as having waited.
+		created, err := ptypes.Timestamp(t.GetCreateTime())
+		if err != nil {
+			return true
+		}
+		if created.Before(oldest) {
+			oldest = created
+		}
+	}
+
+	return now.Sub(oldest) >= p.wait
 }
 
+// Run streams the proposals for each pool back to Open Match as soon as that
+// pool has been processed, rather than buffering the proposals for the whole
+// profile.  Proposals are sent in pool order, and in the order makeMatches
+// returns them within a pool.  Profiles with a roster are matched across all
+// of their pools at once, see runRoster.
 func (s *matchFunctionService) Run(req *pb.RunRequest, stream pb.MatchFunction_RunServer) error {
+	if err := validateProfile(req.GetProfile()); err != nil {
+		log.Printf("Rejecting invalid profile, got %s", err.Error())
+		return err
+	}
+
 	log.Printf("Generating proposals for function %v", req.GetProfile().GetName())
 
-	var proposals []*pb.Match
 	profile := req.GetProfile()
 	pools := profile.GetPools()
 
+	roster, err := getRoster(profile)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if roster != nil {
+		return s.runRoster(profile, roster, stream)
+	}
+
+	partial, err := getPartialMatchPolicy(profile)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	drop, err := getDropDuplicates(profile)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	seen := make(map[string]string)
 	for _, p := range pools {
 		tickets, err := matchfunction.QueryPool(stream.Context(), s.queryServiceClient, p)
 		if err != nil {
@@ -60,18 +199,57 @@ func (s *matchFunctionService) Run(req *pb.RunRequest, stream pb.MatchFunction_R
 			return err
 		}
 
-		matches, err := makeMatches(profile, p, tickets, backfills)
+		matches, err := makeMatches(profile, p, tickets, backfills, partial, s.clock.Now())
 		if err != nil {
 			log.Printf("Failed to generate matches, got %s", err.Error())
 			return err
 		}
+		if err := checkMatchSizes(profile, matches); err != nil {
+			log.Printf("Rejecting oversized matches, got %s", err.Error())
+			return err
+		}
+		matches = dedupMatches(stream.Context(), seen, matches, drop)
+
+		log.Printf("Streaming %v proposals for pool %v to Open Match", len(matches), p.GetName())
+		// Stream the generated proposals back to Open Match.
+		for _, proposal := range matches {
+			if err := stream.Send(&pb.RunResponse{Proposal: proposal}); err != nil {
+				log.Printf("Failed to stream proposals to Open Match, got %s", err.Error())
+				return err
+			}
+		}
+	}
+
+	return nil
+}
 
-		proposals = append(proposals, matches...)
+// runRoster queries the tickets of every pool in the profile, and streams
+// back matches which fill every slot of the roster.  Backfills are not used,
+// as a partially filled match can't guarantee the roster's composition.
+func (s *matchFunctionService) runRoster(profile *pb.MatchProfile, roster []rosterSlot, stream pb.MatchFunction_RunServer) error {
+	poolTickets := make(map[string][]*pb.Ticket)
+	for _, p := range profile.GetPools() {
+		tickets, err := matchfunction.QueryPool(stream.Context(), s.queryServiceClient, p)
+		if err != nil {
+			log.Printf("Failed to query tickets for the given pool, got %s", err.Error())
+			return err
+		}
+		poolTickets[p.GetName()] = tickets
 	}
 
-	log.Printf("Streaming %v proposals to Open Match", len(proposals))
-	// Stream the generated proposals back to Open Match.
-	for _, proposal := range proposals {
+	matches := makeRosterMatches(profile, roster, poolTickets, s.clock.Now())
+	if err := checkMatchSizes(profile, matches); err != nil {
+		log.Printf("Rejecting oversized matches, got %s", err.Error())
+		return err
+	}
+	drop, err := getDropDuplicates(profile)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	matches = dedupMatches(stream.Context(), make(map[string]string), matches, drop)
+
+	log.Printf("Streaming %v roster proposals to Open Match", len(matches))
+	for _, proposal := range matches {
 		if err := stream.Send(&pb.RunResponse{Proposal: proposal}); err != nil {
 			log.Printf("Failed to stream proposals to Open Match, got %s", err.Error())
 			return err
@@ -81,99 +259,398 @@ func (s *matchFunctionService) Run(req *pb.RunRequest, stream pb.MatchFunction_R
 	return nil
 }
 
-func makeMatches(profile *pb.MatchProfile, pool *pb.Pool, tickets []*pb.Ticket, backfills []*pb.Backfill) ([]*pb.Match, error) {
+// validateProfile checks that the profile has a name and at least one pool,
+// and that every pool's filters are well formed.
+func validateProfile(profile *pb.MatchProfile) error {
+	if profile == nil {
+		return status.Error(codes.InvalidArgument, ".profile is required")
+	}
+	if profile.GetName() == "" {
+		return status.Error(codes.InvalidArgument, ".profile.name is required")
+	}
+	if len(profile.GetPools()) == 0 {
+		return status.Error(codes.InvalidArgument, ".profile.pools requires at least one pool")
+	}
+
+	for i, p := range profile.GetPools() {
+		if p == nil {
+			return status.Errorf(codes.InvalidArgument, ".profile.pools[%d] is required", i)
+		}
+		for _, f := range p.GetDoubleRangeFilters() {
+			if f.GetDoubleArg() == "" {
+				return status.Errorf(codes.InvalidArgument, "pool %q has a double range filter without double_arg", p.GetName())
+			}
+			if f.GetMin() > f.GetMax() {
+				return status.Errorf(codes.InvalidArgument, "pool %q has a double range filter on %q with min greater than max", p.GetName(), f.GetDoubleArg())
+			}
+			if f.GetMin() == f.GetMax() && f.GetExclude() != pb.DoubleRangeFilter_NONE {
+				return status.Errorf(codes.InvalidArgument, "pool %q has a double range filter on %q which excludes its only value", p.GetName(), f.GetDoubleArg())
+			}
+		}
+		for _, f := range p.GetStringEqualsFilters() {
+			if f.GetStringArg() == "" {
+				return status.Errorf(codes.InvalidArgument, "pool %q has a string equals filter without string_arg", p.GetName())
+			}
+		}
+		for _, f := range p.GetTagPresentFilters() {
+			if f.GetTag() == "" {
+				return status.Errorf(codes.InvalidArgument, "pool %q has a tag present filter without tag", p.GetName())
+			}
+		}
+		if p.GetCreatedBefore() != nil {
+			if _, err := ptypes.Timestamp(p.GetCreatedBefore()); err != nil {
+				return status.Errorf(codes.InvalidArgument, "pool %q has an invalid created_before value", p.GetName())
+			}
+		}
+		if p.GetCreatedAfter() != nil {
+			if _, err := ptypes.Timestamp(p.GetCreatedAfter()); err != nil {
+				return status.Errorf(codes.InvalidArgument, "pool %q has an invalid created_after value", p.GetName())
+			}
+		}
+	}
+
+	if _, err := getRoster(profile); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := getLatencyGrouping(profile); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := getTeamBalance(profile); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := getAging(profile); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := getMatchOrder(profile); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := getBackfillDoubleArgs(profile); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := getPlayersPerMatch(profile); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := getPartialMatchPolicy(profile); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := getMaxTickets(profile); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := getDropDuplicates(profile); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	size, err := getMatchSize(profile)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if size != nil {
+		for _, key := range []string{latencyKey, teamsKey, playersPerMatchKey} {
+			if _, ok := profile.GetExtensions()[key]; ok {
+				return status.Errorf(codes.InvalidArgument, "match size can't be combined with %s", key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// rosterSlot requires count tickets from the named pool in every match.
+type rosterSlot struct {
+	pool  string
+	count int
+}
+
+// getRoster reads the roster from the profile's "roster" extension, a Struct
+// mapping pool names to the number of tickets each match needs from that
+// pool.  Slots are returned in the profile's pool order.  A profile without a
+// roster returns nil.
+func getRoster(profile *pb.MatchProfile) ([]rosterSlot, error) {
+	a, ok := profile.GetExtensions()[rosterKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var val structpb.Struct
+	if err := ptypes.UnmarshalAny(a, &val); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal roster: %w", err)
+	}
+
+	var roster []rosterSlot
+	for _, p := range profile.GetPools() {
+		v, ok := val.GetFields()[p.GetName()]
+		if !ok {
+			continue
+		}
+		n, ok := v.GetKind().(*structpb.Value_NumberValue)
+		if !ok || n.NumberValue < 1 || n.NumberValue != float64(int(n.NumberValue)) {
+			return nil, fmt.Errorf("roster slot for pool %q must be a positive whole number", p.GetName())
+		}
+		roster = append(roster, rosterSlot{pool: p.GetName(), count: int(n.NumberValue)})
+	}
+
+	if len(roster) != len(val.GetFields()) {
+		return nil, fmt.Errorf("roster references a pool which is not in the profile")
+	}
+	if len(roster) == 0 {
+		return nil, fmt.Errorf("roster requires at least one slot")
+	}
+
+	return roster, nil
+}
+
+// makeRosterMatches forms matches which take the required number of tickets
+// from each slot's pool, in pool order.  A ticket in several pools is used at
+// most once.  Matching stops at the first roster which can't be filled, so
+// every returned match conforms to the roster.
+func makeRosterMatches(profile *pb.MatchProfile, roster []rosterSlot, poolTickets map[string][]*pb.Ticket, now time.Time) []*pb.Match {
+	used := make(map[string]bool)
+	next := make(map[string]int)
 	var matches []*pb.Match
-	newMatches, remainingTickets, err := handleBackfills(profile, tickets, backfills, len(matches))
+
+	for {
+		var matchTickets []*pb.Ticket
+		picked := make(map[string]bool)
+		for _, slot := range roster {
+			tickets := poolTickets[slot.pool]
+			need := slot.count
+			i := next[slot.pool]
+			for ; need > 0 && i < len(tickets); i++ {
+				id := tickets[i].GetId()
+				if used[id] || picked[id] {
+					continue
+				}
+				picked[id] = true
+				matchTickets = append(matchTickets, tickets[i])
+				need--
+			}
+			if need > 0 {
+				return matches
+			}
+			next[slot.pool] = i
+		}
+
+		for id := range picked {
+			used[id] = true
+		}
+		match := newMatch(len(matches)+1, profile, matchTickets, nil, now)
+		matches = append(matches, match)
+	}
+}
+
+func makeMatches(profile *pb.MatchProfile, pool *pb.Pool, tickets []*pb.Ticket, backfills []*pb.Backfill, partial partialMatchPolicy, now time.Time) ([]*pb.Match, error) {
+	// Tickets are otherwise taken in query order, which can starve tickets
+	// when newer ones keep arriving.
+	aging, err := getAging(profile)
 	if err != nil {
 		return nil, err
 	}
+	if aging != nil {
+		tickets = aging.sort(tickets, now)
+	}
 
-	matches = append(matches, newMatches...)
-	newMatches, remainingTickets = makeFullMatches(profile, remainingTickets, len(matches))
-	matches = append(matches, newMatches...)
+	order, err := getMatchOrder(profile)
+	if err != nil {
+		return nil, err
+	}
 
-	if len(remainingTickets) > 0 {
-		match, err := makeMatchWithBackfill(profile, pool, remainingTickets, len(matches))
+	var matches []*pb.Match
+	if order == matchOrderMatches {
+		// Only full matches are formed here, the leftovers go to the
+		// backfills before any of them start an under-full match.
+		newMatches, remainingTickets, err := makeNewMatches(profile, pool, tickets, len(matches), nil, now)
 		if err != nil {
 			return nil, err
 		}
+		matches = append(matches, newMatches...)
+		tickets = remainingTickets
+	}
+
+	newMatches, remainingTickets, err := handleBackfills(profile, tickets, backfills, len(matches), now)
+	if err != nil {
+		return nil, err
+	}
+	matches = append(matches, newMatches...)
+
+	newMatches, _, err = makeNewMatches(profile, pool, remainingTickets, len(matches), &partial, now)
+	if err != nil {
+		return nil, err
+	}
+	return append(matches, newMatches...), nil
+}
+
+// makeNewMatches forms matches of the tickets without existing backfills, and
+// returns the tickets left over.  Under-full matches with a new backfill are
+// only formed when allowed by partial, a nil partial forms full matches only.
+func makeNewMatches(profile *pb.MatchProfile, pool *pb.Pool, tickets []*pb.Ticket, lastMatchId int, partial *partialMatchPolicy, now time.Time) ([]*pb.Match, []*pb.Ticket, error) {
+	size, err := getMatchSize(profile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if size != nil {
+		if partial == nil {
+			// Fewer than max tickets are left over once the full matches
+			// are formed, so none of them can start a match.
+			size = &matchSize{min: size.max, max: size.max}
+		}
+		return makeSizedMatches(profile, pool, *size, tickets, lastMatchId, now)
+	}
+
+	players, err := getPlayersPerMatch(profile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var matches []*pb.Match
+	latency, err := getLatencyGrouping(profile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if latency != nil {
+		matches, tickets = makeLatencyMatches(profile, latency, players, tickets, lastMatchId, now)
+	} else {
+		matches, tickets = makeFullMatches(profile, players, tickets, lastMatchId, now)
+	}
+
+	teams, err := getTeamBalance(profile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if teams != nil {
+		for _, m := range matches {
+			if err := teams.balance(m); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	// Latency grouping can leave over a full match worth of tickets which are
+	// too far apart to play together, those wait for other tickets instead.
+	if partial != nil && len(tickets) > 0 && len(tickets) < players && partial.allows(tickets, now) {
+		match, err := makeMatchWithBackfill(profile, pool, players, tickets, lastMatchId+len(matches), now)
+		if err != nil {
+			return nil, nil, err
+		}
 
 		matches = append(matches, match)
+		tickets = nil
 	}
 
-	return matches, nil
+	return matches, tickets, nil
 }
 
-func handleBackfills(profile *pb.MatchProfile, tickets []*pb.Ticket, backfills []*pb.Backfill, lastMatchId int) ([]*pb.Match, []*pb.Ticket, error) {
+func handleBackfills(profile *pb.MatchProfile, tickets []*pb.Ticket, backfills []*pb.Backfill, lastMatchId int, now time.Time) ([]*pb.Match, []*pb.Ticket, error) {
+	players, err := getPlayersPerMatch(profile)
+	if err != nil {
+		return nil, tickets, err
+	}
+
 	matchId := lastMatchId
 	var matches []*pb.Match
 
 	for _, b := range backfills {
-		openSlots, err := getOpenSlots(b)
+		openSlots, err := backfill.OpenSlots(b)
+		if err == backfill.ErrNoOpenSlots {
+			// Backfills without open slots recorded are assumed to be empty.
+			openSlots, err = int32(players), nil
+		}
 		if err != nil {
 			return nil, tickets, err
 		}
 
-		var matchTickets []*pb.Ticket
-		for openSlots > 0 && len(tickets) > 0 {
-			matchTickets = append(matchTickets, tickets[0])
-			tickets = tickets[1:]
-			openSlots--
+		var matchTickets, skipped []*pb.Ticket
+		for _, t := range tickets {
+			if openSlots > 0 && !avoidsBackfill(t, b.GetId()) {
+				matchTickets = append(matchTickets, t)
+				openSlots--
+			} else {
+				skipped = append(skipped, t)
+			}
 		}
+		tickets = skipped
 
 		if len(matchTickets) > 0 {
-			err := setOpenSlots(b, openSlots)
+			err := backfill.SetOpenSlots(b, openSlots)
 			if err != nil {
 				return nil, tickets, err
 			}
 
 			matchId++
-			match := newMatch(matchId, profile.Name, matchTickets, b)
-			matches = append(matches, &match)
+			match := newMatch(matchId, profile, matchTickets, b, now)
+			matches = append(matches, match)
 		}
 	}
 
 	return matches, tickets, nil
 }
 
-func makeMatchWithBackfill(profile *pb.MatchProfile, pool *pb.Pool, tickets []*pb.Ticket, lastMatchId int) (*pb.Match, error) {
+// avoidsBackfill reports whether the ticket's "avoid-backfills" extension, a
+// ListValue of backfill ids, names the backfill.  A malformed extension is
+// ignored rather than keeping the ticket out of every backfill.
+func avoidsBackfill(t *pb.Ticket, backfillID string) bool {
+	a, ok := t.GetExtensions()[avoidBackfillsKey]
+	if !ok || backfillID == "" {
+		return false
+	}
+
+	var ids structpb.ListValue
+	if err := ptypes.UnmarshalAny(a, &ids); err != nil {
+		log.Printf("Ignoring malformed %s extension of ticket %s, got %s", avoidBackfillsKey, t.GetId(), err.Error())
+		return false
+	}
+
+	for _, v := range ids.GetValues() {
+		if v.GetStringValue() == backfillID {
+			return true
+		}
+	}
+	return false
+}
+
+// makeMatchWithBackfill forms an under-full match of the tickets, with a new
+// backfill for the remaining slots of a match of players tickets.
+func makeMatchWithBackfill(profile *pb.MatchProfile, pool *pb.Pool, players int, tickets []*pb.Ticket, lastMatchId int, now time.Time) (*pb.Match, error) {
 	if len(tickets) == 0 {
 		return nil, fmt.Errorf("tickets are required")
 	}
 
-	if len(tickets) >= playersPerMatch {
+	if len(tickets) >= players {
 		return nil, fmt.Errorf("too many tickets")
 	}
 
 	matchId := lastMatchId
-	searchFields := newSearchFields(pool)
-	backfill, err := newBackfill(searchFields, playersPerMatch-len(tickets))
+	searchFields, err := newBackfillSearchFields(profile, pool, tickets)
+	if err != nil {
+		return nil, err
+	}
+	backfill, err := newBackfill(searchFields, players-len(tickets), now)
 	if err != nil {
 		return nil, err
 	}
 
 	matchId++
-	match := newMatch(matchId, profile.Name, tickets, backfill)
+	match := newMatch(matchId, profile, tickets, backfill, now)
 	match.AllocateGameserver = true
 
-	return &match, nil
+	return match, nil
 }
 
-func makeFullMatches(profile *pb.MatchProfile, tickets []*pb.Ticket, lastMatchId int) ([]*pb.Match, []*pb.Ticket) {
+// makeFullMatches forms matches of players tickets, in order, and returns the
+// tickets left over.
+func makeFullMatches(profile *pb.MatchProfile, players int, tickets []*pb.Ticket, lastMatchId int, now time.Time) ([]*pb.Match, []*pb.Ticket) {
 	ticketNum := 0
 	matchId := lastMatchId
 	var matches []*pb.Match
 
-	for ticketNum < playersPerMatch && len(tickets) >= playersPerMatch {
+	for ticketNum < players && len(tickets) >= players {
 		ticketNum++
 
-		if ticketNum == playersPerMatch {
+		if ticketNum == players {
 			matchId++
 
-			match := newMatch(matchId, profile.Name, tickets[:playersPerMatch], nil)
-			matches = append(matches, &match)
+			match := newMatch(matchId, profile, tickets[:players], nil, now)
+			matches = append(matches, match)
 
-			tickets = tickets[playersPerMatch:]
+			tickets = tickets[players:]
 			ticketNum = 0
 		}
 	}
@@ -181,6 +658,20 @@ func makeFullMatches(profile *pb.MatchProfile, tickets []*pb.Ticket, lastMatchId
 	return matches, tickets
 }
 
+// doubleRangeValue returns the value a new backfill takes for the double arg
+// of the filter.  A value on an excluded bound is replaced by the middle of
+// the range, so that the backfill still belongs to the pool.
+func doubleRangeValue(f *pb.DoubleRangeFilter) float64 {
+	v := (f.Max - f.Min) / 2
+
+	excludeMin := f.Exclude == pb.DoubleRangeFilter_MIN || f.Exclude == pb.DoubleRangeFilter_BOTH
+	excludeMax := f.Exclude == pb.DoubleRangeFilter_MAX || f.Exclude == pb.DoubleRangeFilter_BOTH
+	if (excludeMin && v == f.Min) || (excludeMax && v == f.Max) {
+		return f.Min + (f.Max-f.Min)/2
+	}
+	return v
+}
+
 func newSearchFields(pool *pb.Pool) *pb.SearchFields {
 	searchFields := pb.SearchFields{}
 	rangeFilters := pool.GetDoubleRangeFilters()
@@ -188,7 +679,7 @@ func newSearchFields(pool *pb.Pool) *pb.SearchFields {
 	if rangeFilters != nil {
 		doubleArgs := make(map[string]float64)
 		for _, f := range rangeFilters {
-			doubleArgs[f.DoubleArg] = (f.Max - f.Min) / 2
+			doubleArgs[f.DoubleArg] = doubleRangeValue(f)
 		}
 
 		if len(doubleArgs) > 0 {
@@ -212,7 +703,7 @@ func newSearchFields(pool *pb.Pool) *pb.SearchFields {
 	tagFilters := pool.GetTagPresentFilters()
 
 	if tagFilters != nil {
-		tags := make([]string, len(tagFilters))
+		tags := make([]string, 0, len(tagFilters))
 		for _, f := range tagFilters {
 			tags = append(tags, f.Tag)
 		}
@@ -225,59 +716,50 @@ func newSearchFields(pool *pb.Pool) *pb.SearchFields {
 	return &searchFields
 }
 
-func newBackfill(searchFields *pb.SearchFields, openSlots int) (*pb.Backfill, error) {
+func newBackfill(searchFields *pb.SearchFields, openSlots int, now time.Time) (*pb.Backfill, error) {
+	createTime, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return nil, err
+	}
+
 	b := pb.Backfill{
 		SearchFields: searchFields,
 		Generation:   0,
-		CreateTime:   ptypes.TimestampNow(),
+		CreateTime:   createTime,
 	}
 
-	err := setOpenSlots(&b, int32(openSlots))
+	err = backfill.SetOpenSlots(&b, int32(openSlots))
 	return &b, err
 }
 
-func newMatch(num int, profile string, tickets []*pb.Ticket, b *pb.Backfill) pb.Match {
-	t := time.Now().Format("2006-01-02T15:04:05.00")
+// newMatch returns a match of the tickets for the profile.  Matches for a
+// profile with a roster record the roster slot of each ticket, see
+// setRosterSlots.
+func newMatch(num int, profile *pb.MatchProfile, tickets []*pb.Ticket, b *pb.Backfill, now time.Time) *pb.Match {
+	t := now.Format("2006-01-02T15:04:05.00")
 
-	return pb.Match{
+	match := &pb.Match{
 		MatchId:       fmt.Sprintf("profile-%s-time-%s-num-%d", matchName, t, num),
-		MatchProfile:  profile,
+		MatchProfile:  profile.GetName(),
 		MatchFunction: matchName,
 		Tickets:       tickets,
 		Backfill:      b,
 	}
-}
 
-func setOpenSlots(b *pb.Backfill, val int32) error {
-	if b.Extensions == nil {
-		b.Extensions = make(map[string]*any.Any)
+	// The roster was validated with the profile, and matches for a roster
+	// only take tickets from its pools, so failing here is a bug.  The match
+	// is still usable without the slots.
+	roster, err := getRoster(profile)
+	if err == nil && roster != nil {
+		var slots map[string][]string
+		slots, err = assignRosterSlots(profile, roster, tickets)
+		if err == nil {
+			err = setRosterSlots(match, slots)
+		}
 	}
-
-	any, err := ptypes.MarshalAny(&wrappers.Int32Value{Value: val})
 	if err != nil {
-		return err
-	}
-
-	b.Extensions[openSlotsKey] = any
-	return nil
-}
-
-func getOpenSlots(b *pb.Backfill) (int32, error) {
-	if b == nil {
-		return 0, fmt.Errorf("expected backfill is not nil")
-	}
-
-	if b.Extensions != nil {
-		if any, ok := b.Extensions[openSlotsKey]; ok {
-			var val wrappers.Int32Value
-			err := ptypes.UnmarshalAny(any, &val)
-			if err != nil {
-				return 0, err
-			}
-
-			return val.Value, nil
-		}
+		log.Printf("Failed to record the roster slots of match %s, got %s", match.MatchId, err.Error())
 	}
 
-	return playersPerMatch, nil
+	return match
 }
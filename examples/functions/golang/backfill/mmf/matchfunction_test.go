@@ -15,12 +15,22 @@
 package mmf
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/backfill"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -37,14 +47,14 @@ func TestHandleBackfills(t *testing.T) {
 	}{
 		{name: "returns no matches when no backfills specified", expectedMatchLen: 0, expectedTicketLen: 0},
 		{name: "returns no matches when no tickets specified", expectedMatchLen: 0, expectedTicketLen: 0},
-		{name: "returns a match with open slots decreased", tickets: []*pb.Ticket{{Id: "1"}}, backfills: []*pb.Backfill{withOpenSlots(1)}, expectedMatchLen: 1, expectedTicketLen: 0, expectedOpenSlots: playersPerMatch - 2},
+		{name: "returns a match with open slots decreased", tickets: []*pb.Ticket{{Id: "1"}}, backfills: []*pb.Backfill{withOpenSlots(1)}, expectedMatchLen: 1, expectedTicketLen: 0, expectedOpenSlots: defaultPlayersPerMatch - 2},
 	} {
 		testCase := tc
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
 
 			profile := pb.MatchProfile{Name: "matchProfile"}
-			matches, tickets, err := handleBackfills(&profile, testCase.tickets, testCase.backfills, testCase.lastMatchId)
+			matches, tickets, err := handleBackfills(&profile, testCase.tickets, testCase.backfills, testCase.lastMatchId, time.Now())
 			require.Equal(t, testCase.expectedErr, err != nil)
 			require.Equal(t, testCase.expectedTicketLen, len(tickets))
 
@@ -54,7 +64,7 @@ func TestHandleBackfills(t *testing.T) {
 				for _, m := range matches {
 					require.NotNil(t, m.Backfill)
 
-					openSlots, err := getOpenSlots(m.Backfill)
+					openSlots, err := backfill.OpenSlots(m.Backfill)
 					require.NoError(t, err)
 					require.Equal(t, testCase.expectedOpenSlots, openSlots)
 				}
@@ -63,6 +73,35 @@ func TestHandleBackfills(t *testing.T) {
 	}
 }
 
+func TestHandleBackfillsAvoidsBackfills(t *testing.T) {
+	abandoned := withOpenSlots(1)
+	abandoned.Id = "abandoned"
+	other := withOpenSlots(1)
+	other.Id = "other"
+
+	ids, err := ptypes.MarshalAny(&structpb.ListValue{Values: []*structpb.Value{
+		{Kind: &structpb.Value_StringValue{StringValue: "abandoned"}},
+	}})
+	require.NoError(t, err)
+	leaver := &pb.Ticket{Id: "leaver", Extensions: map[string]*any.Any{avoidBackfillsKey: ids}}
+
+	profile := pb.MatchProfile{Name: "matchProfile"}
+	matches, tickets, err := handleBackfills(&profile, []*pb.Ticket{leaver}, []*pb.Backfill{abandoned, other}, 0, time.Now())
+	require.NoError(t, err)
+	require.Empty(t, tickets)
+	require.Len(t, matches, 1)
+	require.Equal(t, "other", matches[0].Backfill.Id)
+	require.Equal(t, []string{"leaver"}, ticketIDs(matches[0].Tickets))
+
+	// The abandoned backfill is left for other tickets, and tickets keep their
+	// order otherwise.
+	matches, tickets, err = handleBackfills(&profile, []*pb.Ticket{leaver, {Id: "1"}, {Id: "2"}}, []*pb.Backfill{abandoned}, 0, time.Now())
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, []string{"1"}, ticketIDs(matches[0].Tickets))
+	require.Equal(t, []string{"leaver", "2"}, ticketIDs(tickets))
+}
+
 func TestMakeMatchWithBackfill(t *testing.T) {
 	for _, testCase := range []struct {
 		name              string
@@ -74,7 +113,7 @@ func TestMakeMatchWithBackfill(t *testing.T) {
 		{name: "returns an error when length of tickets is greater then playerPerMatch", tickets: []*pb.Ticket{{Id: "1"}, {Id: "2"}, {Id: "3"}, {Id: "4"}, {Id: "5"}}, expectedErr: true},
 		{name: "returns an error when length of tickets is equal to playerPerMatch", tickets: []*pb.Ticket{{Id: "1"}, {Id: "2"}, {Id: "3"}, {Id: "4"}}, expectedErr: true},
 		{name: "returns an error when no tickets are provided", expectedErr: true},
-		{name: "returns a match with backfill", tickets: []*pb.Ticket{{Id: "1"}}, expectedOpenSlots: playersPerMatch - 1},
+		{name: "returns a match with backfill", tickets: []*pb.Ticket{{Id: "1"}}, expectedOpenSlots: defaultPlayersPerMatch - 1},
 	} {
 		testCase := testCase
 		t.Run(testCase.name, func(t *testing.T) {
@@ -82,7 +121,8 @@ func TestMakeMatchWithBackfill(t *testing.T) {
 
 			pool := pb.Pool{}
 			profile := pb.MatchProfile{Name: "matchProfile"}
-			match, err := makeMatchWithBackfill(&profile, &pool, testCase.tickets, testCase.lastMatchId)
+			now := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+			match, err := makeMatchWithBackfill(&profile, &pool, defaultPlayersPerMatch, testCase.tickets, testCase.lastMatchId, now)
 			require.Equal(t, testCase.expectedErr, err != nil)
 
 			if err == nil {
@@ -90,8 +130,13 @@ func TestMakeMatchWithBackfill(t *testing.T) {
 				require.NotNil(t, match.Backfill)
 				require.True(t, match.AllocateGameserver)
 				require.Equal(t, "", match.Backfill.Id)
+				require.Equal(t, "profile-backfill-matchfunction-time-2020-03-01T12:00:00.00-num-1", match.MatchId)
 
-				openSlots, err := getOpenSlots(match.Backfill)
+				createTime, err := ptypes.Timestamp(match.Backfill.CreateTime)
+				require.Nil(t, err)
+				require.True(t, now.Equal(createTime))
+
+				openSlots, err := backfill.OpenSlots(match.Backfill)
 				require.Nil(t, err)
 				require.Equal(t, testCase.expectedOpenSlots, openSlots)
 			}
@@ -109,26 +154,390 @@ func TestMakeFullMatches(t *testing.T) {
 		expectedTicketLen int
 	}{
 		{name: "returns no matches when there are no tickets", tickets: []*pb.Ticket{}, expectedMatchLen: 0, expectedTicketLen: 0},
-		{name: "returns no matches when lenght of tickets is less then playersPerMatch", tickets: []*pb.Ticket{{Id: "1"}}, expectedMatchLen: 0, expectedTicketLen: 1},
-		{name: "returns a match when length of tickets is greater then playersPerMatch", tickets: []*pb.Ticket{{Id: "1"}, {Id: "2"}}, expectedMatchLen: 1, expectedTicketLen: 0},
+		{name: "returns no matches when lenght of tickets is less then defaultPlayersPerMatch", tickets: []*pb.Ticket{{Id: "1"}}, expectedMatchLen: 0, expectedTicketLen: 1},
+		{name: "returns a match when length of tickets is greater then defaultPlayersPerMatch", tickets: []*pb.Ticket{{Id: "1"}, {Id: "2"}}, expectedMatchLen: 1, expectedTicketLen: 0},
 	} {
 		testCase := testCase
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
 			profile := pb.MatchProfile{Name: "matchProfile"}
-			matches, tickets := makeFullMatches(&profile, testCase.tickets, testCase.lastMatchId)
+			matches, tickets := makeFullMatches(&profile, defaultPlayersPerMatch, testCase.tickets, testCase.lastMatchId, time.Now())
 
 			require.Equal(t, testCase.expectedMatchLen, len(matches))
 			require.Equal(t, testCase.expectedTicketLen, len(tickets))
 
 			for _, m := range matches {
 				require.Nil(t, m.Backfill)
-				require.Equal(t, playersPerMatch, len(m.Tickets))
+				require.Equal(t, defaultPlayersPerMatch, len(m.Tickets))
+			}
+		})
+	}
+}
+
+func TestMakeMatchesPartialMatchPolicy(t *testing.T) {
+	now := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+	createdAgo := func(d time.Duration) []*pb.Ticket {
+		ts, err := ptypes.TimestampProto(now.Add(-d))
+		require.Nil(t, err)
+		return []*pb.Ticket{{Id: "1", CreateTime: ts}}
+	}
+
+	for _, testCase := range []struct {
+		name          string
+		tickets       []*pb.Ticket
+		policy        partialMatchPolicy
+		expectedMatch bool
+	}{
+		{name: "forms an under-full match after the wait", tickets: createdAgo(2 * time.Minute), policy: partialMatchPolicy{minSize: 1, wait: time.Minute}, expectedMatch: true},
+		{name: "forms no match before the wait", tickets: createdAgo(30 * time.Second), policy: partialMatchPolicy{minSize: 1, wait: time.Minute}},
+		{name: "forms no match below the min size", tickets: createdAgo(2 * time.Minute), policy: partialMatchPolicy{minSize: defaultPlayersPerMatch, wait: time.Minute}},
+	} {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := pb.Pool{}
+			profile := pb.MatchProfile{Name: "matchProfile"}
+			matches, err := makeMatches(&profile, &pool, testCase.tickets, nil, testCase.policy, now)
+			require.Nil(t, err)
+
+			if !testCase.expectedMatch {
+				require.Empty(t, matches)
+				return
+			}
+
+			require.Len(t, matches, 1)
+			require.Len(t, matches[0].Tickets, len(testCase.tickets))
+			require.NotNil(t, matches[0].Backfill)
+
+			openSlots, err := backfill.OpenSlots(matches[0].Backfill)
+			require.Nil(t, err)
+			require.Equal(t, int32(defaultPlayersPerMatch-len(testCase.tickets)), openSlots)
+		})
+	}
+}
+
+func TestRunStreamsProposalsPerPool(t *testing.T) {
+	query := &fakeQueryServiceClient{
+		tickets: map[string][]*pb.Ticket{
+			"pool-1": {{Id: "1"}, {Id: "2"}},
+			"pool-2": {{Id: "3"}, {Id: "4"}},
+		},
+	}
+	stream := &fakeRunServer{query: query}
+	s := matchFunctionService{queryServiceClient: query, clock: utilTesting.NewFakeClock(time.Now())}
+
+	req := &pb.RunRequest{
+		Profile: &pb.MatchProfile{
+			Name:  "matchProfile",
+			Pools: []*pb.Pool{{Name: "pool-1"}, {Name: "pool-2"}},
+		},
+	}
+	require.NoError(t, s.Run(req, stream))
+
+	// The proposal for the first pool is sent before the second pool is queried.
+	require.Equal(t, []int{1, 2}, stream.queriedPools)
+	require.Equal(t, "1", stream.proposals[0].Tickets[0].Id)
+	require.Equal(t, "3", stream.proposals[1].Tickets[0].Id)
+}
+
+func TestRunDropsDuplicateTickets(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		dropDuplicates  bool
+		expectedMatches [][]string
+	}{
+		{name: "drops the proposal reusing a ticket", dropDuplicates: true, expectedMatches: [][]string{{"1", "2"}, {"4", "5"}}},
+		{name: "only reports the proposal reusing a ticket", expectedMatches: [][]string{{"1", "2"}, {"2", "3"}, {"4", "5"}}},
+	} {
+		testCase := tc
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Ticket 2 is in both pools, so it is matched once for each.
+			query := &fakeQueryServiceClient{
+				tickets: map[string][]*pb.Ticket{
+					"pool-1": {{Id: "1"}, {Id: "2"}},
+					"pool-2": {{Id: "2"}, {Id: "3"}, {Id: "4"}, {Id: "5"}},
+				},
 			}
+			stream := &fakeRunServer{query: query}
+			s := matchFunctionService{queryServiceClient: query, clock: utilTesting.NewFakeClock(time.Now())}
+
+			profile := duplicateTicketsProfile(t, testCase.dropDuplicates)
+			profile.Pools = []*pb.Pool{{Name: "pool-1"}, {Name: "pool-2"}}
+			require.NoError(t, s.Run(&pb.RunRequest{Profile: profile}, stream))
+
+			var matches [][]string
+			for _, m := range stream.proposals {
+				matches = append(matches, ticketIDs(m.Tickets))
+			}
+			require.Equal(t, testCase.expectedMatches, matches)
 		})
 	}
 }
 
+func TestDedupMatches(t *testing.T) {
+	seen := map[string]string{"1": "earlier"}
+
+	matches := dedupMatches(context.Background(), seen, []*pb.Match{
+		{MatchId: "a", Tickets: []*pb.Ticket{{Id: "1"}, {Id: "2"}}},
+		{MatchId: "b", Tickets: []*pb.Ticket{{Id: "3"}, {Id: "4"}}},
+		{MatchId: "c", Tickets: []*pb.Ticket{{Id: "4"}, {Id: "5"}}},
+	}, true)
+	require.Len(t, matches, 1)
+	require.Equal(t, "b", matches[0].MatchId)
+	require.Equal(t, map[string]string{"1": "earlier", "3": "b", "4": "b"}, seen)
+}
+
+func TestGetDropDuplicates(t *testing.T) {
+	drop, err := getDropDuplicates(&pb.MatchProfile{})
+	require.NoError(t, err)
+	require.Equal(t, dropDuplicateTickets, drop)
+
+	drop, err = getDropDuplicates(duplicateTicketsProfile(t, false))
+	require.NoError(t, err)
+	require.False(t, drop)
+
+	a, err := ptypes.MarshalAny(&structpb.Struct{Fields: map[string]*structpb.Value{
+		"drop": {Kind: &structpb.Value_StringValue{StringValue: "yes"}},
+	}})
+	require.Nil(t, err)
+	profile := duplicateTicketsProfile(t, true)
+	profile.Extensions[duplicateTicketsKey] = a
+	require.Error(t, validateProfile(profile))
+}
+
+// duplicateTicketsProfile returns a profile which drops the proposals reusing
+// a ticket if drop is set, and otherwise only reports them.
+func duplicateTicketsProfile(t *testing.T, drop bool) *pb.MatchProfile {
+	a, err := ptypes.MarshalAny(&structpb.Struct{Fields: map[string]*structpb.Value{
+		"drop": {Kind: &structpb.Value_BoolValue{BoolValue: drop}},
+	}})
+	require.Nil(t, err)
+
+	return &pb.MatchProfile{
+		Name:       "matchProfile",
+		Pools:      []*pb.Pool{{Name: "pool"}},
+		Extensions: map[string]*any.Any{duplicateTicketsKey: a},
+	}
+}
+
+func TestRunRejectsInvalidProfile(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		profile *pb.MatchProfile
+	}{
+		{name: "nil profile"},
+		{name: "profile without name", profile: &pb.MatchProfile{Pools: []*pb.Pool{{Name: "pool"}}}},
+		{name: "profile without pools", profile: &pb.MatchProfile{Name: "matchProfile"}},
+		{name: "nil pool", profile: &pb.MatchProfile{Name: "matchProfile", Pools: []*pb.Pool{nil}}},
+		{name: "inverted double range", profile: &pb.MatchProfile{Name: "matchProfile", Pools: []*pb.Pool{{
+			Name:               "pool",
+			DoubleRangeFilters: []*pb.DoubleRangeFilter{{DoubleArg: "mmr", Min: 10, Max: 1}},
+		}}}},
+		{name: "empty exclusive double range", profile: &pb.MatchProfile{Name: "matchProfile", Pools: []*pb.Pool{{
+			Name:               "pool",
+			DoubleRangeFilters: []*pb.DoubleRangeFilter{{DoubleArg: "mmr", Min: 10, Max: 10, Exclude: pb.DoubleRangeFilter_MAX}},
+		}}}},
+		{name: "roster with unknown pool", profile: rosterProfile(t, map[string]float64{"pool-a": 2, "pool-c": 1})},
+		{name: "roster with fractional slot", profile: rosterProfile(t, map[string]float64{"pool-a": 1.5})},
+	} {
+		testCase := tc
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			query := &fakeQueryServiceClient{}
+			stream := &fakeRunServer{query: query}
+			s := matchFunctionService{queryServiceClient: query, clock: utilTesting.NewFakeClock(time.Now())}
+
+			err := s.Run(&pb.RunRequest{Profile: testCase.profile}, stream)
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+			require.Equal(t, 0, query.queried)
+			require.Empty(t, stream.proposals)
+		})
+	}
+}
+
+func TestRunHonorsRoster(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		tickets         map[string][]*pb.Ticket
+		expectedMatches [][]string
+	}{
+		{
+			name: "forms matches while the roster can be filled",
+			tickets: map[string][]*pb.Ticket{
+				"pool-a": {{Id: "a1"}, {Id: "a2"}, {Id: "a3"}, {Id: "a4"}, {Id: "a5"}},
+				"pool-b": {{Id: "b1"}, {Id: "b2"}, {Id: "b3"}},
+			},
+			expectedMatches: [][]string{{"a1", "a2", "b1"}, {"a3", "a4", "b2"}},
+		},
+		{
+			name: "forms no match when a slot can't be filled",
+			tickets: map[string][]*pb.Ticket{
+				"pool-a": {{Id: "a1"}},
+				"pool-b": {{Id: "b1"}, {Id: "b2"}, {Id: "b3"}},
+			},
+		},
+		{
+			name: "uses a ticket in both pools only once",
+			tickets: map[string][]*pb.Ticket{
+				"pool-a": {{Id: "a1"}, {Id: "ab"}},
+				"pool-b": {{Id: "ab"}},
+			},
+		},
+	} {
+		testCase := tc
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			query := &fakeQueryServiceClient{tickets: testCase.tickets}
+			stream := &fakeRunServer{query: query}
+			s := matchFunctionService{queryServiceClient: query, clock: utilTesting.NewFakeClock(time.Now())}
+
+			profile := rosterProfile(t, map[string]float64{"pool-a": 2, "pool-b": 1})
+			require.NoError(t, s.Run(&pb.RunRequest{Profile: profile}, stream))
+
+			var matches [][]string
+			for _, m := range stream.proposals {
+				require.Nil(t, m.Backfill)
+				var ids []string
+				for _, ticket := range m.Tickets {
+					ids = append(ids, ticket.Id)
+				}
+				matches = append(matches, ids)
+			}
+			require.Equal(t, testCase.expectedMatches, matches)
+		})
+	}
+}
+
+func TestRunRejectsOversizedMatches(t *testing.T) {
+	const rosterSize = 10000
+	tickets := make([]*pb.Ticket, rosterSize)
+	for i := range tickets {
+		tickets[i] = &pb.Ticket{Id: fmt.Sprintf("a%d", i)}
+	}
+
+	query := &fakeQueryServiceClient{tickets: map[string][]*pb.Ticket{"pool-a": tickets}}
+	stream := &fakeRunServer{query: query}
+	s := matchFunctionService{queryServiceClient: query, clock: utilTesting.NewFakeClock(time.Now())}
+
+	profile := rosterProfile(t, map[string]float64{"pool-a": rosterSize})
+	err := s.Run(&pb.RunRequest{Profile: profile}, stream)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "more than the maximum of 100")
+	require.Empty(t, stream.proposals)
+
+	// The same roster is matched by a profile raising the cap.
+	profile.Extensions[maxTicketsKey] = maxTicketsProfile(t, rosterSize).Extensions[maxTicketsKey]
+	require.NoError(t, s.Run(&pb.RunRequest{Profile: profile}, stream))
+	require.Len(t, stream.proposals, 1)
+}
+
+func TestGetMaxTickets(t *testing.T) {
+	maxTickets, err := getMaxTickets(&pb.MatchProfile{})
+	require.NoError(t, err)
+	require.Equal(t, maxTicketsPerMatch, maxTickets)
+
+	maxTickets, err = getMaxTickets(maxTicketsProfile(t, 8))
+	require.NoError(t, err)
+	require.Equal(t, 8, maxTickets)
+
+	require.Error(t, validateProfile(maxTicketsProfile(t, 0)))
+	require.Error(t, validateProfile(maxTicketsProfile(t, 2.5)))
+}
+
+// maxTicketsProfile returns a profile whose proposals have at most count
+// tickets.
+func maxTicketsProfile(t *testing.T, count float64) *pb.MatchProfile {
+	a, err := ptypes.MarshalAny(&structpb.Struct{Fields: map[string]*structpb.Value{
+		"count": {Kind: &structpb.Value_NumberValue{NumberValue: count}},
+	}})
+	require.Nil(t, err)
+
+	return &pb.MatchProfile{
+		Name:       "matchProfile",
+		Pools:      []*pb.Pool{{Name: "pool"}},
+		Extensions: map[string]*any.Any{maxTicketsKey: a},
+	}
+}
+
+// rosterProfile returns a profile with pool-a and pool-b, and the given roster.
+func rosterProfile(t *testing.T, roster map[string]float64) *pb.MatchProfile {
+	val := &structpb.Struct{Fields: make(map[string]*structpb.Value)}
+	for pool, count := range roster {
+		val.Fields[pool] = &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: count}}
+	}
+	a, err := ptypes.MarshalAny(val)
+	require.Nil(t, err)
+
+	return &pb.MatchProfile{
+		Name:       "matchProfile",
+		Pools:      []*pb.Pool{{Name: "pool-a"}, {Name: "pool-b"}},
+		Extensions: map[string]*any.Any{rosterKey: a},
+	}
+}
+
+// fakeQueryServiceClient returns the configured tickets for each pool, and no
+// backfills.
+type fakeQueryServiceClient struct {
+	pb.QueryServiceClient
+	tickets map[string][]*pb.Ticket
+	queried int
+}
+
+func (c *fakeQueryServiceClient) QueryTickets(ctx context.Context, req *pb.QueryTicketsRequest, opts ...grpc.CallOption) (pb.QueryService_QueryTicketsClient, error) {
+	c.queried++
+	return &fakeQueryTicketsClient{tickets: c.tickets[req.GetPool().GetName()]}, nil
+}
+
+func (c *fakeQueryServiceClient) QueryBackfills(ctx context.Context, req *pb.QueryBackfillsRequest, opts ...grpc.CallOption) (pb.QueryService_QueryBackfillsClient, error) {
+	return &fakeQueryBackfillsClient{}, nil
+}
+
+type fakeQueryTicketsClient struct {
+	grpc.ClientStream
+	tickets []*pb.Ticket
+}
+
+func (c *fakeQueryTicketsClient) Recv() (*pb.QueryTicketsResponse, error) {
+	if c.tickets == nil {
+		return nil, io.EOF
+	}
+	resp := &pb.QueryTicketsResponse{Tickets: c.tickets}
+	c.tickets = nil
+	return resp, nil
+}
+
+type fakeQueryBackfillsClient struct {
+	grpc.ClientStream
+}
+
+func (c *fakeQueryBackfillsClient) Recv() (*pb.QueryBackfillsResponse, error) {
+	return nil, io.EOF
+}
+
+// fakeRunServer records the proposals sent, along with how many pools had been
+// queried when each was sent.
+type fakeRunServer struct {
+	grpc.ServerStream
+	query        *fakeQueryServiceClient
+	proposals    []*pb.Match
+	queriedPools []int
+}
+
+func (s *fakeRunServer) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeRunServer) Send(resp *pb.RunResponse) error {
+	s.proposals = append(s.proposals, resp.GetProposal())
+	s.queriedPools = append(s.queriedPools, s.query.queried)
+	return nil
+}
+
 func withOpenSlots(openSlots int) *pb.Backfill {
 	val, err := ptypes.MarshalAny(&wrappers.Int32Value{Value: int32(openSlots)})
 	if err != nil {
@@ -137,7 +546,7 @@ func withOpenSlots(openSlots int) *pb.Backfill {
 
 	return &pb.Backfill{
 		Extensions: map[string]*any.Any{
-			openSlotsKey: val,
+			backfill.OpenSlotsKey: val,
 		},
 	}
 }
@@ -0,0 +1,106 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const matchSizeKey = "match-size"
+
+// matchSize bounds the number of tickets in a match.  Matches start with at
+// least min tickets, and a backfill fills the remaining slots up to max.
+type matchSize struct {
+	min int
+	max int
+}
+
+// getMatchSize reads the bounds from the profile's "match-size" extension, a
+// Struct with "min" and "max" numbers.  A profile without the extension
+// returns nil, and its matches have exactly the profile's players per match,
+// see getPlayersPerMatch.
+func getMatchSize(profile *pb.MatchProfile) (*matchSize, error) {
+	a, ok := profile.GetExtensions()[matchSizeKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var val structpb.Struct
+	if err := ptypes.UnmarshalAny(a, &val); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal match size: %w", err)
+	}
+
+	bound := func(name string) (int, error) {
+		n, ok := val.GetFields()[name].GetKind().(*structpb.Value_NumberValue)
+		if !ok || n.NumberValue < 1 || n.NumberValue != float64(int(n.NumberValue)) {
+			return 0, fmt.Errorf("match size %s must be a positive whole number", name)
+		}
+		return int(n.NumberValue), nil
+	}
+	min, err := bound("min")
+	if err != nil {
+		return nil, err
+	}
+	max, err := bound("max")
+	if err != nil {
+		return nil, err
+	}
+	if min > max {
+		return nil, fmt.Errorf("match size min %d is greater than max %d", min, max)
+	}
+
+	return &matchSize{min: min, max: max}, nil
+}
+
+// makeSizedMatches is makeFullMatches and makeMatchWithBackfill for profiles
+// with a match size.  Full matches of max tickets are formed first, then the
+// remaining tickets form a match with a backfill for the open slots if there
+// are at least min of them.  Fewer than min tickets are left over to wait.
+func makeSizedMatches(profile *pb.MatchProfile, pool *pb.Pool, size matchSize, tickets []*pb.Ticket, lastMatchId int, now time.Time) ([]*pb.Match, []*pb.Ticket, error) {
+	matchId := lastMatchId
+	var matches []*pb.Match
+
+	for len(tickets) >= size.max {
+		matchId++
+		match := newMatch(matchId, profile, tickets[:size.max], nil, now)
+		matches = append(matches, match)
+		tickets = tickets[size.max:]
+	}
+
+	if len(tickets) == 0 || len(tickets) < size.min {
+		return matches, tickets, nil
+	}
+
+	searchFields, err := newBackfillSearchFields(profile, pool, tickets)
+	if err != nil {
+		return nil, nil, err
+	}
+	backfill, err := newBackfill(searchFields, size.max-len(tickets), now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matchId++
+	match := newMatch(matchId, profile, tickets, backfill, now)
+	match.AllocateGameserver = true
+	matches = append(matches, match)
+
+	return matches, nil, nil
+}
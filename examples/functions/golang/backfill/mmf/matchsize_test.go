@@ -0,0 +1,120 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/backfill"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestMakeSizedMatches(t *testing.T) {
+	tickets := func(n int) []*pb.Ticket {
+		var tickets []*pb.Ticket
+		for i := 1; i <= n; i++ {
+			tickets = append(tickets, &pb.Ticket{Id: fmt.Sprint(i)})
+		}
+		return tickets
+	}
+
+	for _, testCase := range []struct {
+		name              string
+		tickets           int
+		expectedSizes     []int
+		expectedOpenSlots int32
+		expectedLeftOver  int
+	}{
+		{name: "returns no matches when there are no tickets"},
+		{name: "returns no matches when there are fewer tickets than min", tickets: 5, expectedLeftOver: 5},
+		{name: "returns a match with backfill at min", tickets: 6, expectedSizes: []int{6}, expectedOpenSlots: 4},
+		{name: "returns a match with backfill between min and max", tickets: 9, expectedSizes: []int{9}, expectedOpenSlots: 1},
+		{name: "returns a full match at max", tickets: 10, expectedSizes: []int{10}},
+		{name: "returns full matches before a match with backfill", tickets: 27, expectedSizes: []int{10, 10, 7}, expectedOpenSlots: 3},
+		{name: "leaves tickets fewer than min after full matches", tickets: 13, expectedSizes: []int{10}, expectedLeftOver: 3},
+	} {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			profile := pb.MatchProfile{Name: "matchProfile"}
+			matches, leftOver, err := makeSizedMatches(&profile, &pb.Pool{}, matchSize{min: 6, max: 10}, tickets(testCase.tickets), 0, time.Now())
+			require.NoError(t, err)
+			require.Len(t, leftOver, testCase.expectedLeftOver)
+			require.Len(t, matches, len(testCase.expectedSizes))
+
+			for i, m := range matches {
+				require.Len(t, m.Tickets, testCase.expectedSizes[i])
+				if len(m.Tickets) == 10 {
+					require.Nil(t, m.Backfill)
+					continue
+				}
+
+				require.NotNil(t, m.Backfill)
+				require.True(t, m.AllocateGameserver)
+				openSlots, err := backfill.OpenSlots(m.Backfill)
+				require.NoError(t, err)
+				require.Equal(t, testCase.expectedOpenSlots, openSlots)
+			}
+		})
+	}
+}
+
+func TestMakeMatchesHonorsMatchSize(t *testing.T) {
+	profile := matchSizeProfile(t, 2, 3)
+	tickets := []*pb.Ticket{{Id: "1"}, {Id: "2"}, {Id: "3"}, {Id: "4"}, {Id: "5"}}
+
+	matches, err := makeMatches(profile, profile.Pools[0], tickets, nil, partialMatchPolicy{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	require.Equal(t, []string{"1", "2", "3"}, ticketIDs(matches[0].Tickets))
+	require.Nil(t, matches[0].Backfill)
+	require.Equal(t, []string{"4", "5"}, ticketIDs(matches[1].Tickets))
+	require.NotNil(t, matches[1].Backfill)
+}
+
+func TestGetMatchSizeInvalid(t *testing.T) {
+	require.NoError(t, validateProfile(matchSizeProfile(t, 6, 10)))
+	require.NoError(t, validateProfile(matchSizeProfile(t, 10, 10)))
+	require.Error(t, validateProfile(matchSizeProfile(t, 0, 10)))
+	require.Error(t, validateProfile(matchSizeProfile(t, 6, 5)))
+	require.Error(t, validateProfile(matchSizeProfile(t, 6, 9.5)))
+
+	profile := matchSizeProfile(t, 6, 10)
+	profile.Extensions[teamsKey] = teamsProfile(t, "mmr", 2).Extensions[teamsKey]
+	require.Error(t, validateProfile(profile))
+}
+
+// matchSizeProfile returns a profile whose matches have between min and max
+// tickets.
+func matchSizeProfile(t *testing.T, min, max float64) *pb.MatchProfile {
+	a, err := ptypes.MarshalAny(&structpb.Struct{Fields: map[string]*structpb.Value{
+		"min": {Kind: &structpb.Value_NumberValue{NumberValue: min}},
+		"max": {Kind: &structpb.Value_NumberValue{NumberValue: max}},
+	}})
+	require.Nil(t, err)
+
+	return &pb.MatchProfile{
+		Name:       "matchProfile",
+		Pools:      []*pb.Pool{{Name: "pool"}},
+		Extensions: map[string]*any.Any{matchSizeKey: a},
+	}
+}
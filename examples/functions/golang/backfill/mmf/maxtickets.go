@@ -0,0 +1,46 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const maxTicketsKey = "max-tickets"
+
+// getMaxTickets reads the most tickets a proposal may have from the profile's
+// "max-tickets" extension, a Struct with a "count" number.  A profile without
+// the extension has maxTicketsPerMatch.
+func getMaxTickets(profile *pb.MatchProfile) (int, error) {
+	a, ok := profile.GetExtensions()[maxTicketsKey]
+	if !ok {
+		return maxTicketsPerMatch, nil
+	}
+
+	var val structpb.Struct
+	if err := ptypes.UnmarshalAny(a, &val); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal max tickets: %w", err)
+	}
+
+	n, ok := val.GetFields()["count"].GetKind().(*structpb.Value_NumberValue)
+	if !ok || n.NumberValue < 1 || n.NumberValue != float64(int(n.NumberValue)) {
+		return 0, fmt.Errorf("max tickets count must be a positive whole number")
+	}
+	return int(n.NumberValue), nil
+}
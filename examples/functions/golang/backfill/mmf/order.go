@@ -0,0 +1,54 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	matchOrderKey = "match-order"
+
+	// matchOrderBackfills gives existing backfills the first pick of tickets,
+	// and matchOrderMatches forms full new matches first, leaving backfills
+	// the tickets which don't make a full match.
+	matchOrderBackfills = "backfills"
+	matchOrderMatches   = "matches"
+)
+
+// getMatchOrder reads what gets the first pick of tickets from the profile's
+// "match-order" extension, a Struct with a "first" string, "backfills" or
+// "matches".  A profile without the extension prioritizes backfills.
+func getMatchOrder(profile *pb.MatchProfile) (string, error) {
+	a, ok := profile.GetExtensions()[matchOrderKey]
+	if !ok {
+		return matchOrderBackfills, nil
+	}
+
+	var val structpb.Struct
+	if err := ptypes.UnmarshalAny(a, &val); err != nil {
+		return "", fmt.Errorf("failed to unmarshal match order: %w", err)
+	}
+
+	first := val.GetFields()["first"].GetStringValue()
+	if first != matchOrderBackfills && first != matchOrderMatches {
+		return "", fmt.Errorf("match order first must be %q or %q", matchOrderBackfills, matchOrderMatches)
+	}
+	return first, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/backfill"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestMakeMatchesHonorsMatchOrder(t *testing.T) {
+	for _, tc := range []struct {
+		first     string
+		backfill  []string
+		full      [][]string
+		remaining int32
+	}{
+		// The backfill takes two tickets, and the third waits alone.
+		{first: matchOrderBackfills, backfill: []string{"1", "2"}},
+		// The first two tickets form a full match, and the backfill only
+		// gets the third, keeping one of its slots open.
+		{first: matchOrderMatches, backfill: []string{"3"}, full: [][]string{{"1", "2"}}, remaining: 1},
+	} {
+		tc := tc
+		t.Run(tc.first, func(t *testing.T) {
+			profile := matchOrderProfile(t, tc.first)
+			tickets := []*pb.Ticket{{Id: "1"}, {Id: "2"}, {Id: "3"}}
+			backfills := []*pb.Backfill{withOpenSlots(2)}
+
+			matches, err := makeMatches(profile, profile.Pools[0], tickets, backfills, partialMatchPolicy{minSize: defaultPlayersPerMatch}, time.Now())
+			require.NoError(t, err)
+
+			var filled []string
+			var full [][]string
+			for _, m := range matches {
+				if m.GetBackfill() != nil {
+					openSlots, err := backfill.OpenSlots(m.GetBackfill())
+					require.NoError(t, err)
+					require.Equal(t, tc.remaining, openSlots)
+					filled = append(filled, ticketIDs(m.GetTickets())...)
+				} else {
+					full = append(full, ticketIDs(m.GetTickets()))
+				}
+			}
+			require.Equal(t, tc.backfill, filled)
+			require.Equal(t, tc.full, full)
+		})
+	}
+}
+
+func TestGetMatchOrderInvalid(t *testing.T) {
+	require.NoError(t, validateProfile(matchOrderProfile(t, matchOrderBackfills)))
+	require.NoError(t, validateProfile(matchOrderProfile(t, matchOrderMatches)))
+	require.Error(t, validateProfile(matchOrderProfile(t, "tickets")))
+}
+
+// matchOrderProfile returns a profile giving first the first pick of tickets.
+func matchOrderProfile(t *testing.T, first string) *pb.MatchProfile {
+	a, err := ptypes.MarshalAny(&structpb.Struct{Fields: map[string]*structpb.Value{
+		"first": {Kind: &structpb.Value_StringValue{StringValue: first}},
+	}})
+	require.Nil(t, err)
+
+	return &pb.MatchProfile{
+		Name:       "matchProfile",
+		Pools:      []*pb.Pool{{Name: "pool"}},
+		Extensions: map[string]*any.Any{matchOrderKey: a},
+	}
+}
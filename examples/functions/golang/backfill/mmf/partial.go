@@ -0,0 +1,61 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const partialMatchKey = "partial-match"
+
+// getPartialMatchPolicy reads the partial match policy from the profile's
+// "partial-match" extension, a Struct with an optional "minSize" positive
+// whole number and an optional "waitSeconds" non-negative number.  Missing
+// fields, or a profile without the extension, take minViableMatchSize and
+// partialMatchWait.
+func getPartialMatchPolicy(profile *pb.MatchProfile) (partialMatchPolicy, error) {
+	policy := partialMatchPolicy{minSize: minViableMatchSize, wait: partialMatchWait}
+
+	a, ok := profile.GetExtensions()[partialMatchKey]
+	if !ok {
+		return policy, nil
+	}
+
+	var val structpb.Struct
+	if err := ptypes.UnmarshalAny(a, &val); err != nil {
+		return partialMatchPolicy{}, fmt.Errorf("failed to unmarshal partial match: %w", err)
+	}
+
+	if v, ok := val.GetFields()["minSize"]; ok {
+		n, ok := v.GetKind().(*structpb.Value_NumberValue)
+		if !ok || n.NumberValue < 1 || n.NumberValue != float64(int(n.NumberValue)) {
+			return partialMatchPolicy{}, fmt.Errorf("partial match minSize must be a positive whole number")
+		}
+		policy.minSize = int(n.NumberValue)
+	}
+	if v, ok := val.GetFields()["waitSeconds"]; ok {
+		n, ok := v.GetKind().(*structpb.Value_NumberValue)
+		if !ok || n.NumberValue < 0 {
+			return partialMatchPolicy{}, fmt.Errorf("partial match waitSeconds must be a non-negative number")
+		}
+		policy.wait = time.Duration(n.NumberValue * float64(time.Second))
+	}
+	return policy, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/require"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/backfill"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestRunHonorsPartialMatchPolicy(t *testing.T) {
+	now := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+	createdAgo := func(n int, d time.Duration) []*pb.Ticket {
+		ts, err := ptypes.TimestampProto(now.Add(-d))
+		require.Nil(t, err)
+		var tickets []*pb.Ticket
+		for i := 1; i <= n; i++ {
+			tickets = append(tickets, &pb.Ticket{Id: fmt.Sprint(i), CreateTime: ts})
+		}
+		return tickets
+	}
+
+	for _, tc := range []struct {
+		name          string
+		tickets       []*pb.Ticket
+		expectedMatch bool
+	}{
+		{name: "forms an under-full match after the wait", tickets: createdAgo(3, 2*time.Minute), expectedMatch: true},
+		{name: "forms no match before the wait", tickets: createdAgo(3, 30*time.Second)},
+		{name: "forms no match below the min size", tickets: createdAgo(1, 2*time.Minute)},
+	} {
+		testCase := tc
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			query := &fakeQueryServiceClient{tickets: map[string][]*pb.Ticket{"pool": testCase.tickets}}
+			stream := &fakeRunServer{query: query}
+			s := matchFunctionService{queryServiceClient: query, clock: utilTesting.NewFakeClock(now)}
+
+			profile := playersPerMatchProfile(t, 4)
+			profile.Extensions[partialMatchKey] = partialMatchProfile(t, map[string]float64{"minSize": 2, "waitSeconds": 60}).Extensions[partialMatchKey]
+			require.NoError(t, s.Run(&pb.RunRequest{Profile: profile}, stream))
+
+			if !testCase.expectedMatch {
+				require.Empty(t, stream.proposals)
+				return
+			}
+			require.Len(t, stream.proposals, 1)
+			require.Len(t, stream.proposals[0].Tickets, len(testCase.tickets))
+			openSlots, err := backfill.OpenSlots(stream.proposals[0].Backfill)
+			require.NoError(t, err)
+			require.Equal(t, int32(4-len(testCase.tickets)), openSlots)
+		})
+	}
+}
+
+func TestGetPartialMatchPolicy(t *testing.T) {
+	policy, err := getPartialMatchPolicy(&pb.MatchProfile{})
+	require.NoError(t, err)
+	require.Equal(t, partialMatchPolicy{minSize: minViableMatchSize, wait: partialMatchWait}, policy)
+
+	// Missing fields keep their defaults.
+	policy, err = getPartialMatchPolicy(partialMatchProfile(t, map[string]float64{"waitSeconds": 1.5}))
+	require.NoError(t, err)
+	require.Equal(t, partialMatchPolicy{minSize: minViableMatchSize, wait: 1500 * time.Millisecond}, policy)
+
+	for _, fields := range []map[string]float64{
+		{"minSize": 0},
+		{"minSize": 2.5},
+		{"waitSeconds": -1},
+	} {
+		require.Error(t, validateProfile(partialMatchProfile(t, fields)), "%v", fields)
+	}
+}
+
+// partialMatchProfile returns a profile with the given partial match fields.
+func partialMatchProfile(t *testing.T, fields map[string]float64) *pb.MatchProfile {
+	val := &structpb.Struct{Fields: make(map[string]*structpb.Value)}
+	for k, v := range fields {
+		val.Fields[k] = &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: v}}
+	}
+	a, err := ptypes.MarshalAny(val)
+	require.Nil(t, err)
+
+	return &pb.MatchProfile{
+		Name:       "matchProfile",
+		Pools:      []*pb.Pool{{Name: "pool"}},
+		Extensions: map[string]*any.Any{partialMatchKey: a},
+	}
+}
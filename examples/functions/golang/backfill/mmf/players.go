@@ -0,0 +1,47 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const playersPerMatchKey = "players-per-match"
+
+// getPlayersPerMatch reads the number of tickets in a full match from the
+// profile's "players-per-match" extension, a Struct with a "count" number.  A
+// profile without the extension has defaultPlayersPerMatch.  Backfills without
+// open slots recorded are also assumed to have this many open slots.
+func getPlayersPerMatch(profile *pb.MatchProfile) (int, error) {
+	a, ok := profile.GetExtensions()[playersPerMatchKey]
+	if !ok {
+		return defaultPlayersPerMatch, nil
+	}
+
+	var val structpb.Struct
+	if err := ptypes.UnmarshalAny(a, &val); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal players per match: %w", err)
+	}
+
+	n, ok := val.GetFields()["count"].GetKind().(*structpb.Value_NumberValue)
+	if !ok || n.NumberValue < 1 || n.NumberValue != float64(int(n.NumberValue)) {
+		return 0, fmt.Errorf("players per match count must be a positive whole number")
+	}
+	return int(n.NumberValue), nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/backfill"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestMakeMatchesHonorsPlayersPerMatch(t *testing.T) {
+	for _, players := range []int{4, 6} {
+		players := players
+		t.Run(fmt.Sprintf("%d players", players), func(t *testing.T) {
+			t.Parallel()
+
+			profile := playersPerMatchProfile(t, float64(players))
+			var tickets []*pb.Ticket
+			for i := 1; i <= 2*players+1; i++ {
+				tickets = append(tickets, &pb.Ticket{Id: fmt.Sprint(i)})
+			}
+
+			matches, err := makeMatches(profile, profile.Pools[0], tickets, nil, partialMatchPolicy{minSize: 1}, time.Now())
+			require.NoError(t, err)
+			require.Len(t, matches, 3)
+
+			for _, m := range matches[:2] {
+				require.Len(t, m.Tickets, players)
+				require.Nil(t, m.Backfill)
+			}
+
+			require.Len(t, matches[2].Tickets, 1)
+			openSlots, err := backfill.OpenSlots(matches[2].Backfill)
+			require.NoError(t, err)
+			require.Equal(t, int32(players-1), openSlots)
+		})
+	}
+}
+
+func TestHandleBackfillsDefaultsToPlayersPerMatch(t *testing.T) {
+	for _, players := range []int{4, 6} {
+		players := players
+		t.Run(fmt.Sprintf("%d players", players), func(t *testing.T) {
+			t.Parallel()
+
+			profile := playersPerMatchProfile(t, float64(players))
+			var tickets []*pb.Ticket
+			for i := 1; i <= players+1; i++ {
+				tickets = append(tickets, &pb.Ticket{Id: fmt.Sprint(i)})
+			}
+
+			// The backfill has no open slots recorded, so it takes a full
+			// match of tickets.
+			matches, remaining, err := handleBackfills(profile, tickets, []*pb.Backfill{{}}, 0, time.Now())
+			require.NoError(t, err)
+			require.Len(t, matches, 1)
+			require.Len(t, matches[0].Tickets, players)
+			require.Len(t, remaining, 1)
+
+			openSlots, err := backfill.OpenSlots(matches[0].Backfill)
+			require.NoError(t, err)
+			require.Equal(t, int32(0), openSlots)
+		})
+	}
+}
+
+func TestMakeMatchWithBackfillPlayersPerMatch(t *testing.T) {
+	profile := pb.MatchProfile{Name: "matchProfile"}
+	tickets := []*pb.Ticket{{Id: "1"}, {Id: "2"}, {Id: "3"}}
+
+	match, err := makeMatchWithBackfill(&profile, &pb.Pool{}, 6, tickets, 0, time.Now())
+	require.NoError(t, err)
+	openSlots, err := backfill.OpenSlots(match.Backfill)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), openSlots)
+
+	_, err = makeMatchWithBackfill(&profile, &pb.Pool{}, 3, tickets, 0, time.Now())
+	require.Error(t, err)
+}
+
+func TestGetPlayersPerMatchInvalid(t *testing.T) {
+	players, err := getPlayersPerMatch(&pb.MatchProfile{})
+	require.NoError(t, err)
+	require.Equal(t, defaultPlayersPerMatch, players)
+
+	require.NoError(t, validateProfile(playersPerMatchProfile(t, 4)))
+	require.Error(t, validateProfile(playersPerMatchProfile(t, 0)))
+	require.Error(t, validateProfile(playersPerMatchProfile(t, 4.5)))
+
+	profile := playersPerMatchProfile(t, 6)
+	profile.Extensions[teamsKey] = teamsProfile(t, "mmr", 3).Extensions[teamsKey]
+	require.NoError(t, validateProfile(profile))
+
+	profile = playersPerMatchProfile(t, 4)
+	profile.Extensions[teamsKey] = teamsProfile(t, "mmr", 3).Extensions[teamsKey]
+	require.Error(t, validateProfile(profile))
+
+	profile = playersPerMatchProfile(t, 4)
+	profile.Extensions[matchSizeKey] = matchSizeProfile(t, 2, 4).Extensions[matchSizeKey]
+	require.Error(t, validateProfile(profile))
+}
+
+// playersPerMatchProfile returns a profile whose full matches have count
+// tickets.
+func playersPerMatchProfile(t *testing.T, count float64) *pb.MatchProfile {
+	a, err := ptypes.MarshalAny(&structpb.Struct{Fields: map[string]*structpb.Value{
+		"count": {Kind: &structpb.Value_NumberValue{NumberValue: count}},
+	}})
+	require.Nil(t, err)
+
+	return &pb.MatchProfile{
+		Name:       "matchProfile",
+		Pools:      []*pb.Pool{{Name: "pool"}},
+		Extensions: map[string]*any.Any{playersPerMatchKey: a},
+	}
+}
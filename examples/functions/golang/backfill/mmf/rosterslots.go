@@ -0,0 +1,125 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const rosterSlotsKey = "roster-slots"
+
+// assignRosterSlots places each ticket in a slot of the roster whose pool
+// filters accept the ticket's search fields, without exceeding the count of
+// any slot.  It returns the ticket ids placed in each slot, keyed by the
+// slot's pool name and in ticket order, or fails if the tickets can't all be
+// placed.  A ticket fitting several slots, such as a player willing to tank
+// or heal, is moved between them as needed to place the others.
+func assignRosterSlots(profile *pb.MatchProfile, roster []rosterSlot, tickets []*pb.Ticket) (map[string][]string, error) {
+	filters := make(map[string]*filter.PoolFilter)
+	for _, p := range profile.GetPools() {
+		pf, err := filter.NewPoolFilter(p)
+		if err != nil {
+			return nil, err
+		}
+		filters[p.GetName()] = pf
+	}
+
+	// Every seat of the roster is matched to at most one ticket, by finding
+	// an augmenting path for each ticket in turn.
+	var seats []string
+	for _, slot := range roster {
+		for i := 0; i < slot.count; i++ {
+			seats = append(seats, slot.pool)
+		}
+	}
+	occupant := make([]int, len(seats))
+	for i := range occupant {
+		occupant[i] = -1
+	}
+
+	var place func(ticket int, visited []bool) bool
+	place = func(ticket int, visited []bool) bool {
+		for seat, pool := range seats {
+			if visited[seat] || !filters[pool].In(tickets[ticket]) {
+				continue
+			}
+			visited[seat] = true
+			if occupant[seat] < 0 || place(occupant[seat], visited) {
+				occupant[seat] = ticket
+				return true
+			}
+		}
+		return false
+	}
+	// Most tickets take a free seat, the search only runs for those which
+	// must displace another ticket.
+	free := func(ticket int) bool {
+		for seat, pool := range seats {
+			if occupant[seat] < 0 && filters[pool].In(tickets[ticket]) {
+				occupant[seat] = ticket
+				return true
+			}
+		}
+		return false
+	}
+	for i, t := range tickets {
+		if !free(i) && !place(i, make([]bool, len(seats))) {
+			return nil, fmt.Errorf("ticket %s fits no open roster slot", t.GetId())
+		}
+	}
+
+	seatOf := make([]int, len(tickets))
+	for seat, ticket := range occupant {
+		if ticket >= 0 {
+			seatOf[ticket] = seat
+		}
+	}
+	slots := make(map[string][]string)
+	for i, t := range tickets {
+		pool := seats[seatOf[i]]
+		slots[pool] = append(slots[pool], t.GetId())
+	}
+	return slots, nil
+}
+
+// setRosterSlots records the ticket ids in each roster slot in the match's
+// "roster-slots" extension, a Struct mapping pool names to ListValues of
+// ticket ids.
+func setRosterSlots(match *pb.Match, slots map[string][]string) error {
+	val := &structpb.Struct{Fields: make(map[string]*structpb.Value)}
+	for pool, ids := range slots {
+		list := &structpb.ListValue{}
+		for _, id := range ids {
+			list.Values = append(list.Values, &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: id}})
+		}
+		val.Fields[pool] = &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: list}}
+	}
+
+	a, err := ptypes.MarshalAny(val)
+	if err != nil {
+		return err
+	}
+	if match.Extensions == nil {
+		match.Extensions = make(map[string]*any.Any)
+	}
+	match.Extensions[rosterSlotsKey] = a
+	return nil
+}
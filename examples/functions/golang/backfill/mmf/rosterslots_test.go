@@ -0,0 +1,108 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestAssignRosterSlots(t *testing.T) {
+	profile := roleProfile(t)
+	roster, err := getRoster(profile)
+	require.NoError(t, err)
+
+	// flex can tank or heal, and must heal for tank to get the tank slot.
+	tickets := []*pb.Ticket{
+		roleTicket("flex", "tank", "healer"),
+		roleTicket("tank", "tank"),
+		roleTicket("dps-1", "dps"),
+		roleTicket("dps-2", "dps"),
+	}
+	slots, err := assignRosterSlots(profile, roster, tickets)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{
+		"tank":   {"tank"},
+		"healer": {"flex"},
+		"dps":    {"dps-1", "dps-2"},
+	}, slots)
+
+	// Nobody can heal.
+	tickets[0] = roleTicket("dps-3", "dps")
+	_, err = assignRosterSlots(profile, roster, tickets)
+	require.Error(t, err)
+}
+
+func TestNewMatchRecordsRosterSlots(t *testing.T) {
+	profile := roleProfile(t)
+	tickets := []*pb.Ticket{
+		roleTicket("healer", "healer"),
+		roleTicket("dps-1", "dps"),
+		roleTicket("tank", "tank"),
+		roleTicket("dps-2", "dps"),
+	}
+
+	match := newMatch(1, profile, tickets, nil, time.Now())
+	require.Contains(t, match.Extensions, rosterSlotsKey)
+
+	var slots structpb.Struct
+	require.NoError(t, ptypes.UnmarshalAny(match.Extensions[rosterSlotsKey], &slots))
+	got := make(map[string][]string)
+	for pool, ids := range slots.GetFields() {
+		for _, id := range ids.GetListValue().GetValues() {
+			got[pool] = append(got[pool], id.GetStringValue())
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"tank":   {"tank"},
+		"healer": {"healer"},
+		"dps":    {"dps-1", "dps-2"},
+	}, got)
+
+	// Matches for profiles without a roster have no slots.
+	match = newMatch(1, &pb.MatchProfile{Name: "matchProfile"}, tickets, nil, time.Now())
+	require.NotContains(t, match.Extensions, rosterSlotsKey)
+}
+
+func roleTicket(id string, roles ...string) *pb.Ticket {
+	return &pb.Ticket{Id: id, SearchFields: &pb.SearchFields{Tags: roles}}
+}
+
+// roleProfile returns a profile with a pool per role, and a roster of a tank,
+// a healer and two dps.
+func roleProfile(t *testing.T) *pb.MatchProfile {
+	roster := map[string]float64{"tank": 1, "healer": 1, "dps": 2}
+
+	val := &structpb.Struct{Fields: make(map[string]*structpb.Value)}
+	var pools []*pb.Pool
+	for _, role := range []string{"tank", "healer", "dps"} {
+		val.Fields[role] = &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: roster[role]}}
+		pools = append(pools, &pb.Pool{Name: role, TagPresentFilters: []*pb.TagPresentFilter{{Tag: role}}})
+	}
+	a, err := ptypes.MarshalAny(val)
+	require.Nil(t, err)
+
+	return &pb.MatchProfile{
+		Name:       "matchProfile",
+		Pools:      pools,
+		Extensions: map[string]*any.Any{rosterKey: a},
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	backfillSearchFieldsKey = "backfill-search-fields"
+
+	// backfillDoubleArgsPool sets the double args of a new backfill from the
+	// pool's double range filters, and backfillDoubleArgsTickets from the
+	// average value of the tickets seated in the match.
+	backfillDoubleArgsPool    = "pool"
+	backfillDoubleArgsTickets = "tickets"
+)
+
+// getBackfillDoubleArgs reads where the double args of new backfills come from
+// out of the profile's "backfill-search-fields" extension, a Struct with a
+// "double-args" string, "pool" or "tickets".  A profile without the extension
+// uses the pool.
+func getBackfillDoubleArgs(profile *pb.MatchProfile) (string, error) {
+	a, ok := profile.GetExtensions()[backfillSearchFieldsKey]
+	if !ok {
+		return backfillDoubleArgsPool, nil
+	}
+
+	var val structpb.Struct
+	if err := ptypes.UnmarshalAny(a, &val); err != nil {
+		return "", fmt.Errorf("failed to unmarshal backfill search fields: %w", err)
+	}
+
+	from := val.GetFields()["double-args"].GetStringValue()
+	if from != backfillDoubleArgsPool && from != backfillDoubleArgsTickets {
+		return "", fmt.Errorf("backfill search fields double-args must be %q or %q", backfillDoubleArgsPool, backfillDoubleArgsTickets)
+	}
+	return from, nil
+}
+
+// newBackfillSearchFields returns the search fields of a backfill created for
+// the tickets of the pool.  When the profile asks for it, each double arg is
+// the average of the tickets' values so that incoming players fit the match
+// as it is, keeping the pool's value if no ticket has the arg.
+func newBackfillSearchFields(profile *pb.MatchProfile, pool *pb.Pool, tickets []*pb.Ticket) (*pb.SearchFields, error) {
+	from, err := getBackfillDoubleArgs(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	searchFields := newSearchFields(pool)
+	if from != backfillDoubleArgsTickets {
+		return searchFields, nil
+	}
+
+	for arg := range searchFields.DoubleArgs {
+		sum, n := 0.0, 0
+		for _, t := range tickets {
+			if v, ok := t.GetSearchFields().GetDoubleArgs()[arg]; ok {
+				sum += v
+				n++
+			}
+		}
+		if n > 0 {
+			searchFields.DoubleArgs[arg] = sum / float64(n)
+		}
+	}
+	return searchFields, nil
+}
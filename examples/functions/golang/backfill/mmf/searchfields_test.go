@@ -0,0 +1,128 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestMakeMatchWithBackfillInheritsTicketDoubleArgs(t *testing.T) {
+	pool := &pb.Pool{
+		Name: "pool",
+		DoubleRangeFilters: []*pb.DoubleRangeFilter{
+			{DoubleArg: "mmr", Min: 1000, Max: 3000},
+			{DoubleArg: "level", Min: 0, Max: 100},
+		},
+	}
+	tickets := []*pb.Ticket{{Id: "1", SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 2600}}}}
+
+	for _, tc := range []struct {
+		from  string
+		mmr   float64
+		level float64
+	}{
+		{from: backfillDoubleArgsPool, mmr: 1000, level: 50},
+		// The seated player has no level, which keeps the pool's value.
+		{from: backfillDoubleArgsTickets, mmr: 2600, level: 50},
+	} {
+		tc := tc
+		t.Run(tc.from, func(t *testing.T) {
+			profile := backfillSearchFieldsProfile(t, tc.from)
+			profile.Pools = []*pb.Pool{pool}
+			require.NoError(t, validateProfile(profile))
+
+			match, err := makeMatchWithBackfill(profile, pool, defaultPlayersPerMatch, tickets, 0, time.Now())
+			require.NoError(t, err)
+			require.Equal(t, map[string]float64{"mmr": tc.mmr, "level": tc.level}, match.Backfill.SearchFields.DoubleArgs)
+		})
+	}
+}
+
+func TestMakeSizedMatchesInheritsTicketDoubleArgs(t *testing.T) {
+	pool := &pb.Pool{
+		Name:               "pool",
+		DoubleRangeFilters: []*pb.DoubleRangeFilter{{DoubleArg: "mmr", Min: 0, Max: 5000}},
+	}
+	// The seated players average 1500, and the ticket without an mmr is
+	// left out of the average.
+	tickets := []*pb.Ticket{{Id: "none"}}
+	for _, v := range []float64{1000, 1500, 2000} {
+		tickets = append(tickets, &pb.Ticket{SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": v}}})
+	}
+
+	profile := backfillSearchFieldsProfile(t, backfillDoubleArgsTickets)
+	matches, _, err := makeSizedMatches(profile, pool, matchSize{min: 2, max: 6}, tickets, 0, time.Now())
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, 1500.0, matches[0].Backfill.SearchFields.DoubleArgs["mmr"])
+}
+
+func TestNewSearchFieldsExclusiveBounds(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		min     float64
+		max     float64
+		exclude pb.DoubleRangeFilter_Exclude
+		want    float64
+	}{
+		// Bounds are inclusive by default, so values on them are kept.
+		{name: "on min", min: 1000, max: 3000, exclude: pb.DoubleRangeFilter_NONE, want: 1000},
+		{name: "on max", min: -50, max: 50, exclude: pb.DoubleRangeFilter_NONE, want: 50},
+		{name: "on excluded min", min: 1000, max: 3000, exclude: pb.DoubleRangeFilter_MIN, want: 2000},
+		{name: "on excluded max", min: -50, max: 50, exclude: pb.DoubleRangeFilter_MAX, want: 0},
+		{name: "on min excluding max", min: 1000, max: 3000, exclude: pb.DoubleRangeFilter_MAX, want: 1000},
+		{name: "on min excluding both", min: 1000, max: 3000, exclude: pb.DoubleRangeFilter_BOTH, want: 2000},
+		{name: "within bounds excluding both", min: 0, max: 100, exclude: pb.DoubleRangeFilter_BOTH, want: 50},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			pool := &pb.Pool{DoubleRangeFilters: []*pb.DoubleRangeFilter{
+				{DoubleArg: "mmr", Min: tc.min, Max: tc.max, Exclude: tc.exclude},
+			}}
+			require.Equal(t, tc.want, newSearchFields(pool).DoubleArgs["mmr"])
+		})
+	}
+}
+
+func TestNewSearchFieldsTags(t *testing.T) {
+	pool := &pb.Pool{TagPresentFilters: []*pb.TagPresentFilter{{Tag: "beta"}, {Tag: "ranked"}}}
+	require.Equal(t, []string{"beta", "ranked"}, newSearchFields(pool).Tags)
+}
+
+func TestGetBackfillDoubleArgsInvalid(t *testing.T) {
+	require.Error(t, validateProfile(backfillSearchFieldsProfile(t, "midpoint")))
+}
+
+// backfillSearchFieldsProfile returns a profile taking the double args of new
+// backfills from from.
+func backfillSearchFieldsProfile(t *testing.T, from string) *pb.MatchProfile {
+	a, err := ptypes.MarshalAny(&structpb.Struct{Fields: map[string]*structpb.Value{
+		"double-args": {Kind: &structpb.Value_StringValue{StringValue: from}},
+	}})
+	require.Nil(t, err)
+
+	return &pb.MatchProfile{
+		Name:       "matchProfile",
+		Pools:      []*pb.Pool{{Name: "pool"}},
+		Extensions: map[string]*any.Any{backfillSearchFieldsKey: a},
+	}
+}
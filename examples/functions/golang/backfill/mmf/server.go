@@ -20,6 +20,7 @@ import (
 	"net"
 
 	"google.golang.org/grpc"
+	"open-match.dev/open-match/internal/util"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -35,6 +36,7 @@ func Start(queryServiceAddr string, serverPort int) {
 
 	mmfService := matchFunctionService{
 		queryServiceClient: pb.NewQueryServiceClient(conn),
+		clock:              util.RealClock(),
 	}
 
 	// Create and host a new gRPC service on the configured port.
@@ -0,0 +1,97 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"open-match.dev/open-match/pkg/matchfunction"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const teamsKey = "teams"
+
+// teamBalance splits full matches into teams with a balanced average of a
+// double arg, such as MMR.
+type teamBalance struct {
+	doubleArg string
+	teams     int
+}
+
+// getTeamBalance reads the balancing from the profile's "teams" extension, a
+// Struct with a "doubleArg" string and a "teams" number, which must divide
+// the profile's players per match.  Matches with a backfill, and roster
+// matches, are left as they are.  A profile without the extension returns nil.
+func getTeamBalance(profile *pb.MatchProfile) (*teamBalance, error) {
+	a, ok := profile.GetExtensions()[teamsKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var val structpb.Struct
+	if err := ptypes.UnmarshalAny(a, &val); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal teams: %w", err)
+	}
+
+	doubleArg := val.GetFields()["doubleArg"].GetStringValue()
+	if doubleArg == "" {
+		return nil, fmt.Errorf("teams requires a doubleArg")
+	}
+	players, err := getPlayersPerMatch(profile)
+	if err != nil {
+		return nil, err
+	}
+	n, ok := val.GetFields()["teams"].GetKind().(*structpb.Value_NumberValue)
+	if !ok || n.NumberValue < 1 || n.NumberValue != float64(int(n.NumberValue)) || players%int(n.NumberValue) != 0 {
+		return nil, fmt.Errorf("teams must be a whole number dividing the %d players of a match", players)
+	}
+
+	return &teamBalance{doubleArg: doubleArg, teams: int(n.NumberValue)}, nil
+}
+
+// balance reorders the match's tickets team by team, and records the ticket
+// ids of each team in the match's "teams" extension, a ListValue of
+// ListValues.
+func (b *teamBalance) balance(match *pb.Match) error {
+	teams, err := matchfunction.BalanceTeams(match.Tickets, len(match.Tickets)/b.teams, b.doubleArg)
+	if err != nil {
+		return err
+	}
+
+	list := &structpb.ListValue{}
+	var tickets []*pb.Ticket
+	for _, team := range teams {
+		ids := &structpb.ListValue{}
+		for _, t := range team {
+			ids.Values = append(ids.Values, &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: t.Id}})
+		}
+		list.Values = append(list.Values, &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: ids}})
+		tickets = append(tickets, team...)
+	}
+
+	a, err := ptypes.MarshalAny(list)
+	if err != nil {
+		return err
+	}
+	if match.Extensions == nil {
+		match.Extensions = make(map[string]*any.Any)
+	}
+	match.Extensions[teamsKey] = a
+	match.Tickets = tickets
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestTeamBalance(t *testing.T) {
+	ticket := func(id string, mmr float64) *pb.Ticket {
+		return &pb.Ticket{Id: id, SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": mmr}}}
+	}
+	match := &pb.Match{Tickets: []*pb.Ticket{ticket("a", 2000), ticket("b", 1900), ticket("c", 1100), ticket("d", 1000)}}
+
+	b := &teamBalance{doubleArg: "mmr", teams: 2}
+	require.NoError(t, b.balance(match))
+	require.Equal(t, []string{"a", "d", "b", "c"}, ticketIDs(match.Tickets))
+
+	var teams structpb.ListValue
+	require.NoError(t, ptypes.UnmarshalAny(match.Extensions[teamsKey], &teams))
+	require.Len(t, teams.Values, 2)
+	require.Equal(t, "a", teams.Values[0].GetListValue().Values[0].GetStringValue())
+	require.Equal(t, "d", teams.Values[0].GetListValue().Values[1].GetStringValue())
+	require.Equal(t, "b", teams.Values[1].GetListValue().Values[0].GetStringValue())
+	require.Equal(t, "c", teams.Values[1].GetListValue().Values[1].GetStringValue())
+}
+
+func TestMakeMatchesBalancesTeams(t *testing.T) {
+	profile := teamsProfile(t, "mmr", 2)
+	tickets := []*pb.Ticket{
+		{Id: "1", SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 1000}}},
+		{Id: "2", SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 2000}}},
+		{Id: "3", SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 1500}}},
+	}
+
+	matches, err := makeMatches(profile, profile.Pools[0], tickets, nil, partialMatchPolicy{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+
+	// Only the full match is split into teams.
+	require.Contains(t, matches[0].Extensions, teamsKey)
+	require.Equal(t, []string{"2", "1"}, ticketIDs(matches[0].Tickets))
+	require.NotContains(t, matches[1].Extensions, teamsKey)
+}
+
+func TestGetTeamBalanceInvalid(t *testing.T) {
+	require.NoError(t, validateProfile(teamsProfile(t, "mmr", 1)))
+	require.Error(t, validateProfile(teamsProfile(t, "", 2)))
+	require.Error(t, validateProfile(teamsProfile(t, "mmr", 3)))
+	require.Error(t, validateProfile(teamsProfile(t, "mmr", 1.5)))
+}
+
+// teamsProfile returns a profile splitting matches into teams balanced on
+// doubleArg.
+func teamsProfile(t *testing.T, doubleArg string, teams float64) *pb.MatchProfile {
+	a, err := ptypes.MarshalAny(&structpb.Struct{Fields: map[string]*structpb.Value{
+		"doubleArg": {Kind: &structpb.Value_StringValue{StringValue: doubleArg}},
+		"teams":     {Kind: &structpb.Value_NumberValue{NumberValue: teams}},
+	}})
+	require.Nil(t, err)
+
+	return &pb.MatchProfile{
+		Name:       "matchProfile",
+		Pools:      []*pb.Pool{{Name: "pool"}},
+		Extensions: map[string]*any.Any{teamsKey: a},
+	}
+}
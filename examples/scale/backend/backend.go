@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -48,6 +49,7 @@ var (
 	mSumTicketsReturned  = telemetry.Counter("scale_backend_sum_tickets_returned", "tickets in matches returned")
 	mMatchesAssigned     = telemetry.Counter("scale_backend_matches_assigned", "matches assigned")
 	mMatchAssignsFailed  = telemetry.Counter("scale_backend_match_assigns_failed", "match assigns failed")
+	mTicketAssignsFailed = telemetry.Counter("scale_backend_ticket_assigns_failed", "ticket assigns failed")
 	mTicketsDeleted      = telemetry.Counter("scale_backend_tickets_deleted", "tickets deleted")
 	mTicketDeletesFailed = telemetry.Counter("scale_backend_ticket_deletes_failed", "ticket deletes failed")
 )
@@ -82,16 +84,28 @@ func run(cfg config.View) {
 	matchesForAssignment := make(chan *pb.Match, 30000)
 	ticketsForDeletion := make(chan string, 30000)
 
+	if window := activeScenario.BackendAssignmentWindow; window > 0 {
+		batchesForAssignment := make(chan []*pb.Match, 100)
+		go batchMatches(matchesForAssignment, time.NewTicker(window).C, batchesForAssignment)
+		for i := 0; i < 50; i++ {
+			go runBatchedAssignments(be, batchesForAssignment, ticketsForDeletion)
+		}
+	} else {
+		for i := 0; i < 50; i++ {
+			go runAssignments(be, matchesForAssignment, ticketsForDeletion)
+		}
+	}
 	for i := 0; i < 50; i++ {
-		go runAssignments(be, matchesForAssignment, ticketsForDeletion)
 		go runDeletions(fe, ticketsForDeletion)
 	}
 
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
 	// Don't go faster than this, as it likely means that FetchMatches is throwing
 	// errors, and will continue doing so if queried very quickly.
 	for range time.Tick(time.Millisecond * 250) {
 		// Keep pulling matches from Open Match backend
-		profiles := activeScenario.Profiles()
+		profiles := pickProfiles(activeScenario.Profiles(), activeScenario.BackendProfileWeights, r)
 		var wg sync.WaitGroup
 
 		for _, p := range profiles {
@@ -108,6 +122,40 @@ func run(cfg config.View) {
 	}
 }
 
+// pickProfiles returns the profiles to fetch in an iteration.  Without
+// weights, that is every profile once.  With weights, it is as many profiles,
+// each picked at random with a probability proportional to its weight.
+func pickProfiles(profiles []*pb.MatchProfile, weights map[string]float64, r *rand.Rand) []*pb.MatchProfile {
+	if len(weights) == 0 || len(profiles) == 0 {
+		return profiles
+	}
+
+	cumulative := make([]float64, len(profiles))
+	total := 0.0
+	for i, p := range profiles {
+		w, ok := weights[p.GetName()]
+		if !ok {
+			w = 1
+		}
+		if w > 0 {
+			total += w
+		}
+		cumulative[i] = total
+	}
+	if total == 0 {
+		return nil
+	}
+
+	picked := make([]*pb.MatchProfile, len(profiles))
+	for i := range picked {
+		x := r.Float64() * total
+		picked[i] = profiles[sort.Search(len(cumulative), func(j int) bool {
+			return cumulative[j] > x
+		})]
+	}
+	return picked
+}
+
 func runFetchMatches(be pb.BackendServiceClient, p *pb.MatchProfile, matchesForAssignment chan<- *pb.Match) {
 	ctx, span := trace.StartSpan(context.Background(), "scale.backend/FetchMatches")
 	defer span.End()
@@ -118,7 +166,8 @@ func runFetchMatches(be pb.BackendServiceClient, p *pb.MatchProfile, matchesForA
 			Port: 50502,
 			Type: pb.FunctionConfig_GRPC,
 		},
-		Profile: p,
+		Profile:               p,
+		IncludeNoMatchSummary: true,
 	}
 
 	telemetry.RecordUnitMeasurement(ctx, mFetchMatchCalls)
@@ -143,6 +192,16 @@ func runFetchMatches(be pb.BackendServiceClient, p *pb.MatchProfile, matchesForA
 			return
 		}
 
+		if summary := resp.GetNoMatchSummary(); summary != nil {
+			logger.WithFields(logrus.Fields{
+				"profile":           p.GetName(),
+				"proposals":         summary.GetProposals(),
+				"ticketsConsidered": summary.GetTicketsConsidered(),
+				"reason":            summary.GetReason(),
+			}).Debug("no matches returned for profile")
+			continue
+		}
+
 		telemetry.RecordNUnitMeasurement(ctx, mSumTicketsReturned, int64(len(resp.GetMatch().Tickets)))
 		telemetry.RecordUnitMeasurement(ctx, mMatchesReturned)
 
@@ -151,35 +210,88 @@ func runFetchMatches(be pb.BackendServiceClient, p *pb.MatchProfile, matchesForA
 }
 
 func runAssignments(be pb.BackendServiceClient, matchesForAssignment <-chan *pb.Match, ticketsForDeletion chan<- string) {
+	for m := range matchesForAssignment {
+		assignMatches(be, []*pb.Match{m}, ticketsForDeletion)
+	}
+}
+
+func runBatchedAssignments(be pb.BackendServiceClient, batchesForAssignment <-chan []*pb.Match, ticketsForDeletion chan<- string) {
+	for batch := range batchesForAssignment {
+		assignMatches(be, batch, ticketsForDeletion)
+	}
+}
+
+// batchMatches collects the matches received until each tick, and sends them
+// as one batch.  Ticks without matches send nothing.  The last matches are
+// sent once in is closed, then out is closed.
+func batchMatches(in <-chan *pb.Match, ticks <-chan time.Time, out chan<- []*pb.Match) {
+	defer close(out)
+
+	var batch []*pb.Match
+	for {
+		select {
+		case m, ok := <-in:
+			if !ok {
+				if len(batch) > 0 {
+					out <- batch
+				}
+				return
+			}
+			batch = append(batch, m)
+		case <-ticks:
+			if len(batch) > 0 {
+				out <- batch
+				batch = nil
+			}
+		}
+	}
+}
+
+// assignMatches assigns each match to its own server in a single
+// AssignTickets call, then queues the assigned tickets for deletion.
+func assignMatches(be pb.BackendServiceClient, matches []*pb.Match, ticketsForDeletion chan<- string) {
 	ctx := context.Background()
 
-	for m := range matchesForAssignment {
-		ids := []string{}
+	req := &pb.AssignTicketsRequest{}
+	ids := []string{}
+	for _, m := range matches {
+		group := &pb.AssignmentGroup{
+			Assignment: &pb.Assignment{
+				Connection: fmt.Sprintf("%d.%d.%d.%d:2222", rand.Intn(256), rand.Intn(256), rand.Intn(256), rand.Intn(256)),
+			},
+		}
 		for _, t := range m.Tickets {
-			ids = append(ids, t.GetId())
+			group.TicketIds = append(group.TicketIds, t.GetId())
 		}
+		req.Assignments = append(req.Assignments, group)
+		ids = append(ids, group.TicketIds...)
+	}
 
-		if activeScenario.BackendAssignsTickets {
-			_, err := be.AssignTickets(context.Background(), &pb.AssignTicketsRequest{
-				Assignments: []*pb.AssignmentGroup{
-					{
-						TicketIds: ids,
-						Assignment: &pb.Assignment{
-							Connection: fmt.Sprintf("%d.%d.%d.%d:2222", rand.Intn(256), rand.Intn(256), rand.Intn(256), rand.Intn(256)),
-						},
-					},
-				},
-			})
-			if err != nil {
-				telemetry.RecordUnitMeasurement(ctx, mMatchAssignsFailed)
-				logger.WithError(err).Error("failed to assign tickets")
-				continue
-			}
+	failed := make(map[string]bool)
+	if activeScenario.BackendAssignsTickets {
+		resp, err := be.AssignTickets(ctx, req)
+		if err != nil {
+			telemetry.RecordNUnitMeasurement(ctx, mMatchAssignsFailed, int64(len(matches)))
+			logger.WithError(err).Error("failed to assign tickets")
+			return
+		}
 
-			telemetry.RecordUnitMeasurement(ctx, mMatchesAssigned)
+		telemetry.RecordNUnitMeasurement(ctx, mMatchesAssigned, int64(len(matches)))
+
+		// The other tickets are assigned regardless of the failures, which
+		// are tickets deleted since they were matched.
+		for _, f := range resp.GetFailures() {
+			failed[f.GetTicketId()] = true
+			logger.WithFields(logrus.Fields{
+				"ticketId": f.GetTicketId(),
+				"cause":    f.GetCause().String(),
+			}).Debug("failed to assign ticket")
 		}
+		telemetry.RecordNUnitMeasurement(ctx, mTicketAssignsFailed, int64(len(failed)))
+	}
 
-		for _, id := range ids {
+	for _, id := range ids {
+		if !failed[id] {
 			ticketsForDeletion <- id
 		}
 	}
@@ -0,0 +1,147 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestPickProfilesUniform(t *testing.T) {
+	profiles := []*pb.MatchProfile{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	r := rand.New(rand.NewSource(1))
+
+	require.Equal(t, profiles, pickProfiles(profiles, nil, r))
+}
+
+func TestPickProfilesWeighted(t *testing.T) {
+	profiles := []*pb.MatchProfile{{Name: "popular"}, {Name: "niche"}, {Name: "unweighted"}, {Name: "disabled"}}
+	weights := map[string]float64{
+		"popular":  6,
+		"niche":    1,
+		"disabled": 0,
+	}
+	r := rand.New(rand.NewSource(1))
+
+	const iterations = 10000
+	counts := map[string]int{}
+	for i := 0; i < iterations; i++ {
+		picked := pickProfiles(profiles, weights, r)
+		require.Len(t, picked, len(profiles))
+		for _, p := range picked {
+			counts[p.GetName()]++
+		}
+	}
+
+	total := float64(iterations * len(profiles))
+	require.InDelta(t, 6.0/8, float64(counts["popular"])/total, 0.01)
+	require.InDelta(t, 1.0/8, float64(counts["niche"])/total, 0.01)
+	require.InDelta(t, 1.0/8, float64(counts["unweighted"])/total, 0.01)
+	require.Zero(t, counts["disabled"])
+}
+
+func TestBatchMatches(t *testing.T) {
+	in := make(chan *pb.Match)
+	ticks := make(chan time.Time)
+	out := make(chan []*pb.Match, 10)
+	go batchMatches(in, ticks, out)
+
+	send := func(ids ...string) {
+		for _, id := range ids {
+			in <- &pb.Match{MatchId: id}
+		}
+	}
+	// Every window assigns the matches fetched during it, and windows without
+	// matches assign nothing.
+	send("1", "2", "3")
+	ticks <- time.Now()
+	ticks <- time.Now()
+	send("4")
+	ticks <- time.Now()
+	send("5", "6")
+	close(in)
+
+	var batches [][]string
+	for batch := range out {
+		var ids []string
+		for _, m := range batch {
+			ids = append(ids, m.MatchId)
+		}
+		batches = append(batches, ids)
+	}
+	require.Equal(t, [][]string{{"1", "2", "3"}, {"4"}, {"5", "6"}}, batches)
+}
+
+func TestAssignMatchesBatch(t *testing.T) {
+	be := &recordingBackend{}
+	ticketsForDeletion := make(chan string, 10)
+
+	assignMatches(be, []*pb.Match{
+		{MatchId: "a", Tickets: []*pb.Ticket{{Id: "1"}, {Id: "2"}}},
+		{MatchId: "b", Tickets: []*pb.Ticket{{Id: "3"}}},
+	}, ticketsForDeletion)
+
+	// One call assigns each match of the batch to its own server.
+	require.Len(t, be.requests, 1)
+	groups := be.requests[0].Assignments
+	require.Len(t, groups, 2)
+	require.Equal(t, []string{"1", "2"}, groups[0].TicketIds)
+	require.Equal(t, []string{"3"}, groups[1].TicketIds)
+	require.NotEqual(t, "", groups[0].Assignment.Connection)
+
+	close(ticketsForDeletion)
+	var deleted []string
+	for id := range ticketsForDeletion {
+		deleted = append(deleted, id)
+	}
+	require.Equal(t, []string{"1", "2", "3"}, deleted)
+}
+
+func TestAssignMatchesSkipsFailedTickets(t *testing.T) {
+	be := &recordingBackend{
+		failures: []*pb.AssignmentFailure{{TicketId: "2", Cause: pb.AssignmentFailure_TICKET_NOT_FOUND}},
+	}
+	ticketsForDeletion := make(chan string, 10)
+
+	assignMatches(be, []*pb.Match{
+		{MatchId: "a", Tickets: []*pb.Ticket{{Id: "1"}, {Id: "2"}}},
+		{MatchId: "b", Tickets: []*pb.Ticket{{Id: "3"}}},
+	}, ticketsForDeletion)
+
+	// The deleted ticket isn't deleted again.
+	close(ticketsForDeletion)
+	var deleted []string
+	for id := range ticketsForDeletion {
+		deleted = append(deleted, id)
+	}
+	require.Equal(t, []string{"1", "3"}, deleted)
+}
+
+type recordingBackend struct {
+	pb.BackendServiceClient
+	requests []*pb.AssignTicketsRequest
+	failures []*pb.AssignmentFailure
+}
+
+func (b *recordingBackend) AssignTickets(ctx context.Context, req *pb.AssignTicketsRequest, opts ...grpc.CallOption) (*pb.AssignTicketsResponse, error) {
+	b.requests = append(b.requests, req)
+	return &pb.AssignTicketsResponse{Failures: b.failures}, nil
+}
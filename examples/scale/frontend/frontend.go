@@ -17,12 +17,16 @@ package frontend
 import (
 	"context"
 	"math/rand"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
+	"google.golang.org/grpc"
 	"open-match.dev/open-match/examples/scale/scenarios"
 	"open-match.dev/open-match/internal/appmain"
 	"open-match.dev/open-match/internal/config"
@@ -38,8 +42,10 @@ var (
 	})
 	activeScenario = scenarios.ActiveScenario
 
-	mTicketsCreated        = telemetry.Counter("scale_frontend_tickets_created", "tickets created")
-	mTicketCreationsFailed = telemetry.Counter("scale_frontend_ticket_creations_failed", "tickets created")
+	connectionKey = tag.MustNewKey("connection")
+
+	mTicketsCreated        = telemetry.Counter("scale_frontend_tickets_created", "tickets created", connectionKey)
+	mTicketCreationsFailed = telemetry.Counter("scale_frontend_ticket_creations_failed", "tickets created", connectionKey)
 	mRunnersWaiting        = concurrentGauge(telemetry.Gauge("scale_frontend_runners_waiting", "runners waiting"))
 	mRunnersCreating       = concurrentGauge(telemetry.Gauge("scale_frontend_runners_creating", "runners creating"))
 )
@@ -53,13 +59,14 @@ func BindService(p *appmain.Params, b *appmain.Bindings) error {
 }
 
 func run(cfg config.View) {
-	conn, err := rpc.GRPCClientFromConfig(cfg, "api.frontend")
+	fe, err := newFrontendPool(activeScenario.FrontendConnections, func() (*grpc.ClientConn, error) {
+		return rpc.GRPCClientFromConfig(cfg, "api.frontend")
+	})
 	if err != nil {
 		logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 		}).Fatal("failed to get Frontend connection")
 	}
-	fe := pb.NewFrontendServiceClient(conn)
 
 	ticketQPS := int(activeScenario.FrontendTicketCreatedQPS)
 	ticketTotal := activeScenario.FrontendTotalTicketsToCreate
@@ -75,7 +82,7 @@ func run(cfg config.View) {
 	}
 }
 
-func runner(fe pb.FrontendServiceClient) {
+func runner(fe *frontendPool) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -89,7 +96,7 @@ func runner(fe pb.FrontendServiceClient) {
 	time.Sleep(time.Duration(rand.Int63n(int64(time.Second))))
 
 	g.start(mRunnersCreating)
-	id, err := createTicket(ctx, fe)
+	id, err := createTicket(ctx, fe.next())
 	if err != nil {
 		logger.WithError(err).Error("failed to create a ticket")
 		return
@@ -98,7 +105,7 @@ func runner(fe pb.FrontendServiceClient) {
 	_ = id
 }
 
-func createTicket(ctx context.Context, fe pb.FrontendServiceClient) (string, error) {
+func createTicket(ctx context.Context, fe *frontendConn) (string, error) {
 	ctx, span := trace.StartSpan(ctx, "scale.frontend/CreateTicket")
 	defer span.End()
 
@@ -106,16 +113,56 @@ func createTicket(ctx context.Context, fe pb.FrontendServiceClient) (string, err
 		Ticket: activeScenario.Ticket(),
 	}
 
-	resp, err := fe.CreateTicket(ctx, req)
+	resp, err := fe.client.CreateTicket(ctx, req)
 	if err != nil {
-		telemetry.RecordUnitMeasurement(ctx, mTicketCreationsFailed)
+		telemetry.RecordUnitMeasurement(ctx, mTicketCreationsFailed, fe.tag)
 		return "", err
 	}
 
-	telemetry.RecordUnitMeasurement(ctx, mTicketsCreated)
+	telemetry.RecordUnitMeasurement(ctx, mTicketsCreated, fe.tag)
 	return resp.Id, nil
 }
 
+// frontendConn is a single connection of a frontendPool, along with the tag
+// its throughput is reported under.
+type frontendConn struct {
+	client pb.FrontendServiceClient
+	tag    tag.Mutator
+}
+
+// frontendPool spreads calls across several frontend connections, so the
+// concurrent streams of the runners aren't all multiplexed over one HTTP/2
+// connection.
+type frontendPool struct {
+	conns   []*frontendConn
+	counter uint64
+}
+
+func newFrontendPool(size int, dial func() (*grpc.ClientConn, error)) (*frontendPool, error) {
+	if size < 1 {
+		size = 1
+	}
+
+	p := &frontendPool{}
+	for i := 0; i < size; i++ {
+		conn, err := dial()
+		if err != nil {
+			return nil, err
+		}
+		p.conns = append(p.conns, &frontendConn{
+			client: pb.NewFrontendServiceClient(conn),
+			tag:    tag.Upsert(connectionKey, strconv.Itoa(i)),
+		})
+	}
+	return p, nil
+}
+
+// next returns the pool's connections in round robin order.
+func (p *frontendPool) next() *frontendConn {
+	n := atomic.AddUint64(&p.counter, 1)
+	return p.conns[n%uint64(len(p.conns))]
+}
+
 // Allows concurrent moficiation of a gauge value by modifying the concurrent
 // value with a delta.
 func concurrentGauge(s *stats.Int64Measure) func(delta int64) {
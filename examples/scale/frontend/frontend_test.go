@@ -0,0 +1,105 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	shellTesting "open-match.dev/open-match/internal/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// echoFrontend answers CreateTicket without touching any state, so the
+// benchmarks measure the cost of the connections alone.
+type echoFrontend struct {
+	shellTesting.FakeFrontend
+}
+
+func (*echoFrontend) CreateTicket(ctx context.Context, req *pb.CreateTicketRequest) (*pb.Ticket, error) {
+	return &pb.Ticket{Id: "1"}, nil
+}
+
+func TestFrontendPoolRoundRobin(t *testing.T) {
+	dial := serveFrontend(t)
+
+	p, err := newFrontendPool(3, dial)
+	require.NoError(t, err)
+	require.Len(t, p.conns, 3)
+
+	seen := map[*frontendConn]int{}
+	for i := 0; i < 9; i++ {
+		seen[p.next()]++
+	}
+	for _, c := range p.conns {
+		require.Equal(t, 3, seen[c])
+	}
+
+	p, err = newFrontendPool(0, dial)
+	require.NoError(t, err)
+	require.Len(t, p.conns, 1)
+}
+
+func BenchmarkCreateTicket(b *testing.B) {
+	dial := serveFrontend(b)
+
+	for _, size := range []int{1, 8} {
+		size := size
+		b.Run(fmt.Sprintf("connections=%d", size), func(b *testing.B) {
+			p, err := newFrontendPool(size, dial)
+			require.NoError(b, err)
+
+			b.SetParallelism(64)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := createTicket(context.Background(), p.next()); err != nil {
+						b.Error(err)
+					}
+				}
+			})
+		})
+	}
+}
+
+// serveFrontend starts an in-process frontend and returns a function dialing
+// new connections to it.
+func serveFrontend(tb testing.TB) func() (*grpc.ClientConn, error) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(tb, err)
+	s := grpc.NewServer()
+	pb.RegisterFrontendServiceServer(s, &echoFrontend{})
+	go s.Serve(l)
+
+	var conns []*grpc.ClientConn
+	tb.Cleanup(func() {
+		for _, c := range conns {
+			c.Close()
+		}
+		s.Stop()
+	})
+
+	return func() (*grpc.ClientConn, error) {
+		conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+		if err == nil {
+			conns = append(conns, conn)
+		}
+		return conn, err
+	}
+}
@@ -16,6 +16,7 @@ package scenarios
 
 import (
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -62,6 +63,7 @@ var ActiveScenario = func() *Scenario {
 	return &Scenario{
 		FrontendTotalTicketsToCreate: -1,
 		FrontendTicketCreatedQPS:     100,
+		FrontendConnections:          1,
 
 		BackendAssignsTickets: true,
 		BackendDeletesTickets: true,
@@ -89,12 +91,21 @@ type Scenario struct {
 	// MatchExtensionSize        int
 	FrontendTotalTicketsToCreate int // TotalTicketsToCreate = -1 let scale-frontend create tickets forever
 	FrontendTicketCreatedQPS     uint32
+	FrontendConnections          int // Number of frontend connections the ticket creations are spread across
 
 	// GameBackend Configs
 	// ProfileNumber      int
 	// FilterNumber       int
 	BackendAssignsTickets bool
 	BackendDeletesTickets bool
+	// BackendProfileWeights skews the FetchMatches calls towards the profiles
+	// with the higher weights, by profile name.  Profiles without a weight
+	// have a weight of 1.  When empty, every profile is fetched equally.
+	BackendProfileWeights map[string]float64
+	// BackendAssignmentWindow, when set, accumulates the fetched matches for
+	// the window and assigns them together, as a backend evaluating matches
+	// in batches would.  Otherwise each match is assigned as it is fetched.
+	BackendAssignmentWindow time.Duration
 
 	Ticket   func() *pb.Ticket
 	Profiles func() []*pb.MatchProfile
@@ -57,6 +57,10 @@ type TeamShooterScenario struct {
 	skillBoundaries []float64
 	// Maximum difference between two tickets to consider a match valid.
 	maxSkillDifference float64
+	// Width of the skill range shared by profiles of consecutive skill
+	// brackets, half on each side of the boundary.  Tickets in the shared range
+	// are returned to both profiles, exercising proposal collisions.
+	skillOverlap float64
 	// List of mode names.
 	modes []string
 	// Returns a random mode, with some weight.
@@ -82,6 +86,7 @@ func Scenario() *TeamShooterScenario {
 		playersPerGame:     12,
 		skillBoundaries:    []float64{math.Inf(-1), 0, math.Inf(1)},
 		maxSkillDifference: 0.01,
+		skillOverlap:       0.01,
 		modes:              modes,
 		randomMode:         randomMode,
 	}
@@ -94,8 +99,8 @@ func (t *TeamShooterScenario) Profiles() []*pb.MatchProfile {
 	for _, region := range t.regions {
 		for _, mode := range t.modes {
 			for i := 0; i+1 < len(t.skillBoundaries); i++ {
-				skillMin := t.skillBoundaries[i] - t.maxSkillDifference/2
-				skillMax := t.skillBoundaries[i+1] + t.maxSkillDifference/2
+				skillMin := t.skillBoundaries[i] - t.skillOverlap/2
+				skillMax := t.skillBoundaries[i+1] + t.skillOverlap/2
 				p = append(p, &pb.MatchProfile{
 					Name: fmt.Sprintf("%s_%s_%v-%v", region, mode, skillMin, skillMax),
 					Pools: []*pb.Pool{
@@ -0,0 +1,53 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teamshooter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfilesSkillOverlap(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		overlap float64
+	}{
+		{name: "no overlap", overlap: 0},
+		{name: "overlap", overlap: 0.5},
+	} {
+		testCase := tc
+		t.Run(testCase.name, func(t *testing.T) {
+			s := Scenario()
+			s.regions = []string{"region_0"}
+			s.modes = []string{"pl"}
+			s.skillBoundaries = []float64{math.Inf(-1), 0, 1, math.Inf(1)}
+			s.skillOverlap = testCase.overlap
+
+			profiles := s.Profiles()
+			require.Len(t, profiles, 3)
+
+			for i := 0; i+1 < len(profiles); i++ {
+				lower := profiles[i].Pools[0].DoubleRangeFilters[0]
+				upper := profiles[i+1].Pools[0].DoubleRangeFilters[0]
+
+				// The upper end of a bracket and the lower end of the next one
+				// share a range of the configured width.
+				require.Equal(t, testCase.overlap, lower.Max-upper.Min)
+			}
+		})
+	}
+}
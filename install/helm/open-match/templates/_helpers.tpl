@@ -131,20 +131,31 @@ tolerations:
 {{- end }}
 {{- end -}}
 
+{{- /*
+Probes can't present a client certificate, so when mutual TLS is required they
+use the plain HTTP health check on the healthPort instead of the HTTPS gateway.
+*/ -}}
 {{- define "kubernetes.probe" -}}
+{{- $scheme := "HTTP" -}}
+{{- $port := .port -}}
+{{- if and .isHTTPS .requireClientCertificate -}}
+{{- $port = .healthPort -}}
+{{- else if .isHTTPS -}}
+{{- $scheme = "HTTPS" -}}
+{{- end -}}
 livenessProbe:
   httpGet:
-    scheme: {{ if (.isHTTPS) }}HTTPS{{ else }}HTTP{{ end }}
+    scheme: {{ $scheme }}
     path: /healthz
-    port: {{ .port }}
+    port: {{ $port }}
   initialDelaySeconds: 10
   periodSeconds: 10
   failureThreshold: 3
 readinessProbe:
   httpGet:
-    scheme: {{ if (.isHTTPS) }}HTTPS{{ else }}HTTP{{ end }}
+    scheme: {{ $scheme }}
     path: /healthz?readiness=true
-    port: {{ .port }}
+    port: {{ $port }}
   initialDelaySeconds: 10
   periodSeconds: 10
   failureThreshold: 2
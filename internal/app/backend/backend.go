@@ -17,11 +17,13 @@ package backend
 import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"google.golang.org/grpc"
 	"open-match.dev/open-match/internal/appmain"
 	"open-match.dev/open-match/internal/rpc"
 	"open-match.dev/open-match/internal/statestore"
 	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/internal/util"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -29,8 +31,15 @@ var (
 	totalBytesPerMatch      = stats.Int64("open-match.dev/backend/total_bytes_per_match", "Total bytes per match", stats.UnitBytes)
 	ticketsPerMatch         = stats.Int64("open-match.dev/backend/tickets_per_match", "Number of tickets per match", stats.UnitDimensionless)
 	ticketsReleased         = stats.Int64("open-match.dev/backend/tickets_released", "Number of tickets released per request", stats.UnitDimensionless)
+	ticketsReserved         = stats.Int64("open-match.dev/backend/tickets_reserved", "Number of tickets reserved per request", stats.UnitDimensionless)
+	proposalsOversized      = stats.Int64("open-match.dev/backend/proposals_oversized", "Number of proposals dropped for being larger than the maximum proposal size", stats.UnitDimensionless)
 	ticketsAssigned         = stats.Int64("open-match.dev/backend/tickets_assigned", "Number of tickets assigned per request", stats.UnitDimensionless)
 	ticketsTimeToAssignment = stats.Int64("open-match.dev/backend/ticket_time_to_assignment", "Time to assignment for tickets", stats.UnitMilliseconds)
+	backfillsCreated        = stats.Int64("open-match.dev/backend/backfills_created", "Number of backfills created by match functions", stats.UnitDimensionless)
+	backfillSlotsFilled     = stats.Int64("open-match.dev/backend/backfill_slots_filled", "Number of tickets added to existing backfills", stats.UnitDimensionless)
+	backfillFillLatency     = stats.Int64("open-match.dev/backend/backfill_fill_latency", "Time from backfill creation to a ticket filling one of its slots", stats.UnitMilliseconds)
+
+	profileKey = tag.MustNewKey("profile")
 
 	totalMatchesView = &view.View{
 		Measure:     totalBytesPerMatch,
@@ -62,6 +71,18 @@ var (
 		Description: "Number of tickets released per request",
 		Aggregation: view.Sum(),
 	}
+	ticketsReservedView = &view.View{
+		Measure:     ticketsReserved,
+		Name:        "open-match.dev/backend/tickets_reserved",
+		Description: "Number of tickets reserved per request",
+		Aggregation: view.Sum(),
+	}
+	proposalsOversizedView = &view.View{
+		Measure:     proposalsOversized,
+		Name:        "open-match.dev/backend/proposals_oversized",
+		Description: "Number of proposals dropped for being larger than the maximum proposal size",
+		Aggregation: view.Sum(),
+	}
 
 	ticketsTimeToAssignmentView = &view.View{
 		Measure:     ticketsTimeToAssignment,
@@ -69,14 +90,71 @@ var (
 		Description: "Time to assignment for tickets",
 		Aggregation: telemetry.DefaultMillisecondsDistribution,
 	}
+
+	// The backfill views are tagged by profile so that the fraction of slots
+	// filled within a latency objective can be computed, and alerted on, per
+	// game mode.
+	backfillsCreatedView = &view.View{
+		Measure:     backfillsCreated,
+		Name:        "open-match.dev/backend/backfills_created",
+		Description: "Number of backfills created by match functions",
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{profileKey},
+	}
+	backfillSlotsFilledView = &view.View{
+		Measure:     backfillSlotsFilled,
+		Name:        "open-match.dev/backend/backfill_slots_filled",
+		Description: "Number of tickets added to existing backfills",
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{profileKey},
+	}
+	backfillFillLatencyView = &view.View{
+		Measure:     backfillFillLatency,
+		Name:        "open-match.dev/backend/backfill_fill_latency",
+		Description: "Time from backfill creation to a ticket filling one of its slots",
+		Aggregation: telemetry.DefaultMillisecondsDistribution,
+		TagKeys:     []tag.Key{profileKey},
+	}
 )
 
 // BindService creates the backend service and binds it to the serving harness.
 func BindService(p *appmain.Params, b *appmain.Bindings) error {
+	assignmentCipher, err := util.NewAssignmentCipher(p.Config())
+	if err != nil {
+		return err
+	}
+	auditLog, err := util.NewAuditLog(p.Config())
+	if err != nil {
+		return err
+	}
+	order, err := newProposalOrder(p.Config())
+	if err != nil {
+		return err
+	}
+	maxProposalBytes, err := newMaxProposalBytes(p.Config())
+	if err != nil {
+		return err
+	}
+
 	service := &backendService{
-		synchronizer: newSynchronizerClient(p.Config()),
-		store:        statestore.New(p.Config()),
-		cc:           rpc.NewClientCache(p.Config()),
+		synchronizer:      newSynchronizerClient(p.Config()),
+		store:             statestore.New(p.Config()),
+		cc:                rpc.NewClientCache(p.Config()),
+		webhook:           util.NewAssignmentWebhook(p.Config()),
+		maxFetchDuration:  p.Config().GetDuration("maxFetchMatchesDuration"),
+		assignmentCipher:  assignmentCipher,
+		auditLog:          auditLog,
+		validateProposals: p.Config().GetBool("validateProposals"),
+		mmfSlots:          newMmfSlots(p.Config()),
+		proposalOrder:     order,
+		reservationLease:  newReservationLease(p.Config()),
+		maxProposalBytes:  maxProposalBytes,
+	}
+	if service.webhook != nil {
+		b.AddCloser(service.webhook.Close)
+	}
+	if service.auditLog != nil {
+		b.AddCloserErr(service.auditLog.Close)
 	}
 
 	b.AddHealthCheckFunc(service.store.HealthCheck)
@@ -89,7 +167,12 @@ func BindService(p *appmain.Params, b *appmain.Bindings) error {
 		ticketsPerMatchView,
 		ticketsAssignedView,
 		ticketsReleasedView,
+		ticketsReservedView,
+		proposalsOversizedView,
 		ticketsTimeToAssignmentView,
+		backfillsCreatedView,
+		backfillSlotsFilledView,
+		backfillFillLatencyView,
 	)
 	return nil
 }
@@ -15,6 +15,7 @@
 package backend
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -25,6 +26,8 @@ import (
 	"time"
 
 	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
@@ -36,10 +39,13 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/app/evaluator/defaulteval"
 	"open-match.dev/open-match/internal/appmain/contextcause"
+	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/ipb"
 	"open-match.dev/open-match/internal/rpc"
 	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/internal/util"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -49,6 +55,33 @@ type backendService struct {
 	synchronizer *synchronizerClient
 	store        statestore.Service
 	cc           *rpc.ClientCache
+	// webhook is notified of successful assignments.  It is nil when no
+	// webhook is configured.
+	webhook *util.AssignmentWebhook
+	// maxFetchDuration ends FetchMatches streams which run longer, so clients
+	// issue fresh requests.  Zero leaves streams unbounded.
+	maxFetchDuration time.Duration
+	// assignmentCipher encrypts assignment connections before they are
+	// stored.  It is nil when assignments are stored in plaintext.
+	assignmentCipher *util.AssignmentCipher
+	// validateProposals drops proposals with tickets outside of the profile's
+	// pools, or which are no longer active.
+	validateProposals bool
+	// auditLog records successful assignments.  It is nil when no audit sink
+	// is configured.
+	auditLog *util.AuditLog
+	// mmfSlots bounds the number of concurrent match function calls.  It is
+	// nil when the number of calls is unbounded.
+	mmfSlots chan struct{}
+	// proposalOrder sorts proposals before they are evaluated.  It is nil
+	// when proposals are evaluated in the order they are received.
+	proposalOrder proposalOrder
+	// reservationLease is how long ReserveTickets reserves tickets when the
+	// request does not set a lease.
+	reservationLease time.Duration
+	// maxProposalBytes is the largest serialized proposal FetchMatches passes
+	// on.  Larger proposals are dropped.
+	maxProposalBytes int
 }
 
 var (
@@ -57,12 +90,19 @@ var (
 		"component": "app.backend",
 	})
 	errBackfillGenerationMismatch = errors.New("backfill generation mismatch")
+	errBackfillSlotsExhausted     = errors.New("backfill open slots exhausted")
 )
 
 // FetchMatches triggers a MatchFunction with the specified MatchProfiles, while each MatchProfile
 // returns a set of match proposals. FetchMatches method streams the results back to the caller.
 // FetchMatches immediately returns an error if it encounters any execution failures.
 //   - If the synchronizer is enabled, FetchMatch will then call the synchronizer to deduplicate proposals with overlapped tickets.
+//   - If no matches are returned and the request asks for it, a NoMatchSummary is sent before the stream ends.
+//   - If maxFetchMatchesDuration is configured, the stream ends without an error once it runs that long.
+//   - If validateProposals is enabled, proposals with tickets outside of the profile's pools, or which are no longer active, are dropped.
+//   - If maxConcurrentMmfCalls is configured, the match function call waits while that many calls are already running.
+//   - If proposalOrder is configured, proposals are held until the match function is done, and sent to the synchronizer in that order.
+//   - Proposals larger than maxProposalBytes are dropped with a warning, instead of failing the stream.
 func (s *backendService) FetchMatches(req *pb.FetchMatchesRequest, stream pb.BackendService_FetchMatchesServer) error {
 	if req.Config == nil {
 		return status.Error(codes.InvalidArgument, ".config is required")
@@ -71,8 +111,20 @@ func (s *backendService) FetchMatches(req *pb.FetchMatchesRequest, stream pb.Bac
 		return status.Error(codes.InvalidArgument, ".profile is required")
 	}
 
+	validator, err := s.newProposalValidator(req.Profile)
+	if err != nil {
+		return err
+	}
+
+	streamCtx := stream.Context()
+	if s.maxFetchDuration > 0 {
+		var cancel context.CancelFunc
+		streamCtx, cancel = context.WithTimeout(streamCtx, s.maxFetchDuration)
+		defer cancel()
+	}
+
 	// Error group for handling the synchronizer calls only.
-	eg, ctx := errgroup.WithContext(stream.Context())
+	eg, ctx := errgroup.WithContext(streamCtx)
 	syncStream, err := s.synchronizer.synchronize(ctx)
 	if err != nil {
 		return err
@@ -86,12 +138,13 @@ func (s *backendService) FetchMatches(req *pb.FetchMatchesRequest, stream pb.Bac
 	startMmfs := make(chan struct{})
 	proposals := make(chan *pb.Match)
 	m := &sync.Map{}
+	summary := &fetchSummary{}
 
 	eg.Go(func() error {
-		return synchronizeSend(ctx, syncStream, m, proposals)
+		return synchronizeSend(ctx, syncStream, m, proposals, summary, validator, s.maxProposalBytes)
 	})
 	eg.Go(func() error {
-		return synchronizeRecv(ctx, syncStream, m, stream, startMmfs, cancelMmfs, s.store)
+		return synchronizeRecv(ctx, syncStream, m, stream, startMmfs, cancelMmfs, s.store, summary)
 	})
 
 	var mmfErr error
@@ -99,24 +152,80 @@ func (s *backendService) FetchMatches(req *pb.FetchMatchesRequest, stream pb.Bac
 	case <-mmfCtx.Done():
 		mmfErr = fmt.Errorf("mmf was never started")
 	case <-startMmfs:
-		mmfErr = callMmf(mmfCtx, s.cc, req, proposals)
+		if s.proposalOrder != nil {
+			mmfErr = callOrderedMmf(mmfCtx, s.cc, s.mmfSlots, s.maxProposalBytes, s.proposalOrder, req, proposals)
+		} else {
+			mmfErr = callMmf(mmfCtx, s.cc, s.mmfSlots, s.maxProposalBytes, req, proposals)
+		}
 	}
 
 	syncErr := eg.Wait()
 
+	// The matches sent are counted once per call rather than once per match,
+	// with the stream's context as the error group's is canceled by now.
+	if err = s.store.RecordMatchesMade(stream.Context(), summary.sent); err != nil {
+		logger.WithError(err).Error("failed to record matches made")
+	}
+
 	// TODO: Send mmf error in FetchSummary instead of erroring call.
 	if syncErr != nil || mmfErr != nil {
-		return fmt.Errorf(
-			"error(s) in FetchMatches call. syncErr=[%v], mmfErr=[%v]",
-			syncErr,
-			mmfErr,
-		)
+		// Errors caused by reaching the maximum duration end the stream
+		// cleanly, as long as the caller hasn't gone away.
+		if streamCtx.Err() != context.DeadlineExceeded || stream.Context().Err() != nil {
+			return fmt.Errorf(
+				"error(s) in FetchMatches call. syncErr=[%v], mmfErr=[%v]",
+				syncErr,
+				mmfErr,
+			)
+		}
+		logger.Debugf("FetchMatches stream reached the maximum duration of %s", s.maxFetchDuration)
+	}
+
+	if req.GetIncludeNoMatchSummary() && summary.sent == 0 {
+		err = stream.Send(&pb.FetchMatchesResponse{NoMatchSummary: summary.noMatchSummary()})
+		if err != nil {
+			return fmt.Errorf("error sending no match summary to caller of backend: %w", err)
+		}
 	}
 
 	return nil
 }
 
-func synchronizeSend(ctx context.Context, syncStream synchronizerStream, m *sync.Map, proposals <-chan *pb.Match) error {
+// fetchSummary counts what happened to the proposals of a FetchMatches call.
+// proposals, tickets, invalid and oversized are written by synchronizeSend, accepted and
+// sent by synchronizeRecv, and all are read once both have returned.
+type fetchSummary struct {
+	proposals int
+	tickets   int
+	invalid   int
+	oversized int
+	accepted  int
+	sent      int
+}
+
+func (f *fetchSummary) noMatchSummary() *pb.NoMatchSummary {
+	var reason string
+	switch {
+	case f.proposals == 0:
+		reason = "match function returned no proposals"
+	case f.oversized == f.proposals:
+		reason = "all proposals were larger than the maximum proposal size"
+	case f.invalid+f.oversized == f.proposals:
+		reason = "all proposals were invalid for the profile"
+	case f.accepted == 0:
+		reason = "no proposals were accepted by the evaluator"
+	default:
+		reason = "accepted matches were dropped because their backfills were updated concurrently"
+	}
+
+	return &pb.NoMatchSummary{
+		Proposals:         int32(f.proposals),
+		TicketsConsidered: int32(f.tickets),
+		Reason:            reason,
+	}
+}
+
+func synchronizeSend(ctx context.Context, syncStream synchronizerStream, m *sync.Map, proposals <-chan *pb.Match, summary *fetchSummary, validator *proposalValidator, maxProposalBytes int) error {
 sendProposals:
 	for {
 		select {
@@ -126,13 +235,8 @@ sendProposals:
 			if !ok {
 				break sendProposals
 			}
-			_, loaded := m.LoadOrStore(p.GetMatchId(), p)
-			if loaded {
-				return fmt.Errorf("MatchMakingFunction returned same match_id twice: \"%s\"", p.GetMatchId())
-			}
-			err := syncStream.Send(&ipb.SynchronizeRequest{Proposal: p})
-			if err != nil {
-				return fmt.Errorf("error sending proposal to synchronizer: %w", err)
+			if err := sendProposal(ctx, syncStream, m, p, summary, validator, maxProposalBytes); err != nil {
+				return err
 			}
 		}
 	}
@@ -144,7 +248,55 @@ sendProposals:
 	return nil
 }
 
-func synchronizeRecv(ctx context.Context, syncStream synchronizerStream, m *sync.Map, stream pb.BackendService_FetchMatchesServer, startMmfs chan<- struct{}, cancelMmfs contextcause.CancelErrFunc, store statestore.Service) error {
+// sendProposal checks a proposal of the match functions and sends it to the
+// synchronizer, within a span recording the match, its size, and whether it
+// was sent, or dropped as oversized or invalid.
+func sendProposal(ctx context.Context, syncStream synchronizerStream, m *sync.Map, p *pb.Match, summary *fetchSummary, validator *proposalValidator, maxProposalBytes int) error {
+	ctx, span := trace.StartSpan(ctx, "open-match/backend.Proposal")
+	defer span.End()
+	span.AddAttributes(
+		trace.StringAttribute("matchId", p.GetMatchId()),
+		trace.StringAttribute("matchProfile", p.GetMatchProfile()),
+		trace.StringAttribute("matchFunction", p.GetMatchFunction()),
+		trace.Int64Attribute("tickets", int64(len(p.GetTickets()))),
+	)
+
+	_, loaded := m.LoadOrStore(p.GetMatchId(), p)
+	if loaded {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeAlreadyExists, Message: "duplicate match id"})
+		return fmt.Errorf("MatchMakingFunction returned same match_id twice: \"%s\"", p.GetMatchId())
+	}
+	summary.proposals++
+	summary.tickets += len(p.GetTickets())
+	if size := proto.Size(p); size > maxProposalBytes {
+		// The synchronizer and the caller couldn't receive the
+		// proposal, so drop it rather than fail the whole stream.
+		logger.Warningf("dropping proposal %s of %d bytes, which is larger than the maximum of %d bytes", p.GetMatchId(), size, maxProposalBytes)
+		stats.Record(ctx, proposalsOversized.M(1))
+		summary.oversized++
+		span.AddAttributes(trace.StringAttribute("outcome", "oversized"))
+		return nil
+	}
+	valid, err := validator.valid(ctx, p)
+	if err != nil {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeInternal, Message: err.Error()})
+		return fmt.Errorf("error validating proposal %s: %w", p.GetMatchId(), err)
+	}
+	if !valid {
+		summary.invalid++
+		span.AddAttributes(trace.StringAttribute("outcome", "invalid"))
+		return nil
+	}
+	err = syncStream.Send(&ipb.SynchronizeRequest{Proposal: p})
+	if err != nil {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnavailable, Message: err.Error()})
+		return fmt.Errorf("error sending proposal to synchronizer: %w", err)
+	}
+	span.AddAttributes(trace.StringAttribute("outcome", "sent"))
+	return nil
+}
+
+func synchronizeRecv(ctx context.Context, syncStream synchronizerStream, m *sync.Map, stream pb.BackendService_FetchMatchesServer, startMmfs chan<- struct{}, cancelMmfs contextcause.CancelErrFunc, store statestore.Service, summary *fetchSummary) error {
 	var startMmfsOnce sync.Once
 
 	for {
@@ -171,10 +323,11 @@ func synchronizeRecv(ctx context.Context, syncStream synchronizerStream, m *sync
 			if !ok {
 				return fmt.Errorf("error casting sync map value into *pb.Match: %w", err)
 			}
+			summary.accepted++
 
 			err = createOrUpdateBackfill(ctx, match, store)
 			if err != nil {
-				if err == errBackfillGenerationMismatch {
+				if err == errBackfillGenerationMismatch || err == errBackfillSlotsExhausted {
 					continue
 				}
 				return errors.Wrapf(err, "failed to handle match backfill: %s", match.MatchId)
@@ -186,18 +339,43 @@ func synchronizeRecv(ctx context.Context, syncStream synchronizerStream, m *sync
 			if err != nil {
 				return fmt.Errorf("error sending match to caller of backend: %w", err)
 			}
+			summary.sent++
 		}
 	}
 }
 
-// callMmf triggers execution of MMFs to fetch match proposals.
-func callMmf(ctx context.Context, cc *rpc.ClientCache, req *pb.FetchMatchesRequest, proposals chan<- *pb.Match) error {
+// mmfProtocolVersion is the version of the RunRequest and RunResponse contract
+// the backend speaks.  Match functions which don't report a version speak
+// version 1.
+const mmfProtocolVersion = 1
+
+// checkProtocolVersion fails with FailedPrecondition if the match function
+// which sent resp speaks a different protocol version than the backend.
+func checkProtocolVersion(profile *pb.MatchProfile, resp *pb.RunResponse) error {
+	v := resp.GetProtocolVersion()
+	if v == 0 || v == mmfProtocolVersion {
+		return nil
+	}
+	return status.Errorf(codes.FailedPrecondition, "match function for profile %s speaks protocol version %d, but the backend speaks version %d", profile.GetName(), v, mmfProtocolVersion)
+}
+
+// callMmf triggers execution of MMFs to fetch match proposals.  When slots is
+// not nil, the call waits for a free slot before the MMF is run.
+func callMmf(ctx context.Context, cc *rpc.ClientCache, slots chan struct{}, maxProposalBytes int, req *pb.FetchMatchesRequest, proposals chan<- *pb.Match) error {
 	defer close(proposals)
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
 	address := fmt.Sprintf("%s:%d", req.GetConfig().GetHost(), req.GetConfig().GetPort())
 
 	switch req.GetConfig().GetType() {
 	case pb.FunctionConfig_GRPC:
-		return callGrpcMmf(ctx, cc, req.GetProfile(), address, proposals)
+		return callGrpcMmf(ctx, cc, req.GetProfile(), address, maxProposalBytes, proposals)
 	case pb.FunctionConfig_REST:
 		return callHTTPMmf(ctx, cc, req.GetProfile(), address, proposals)
 	default:
@@ -205,7 +383,7 @@ func callMmf(ctx context.Context, cc *rpc.ClientCache, req *pb.FetchMatchesReque
 	}
 }
 
-func callGrpcMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProfile, address string, proposals chan<- *pb.Match) error {
+func callGrpcMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProfile, address string, maxProposalBytes int, proposals chan<- *pb.Match) error {
 	var conn *grpc.ClientConn
 	conn, err := cc.GetGRPC(address)
 	if err != nil {
@@ -213,7 +391,10 @@ func callGrpcMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProf
 	}
 	client := pb.NewMatchFunctionClient(conn)
 
-	stream, err := client.Run(ctx, &pb.RunRequest{Profile: profile})
+	// Oversized proposals are dropped by FetchMatches, so those not much
+	// larger than maxProposalBytes must not fail the stream on the way in.
+	recvSize := grpc.MaxCallRecvMsgSize(maxProposalBytes + mmfRecvHeadroomBytes)
+	stream, err := client.Run(ctx, &pb.RunRequest{Profile: profile, ProtocolVersion: mmfProtocolVersion}, recvSize)
 	if err != nil {
 		err = errors.Wrap(err, "failed to run match function for profile")
 		if ctx.Err() != nil {
@@ -236,6 +417,9 @@ func callGrpcMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProf
 			}
 			return err
 		}
+		if err = checkProtocolVersion(profile, resp); err != nil {
+			return err
+		}
 		select {
 		case proposals <- resp.GetProposal():
 		case <-ctx.Done():
@@ -254,7 +438,7 @@ func callHTTPMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProf
 	}
 
 	var m jsonpb.Marshaler
-	strReq, err := m.MarshalToString(&pb.RunRequest{Profile: profile})
+	strReq, err := m.MarshalToString(&pb.RunRequest{Profile: profile, ProtocolVersion: mmfProtocolVersion})
 	if err != nil {
 		return status.Errorf(codes.FailedPrecondition, "failed to marshal profile pb to string for profile %s: %s", profile.GetName(), err.Error())
 	}
@@ -292,10 +476,16 @@ func callHTTPMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProf
 		if len(item.Error) != 0 {
 			return status.Errorf(codes.Unavailable, "failed to execute matchfunction.Run: %v", item.Error)
 		}
+		// Fields added by newer protocol versions are ignored, so that the
+		// version check below reports the mismatch.
 		resp := &pb.RunResponse{}
-		if err := jsonpb.UnmarshalString(string(item.Result), resp); err != nil {
+		u := jsonpb.Unmarshaler{AllowUnknownFields: true}
+		if err := u.Unmarshal(bytes.NewReader(item.Result), resp); err != nil {
 			return status.Errorf(codes.Unavailable, "failed to execute json.Unmarshal(%s, &resp): %v", item.Result, err)
 		}
+		if err := checkProtocolVersion(profile, resp); err != nil {
+			return err
+		}
 		select {
 		case proposals <- resp.GetProposal():
 		case <-ctx.Done():
@@ -327,10 +517,12 @@ func (s *backendService) ReleaseAllTickets(ctx context.Context, req *pb.ReleaseA
 
 // AssignTickets overwrites the Assignment field of the input TicketIds.
 func (s *backendService) AssignTickets(ctx context.Context, req *pb.AssignTicketsRequest) (*pb.AssignTicketsResponse, error) {
-	resp, err := doAssignTickets(ctx, req, s.store)
+	resp, err := doAssignTickets(ctx, req, s.store, s.assignmentCipher)
 	if err != nil {
 		return nil, err
 	}
+	s.webhook.Notify(req, resp)
+	s.auditLog.Assigned(req, resp)
 
 	numIds := 0
 	for _, ag := range req.Assignments {
@@ -341,6 +533,346 @@ func (s *backendService) AssignTickets(ctx context.Context, req *pb.AssignTicket
 	return resp, nil
 }
 
+// StreamAssignTickets commits each assignment group received on the stream
+// as its own AssignTickets request, and answers it with that group's failures.
+func (s *backendService) StreamAssignTickets(stream pb.BackendService_StreamAssignTicketsServer) error {
+	ctx := stream.Context()
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if req.GetAssignment() == nil {
+			return status.Error(codes.InvalidArgument, ".assignment is required")
+		}
+
+		resp, err := s.AssignTickets(ctx, &pb.AssignTicketsRequest{Assignments: []*pb.AssignmentGroup{req.GetAssignment()}})
+		if err != nil {
+			return err
+		}
+
+		err = stream.Send(&pb.StreamAssignTicketsResponse{Failures: resp.GetFailures()})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Stats returns aggregate matchmaking numbers read from counters maintained
+// in state storage.
+func (s *backendService) Stats(ctx context.Context, req *pb.StatsRequest) (*pb.StatsResponse, error) {
+	st, err := s.store.GetStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.StatsResponse{
+		ActiveTickets:             st.ActiveTickets,
+		Backfills:                 st.Backfills,
+		MatchesLastMinute:         st.MatchesLastMinute,
+		TicketsAssignedLastMinute: st.TicketsAssignedLastMinute,
+	}, nil
+}
+
+// ReserveTickets hides tickets from queries until they are assigned or
+// released, or until the lease ends and they return to the pool.
+func (s *backendService) ReserveTickets(ctx context.Context, req *pb.ReserveTicketsRequest) (*pb.ReserveTicketsResponse, error) {
+	if len(req.GetTicketIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, ".ticket_ids is required")
+	}
+
+	lease := s.reservationLease
+	if req.GetLease() != nil {
+		var err error
+		lease, err = ptypes.Duration(req.GetLease())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid .lease: %s", err.Error())
+		}
+	}
+	if lease <= 0 {
+		return nil, status.Error(codes.InvalidArgument, ".lease must be positive")
+	}
+
+	releaseTime := time.Now().Add(lease)
+	if err := s.store.ReserveTickets(ctx, req.GetTicketIds(), lease); err != nil {
+		return nil, err
+	}
+
+	ts, err := ptypes.TimestampProto(releaseTime)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert release time: %s", err.Error())
+	}
+	stats.Record(ctx, ticketsReserved.M(int64(len(req.GetTicketIds()))))
+	return &pb.ReserveTicketsResponse{ReleaseTime: ts}, nil
+}
+
+// defaultMaxProposalBytes is gRPC's default maximum received message size,
+// which the synchronizer and FetchMatches callers use unless configured
+// otherwise.
+const defaultMaxProposalBytes = 4 * 1024 * 1024
+
+// mmfRecvHeadroomBytes is how much larger than maxProposalBytes a message
+// from a match function may be, so that slightly oversized proposals are
+// dropped rather than failing the match function's stream.
+const mmfRecvHeadroomBytes = 1024 * 1024
+
+// newMaxProposalBytes returns maxProposalBytes, or defaultMaxProposalBytes if
+// it is unset or zero.  A negative maxProposalBytes is an error.
+func newMaxProposalBytes(cfg config.View) (int, error) {
+	const name = "maxProposalBytes"
+
+	n := cfg.GetInt(name)
+	if n < 0 {
+		return 0, errors.Errorf("%s must not be negative, got %d", name, n)
+	}
+	if !cfg.IsSet(name) || n == 0 {
+		return defaultMaxProposalBytes, nil
+	}
+	return n, nil
+}
+
+// newReservationLease returns the default lease of ReserveTickets, which is
+// ticketReservationLease, or the pending release timeout if that is unset.
+func newReservationLease(cfg config.View) time.Duration {
+	if cfg.IsSet("ticketReservationLease") {
+		return cfg.GetDuration("ticketReservationLease")
+	}
+	return cfg.GetDuration("pendingReleaseTimeout")
+}
+
+// GetTicketPendingRelease reports whether a Ticket is hidden from queries for
+// having been returned in a match, and when that times out.
+func (s *backendService) GetTicketPendingRelease(ctx context.Context, req *pb.GetTicketPendingReleaseRequest) (*pb.GetTicketPendingReleaseResponse, error) {
+	if req.GetTicketId() == "" {
+		return nil, status.Error(codes.InvalidArgument, ".ticket_id is required")
+	}
+
+	releaseTime, pending, err := s.store.GetPendingReleaseTime(ctx, req.GetTicketId())
+	if err != nil {
+		return nil, err
+	}
+	if !pending {
+		return &pb.GetTicketPendingReleaseResponse{}, nil
+	}
+
+	ts, err := ptypes.TimestampProto(releaseTime)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert release time: %s", err.Error())
+	}
+	return &pb.GetTicketPendingReleaseResponse{PendingRelease: true, ReleaseTime: ts}, nil
+}
+
+// MatchAndAssign runs the MatchFunction for a single profile, selects the best
+// proposal with the default evaluator, and assigns the proposal's tickets.
+// Unlike FetchMatches, proposals do not go through the synchronizer: the
+// selected proposal's tickets are added to pending release at once, and the
+// call is aborted if another proposal already holds any of them.
+func (s *backendService) MatchAndAssign(ctx context.Context, req *pb.MatchAndAssignRequest) (*pb.MatchAndAssignResponse, error) {
+	if req.Config == nil {
+		return nil, status.Error(codes.InvalidArgument, ".config is required")
+	}
+	if req.Profile == nil {
+		return nil, status.Error(codes.InvalidArgument, ".profile is required")
+	}
+	if req.Assignment == nil {
+		return nil, status.Error(codes.InvalidArgument, ".assignment is required")
+	}
+
+	validator, err := s.newProposalValidator(req.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := collectProposals(ctx, s.cc, s.mmfSlots, s.maxProposalBytes, req.Config, req.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	valid := matches[:0]
+	for _, m := range matches {
+		ok, err := validator.valid(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			valid = append(valid, m)
+		}
+	}
+	s.proposalOrder.sort(valid)
+
+	match, err := selectBestProposal(ctx, valid)
+	if err != nil {
+		return nil, err
+	}
+	if match == nil {
+		return nil, status.Errorf(codes.NotFound, "match function returned no match for profile %s", req.Profile.GetName())
+	}
+
+	ids := make([]string, 0, len(match.Tickets))
+	for _, t := range match.Tickets {
+		ids = append(ids, t.Id)
+	}
+
+	// Keep the tickets out of concurrent queries until they are assigned, and
+	// back off if another proposal got any of them first.
+	err = s.store.ProposeTickets(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	err = createOrUpdateBackfill(ctx, match, s.store)
+	if err != nil {
+		if errRelease := s.store.DeleteTicketsFromPendingRelease(ctx, ids); errRelease != nil {
+			logger.WithError(errRelease).Error("failed to release the tickets of an unassigned match")
+		}
+		if err == errBackfillGenerationMismatch {
+			return nil, status.Errorf(codes.Aborted, "backfill %s of match %s was updated concurrently", match.GetBackfill().GetId(), match.MatchId)
+		}
+		if err == errBackfillSlotsExhausted {
+			return nil, status.Errorf(codes.Aborted, "backfill %s of match %s has too few open slots", match.GetBackfill().GetId(), match.MatchId)
+		}
+		return nil, errors.Wrapf(err, "failed to handle match backfill: %s", match.MatchId)
+	}
+
+	assignReq := &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{{TicketIds: ids, Assignment: req.Assignment}},
+	}
+	resp, err := doAssignTickets(ctx, assignReq, s.store, s.assignmentCipher)
+	if err != nil {
+		return nil, err
+	}
+	s.webhook.Notify(assignReq, resp)
+	s.auditLog.Assigned(assignReq, resp)
+
+	failed := map[string]struct{}{}
+	for _, f := range resp.Failures {
+		failed[f.TicketId] = struct{}{}
+	}
+	for _, t := range match.Tickets {
+		if _, ok := failed[t.Id]; !ok {
+			t.Assignment = req.Assignment
+		}
+	}
+
+	stats.Record(ctx, totalBytesPerMatch.M(int64(proto.Size(match))))
+	stats.Record(ctx, ticketsPerMatch.M(int64(len(match.Tickets))))
+	stats.Record(ctx, ticketsAssigned.M(int64(len(ids)-len(failed))))
+	if err = s.store.RecordMatchesMade(ctx, 1); err != nil {
+		logger.WithError(err).Error("failed to record match made")
+	}
+
+	return &pb.MatchAndAssignResponse{Match: match, Assignment: req.Assignment}, nil
+}
+
+// PreviewMatches runs the MatchFunction for a single profile, and returns its
+// proposals along with their default evaluator score and whether the default
+// evaluator selects them.  Nothing is written to the state store.
+func (s *backendService) PreviewMatches(ctx context.Context, req *pb.PreviewMatchesRequest) (*pb.PreviewMatchesResponse, error) {
+	if req.Config == nil {
+		return nil, status.Error(codes.InvalidArgument, ".config is required")
+	}
+	if req.Profile == nil {
+		return nil, status.Error(codes.InvalidArgument, ".profile is required")
+	}
+
+	matches, err := collectProposals(ctx, s.cc, s.mmfSlots, s.maxProposalBytes, req.Config, req.Profile)
+	if err != nil {
+		return nil, err
+	}
+	s.proposalOrder.sort(matches)
+
+	ids, _, err := evaluateProposals(ctx, matches)
+	if err != nil {
+		return nil, err
+	}
+	selected := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		selected[id] = true
+	}
+
+	resp := &pb.PreviewMatchesResponse{}
+	for _, m := range matches {
+		preview := &pb.MatchPreview{Match: m, Selected: selected[m.GetMatchId()]}
+		if a, ok := m.GetExtensions()["evaluation_input"]; ok {
+			inp := &pb.DefaultEvaluationCriteria{}
+			// Matches with invalid inputs are rejected by the evaluator, and
+			// so are previewed unselected with a zero score.
+			if ptypes.UnmarshalAny(a, inp) == nil {
+				preview.Score = inp.GetScore()
+			}
+		}
+		resp.Previews = append(resp.Previews, preview)
+	}
+
+	return resp, nil
+}
+
+// collectProposals runs the MatchFunction, and returns all of its proposals.
+func collectProposals(ctx context.Context, cc *rpc.ClientCache, slots chan struct{}, maxProposalBytes int, config *pb.FunctionConfig, profile *pb.MatchProfile) ([]*pb.Match, error) {
+	proposals := make(chan *pb.Match)
+	var matches []*pb.Match
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return callMmf(egCtx, cc, slots, maxProposalBytes, &pb.FetchMatchesRequest{Config: config, Profile: profile}, proposals)
+	})
+	eg.Go(func() error {
+		for p := range proposals {
+			matches = append(matches, p)
+		}
+		return nil
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// selectBestProposal returns the first match the default evaluator accepts,
+// which is the highest scored one. Proposals without tickets are ignored.
+func selectBestProposal(ctx context.Context, proposals []*pb.Match) (*pb.Match, error) {
+	ids, byID, err := evaluateProposals(ctx, proposals)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	return byID[ids[0]], nil
+}
+
+// evaluateProposals runs the default evaluator over the proposals with
+// tickets, and returns the IDs of the selected proposals, best first, along
+// with the proposals by ID.
+func evaluateProposals(ctx context.Context, proposals []*pb.Match) ([]string, map[string]*pb.Match, error) {
+	byID := make(map[string]*pb.Match, len(proposals))
+	in := make(chan *pb.Match, len(proposals))
+	for _, p := range proposals {
+		if len(p.GetTickets()) == 0 {
+			continue
+		}
+		if _, ok := byID[p.GetMatchId()]; ok {
+			return nil, nil, fmt.Errorf("MatchMakingFunction returned same match_id twice: \"%s\"", p.GetMatchId())
+		}
+		byID[p.GetMatchId()] = p
+		in <- p
+	}
+	close(in)
+
+	out := make(chan string, len(byID))
+	if err := defaulteval.Evaluate(ctx, in, out); err != nil {
+		return nil, nil, err
+	}
+	close(out)
+
+	var ids []string
+	for id := range out {
+		ids = append(ids, id)
+	}
+	return ids, byID, nil
+}
+
 func createOrUpdateBackfill(ctx context.Context, match *pb.Match, store statestore.Service) error {
 	backfill := match.GetBackfill()
 	if backfill == nil {
@@ -357,7 +889,13 @@ func createOrUpdateBackfill(ctx context.Context, match *pb.Match, store statesto
 		backfill.Id = xid.New().String()
 		backfill.CreateTime = ptypes.TimestampNow()
 		backfill.Generation = 1
-		return store.CreateBackfill(ctx, backfill, ticketIds)
+		err := store.CreateBackfill(ctx, backfill, ticketIds)
+		if err != nil {
+			return err
+		}
+
+		recordBackfillCreated(ctx, match.GetMatchProfile())
+		return nil
 	}
 
 	m := store.NewMutex(backfill.Id)
@@ -385,15 +923,43 @@ func createOrUpdateBackfill(ctx context.Context, match *pb.Match, store statesto
 		return errBackfillGenerationMismatch
 	}
 
+	_, countsSlots := bf.GetExtensions()[statestore.BackfillOpenSlotsKey]
+
 	bf.SearchFields = backfill.SearchFields
 	bf.Extensions = backfill.Extensions
 	bf.Generation++
 
-	return store.UpdateBackfill(ctx, bf, append(ids, ticketIds...))
+	// The match function counts the slots it fills on its own copy of the
+	// backfill, the stored count is decremented along with the update so a
+	// fill never takes more slots than are open, whatever the proposal claims.
+	if countsSlots {
+		err = store.FillBackfill(ctx, bf, append(ids, ticketIds...), int32(len(match.GetTickets())))
+		if status.Code(err) == codes.FailedPrecondition {
+			logger.WithFields(logrus.Fields{"backfill_id": backfill.Id}).
+				WithError(err).
+				Error("failed to fill backfill")
+			return errBackfillSlotsExhausted
+		}
+	} else {
+		err = store.UpdateBackfill(ctx, bf, append(ids, ticketIds...))
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = recordBackfillFilled(ctx, match.GetMatchProfile(), bf, len(match.GetTickets())); err != nil {
+		logger.WithError(err).Errorf("failed to record fill latency for backfill %s", bf.Id)
+	}
+	return nil
 }
 
-func doAssignTickets(ctx context.Context, req *pb.AssignTicketsRequest, store statestore.Service) (*pb.AssignTicketsResponse, error) {
-	resp, tickets, err := store.UpdateAssignments(ctx, req)
+func doAssignTickets(ctx context.Context, req *pb.AssignTicketsRequest, store statestore.Service, assignmentCipher *util.AssignmentCipher) (*pb.AssignTicketsResponse, error) {
+	stored, err := encryptAssignments(req, assignmentCipher)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, tickets, err := store.UpdateAssignments(ctx, stored)
 	if err != nil {
 		return nil, err
 	}
@@ -405,6 +971,10 @@ func doAssignTickets(ctx context.Context, req *pb.AssignTicketsRequest, store st
 		}
 	}
 
+	if err = store.RecordTicketsAssigned(ctx, len(tickets)); err != nil {
+		logger.WithError(err).Error("failed to record tickets assigned")
+	}
+
 	ids := []string{}
 
 	for _, ag := range req.Assignments {
@@ -429,6 +999,53 @@ func doAssignTickets(ctx context.Context, req *pb.AssignTicketsRequest, store st
 	return resp, nil
 }
 
+func recordBackfillCreated(ctx context.Context, profile string) {
+	err := stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(profileKey, profile)}, backfillsCreated.M(1))
+	if err != nil {
+		logger.WithError(err).Error("failed to record backfill creation")
+	}
+}
+
+// recordBackfillFilled records that the given number of tickets filled slots
+// of an existing backfill, along with how long after the backfill's creation
+// the slots were filled.
+func recordBackfillFilled(ctx context.Context, profile string, bf *pb.Backfill, filled int) error {
+	if filled == 0 {
+		return nil
+	}
+
+	created, err := ptypes.Timestamp(bf.CreateTime)
+	if err != nil {
+		return err
+	}
+
+	latency := time.Since(created).Milliseconds()
+	measurements := []stats.Measurement{backfillSlotsFilled.M(int64(filled))}
+	for i := 0; i < filled; i++ {
+		measurements = append(measurements, backfillFillLatency.M(latency))
+	}
+
+	return stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(profileKey, profile)}, measurements...)
+}
+
+// encryptAssignments returns a copy of req with its assignments encrypted, so
+// the caller's request still holds the plaintext assignments.
+func encryptAssignments(req *pb.AssignTicketsRequest, assignmentCipher *util.AssignmentCipher) (*pb.AssignTicketsRequest, error) {
+	if assignmentCipher == nil {
+		return req, nil
+	}
+
+	encrypted := &pb.AssignTicketsRequest{}
+	for _, ag := range req.Assignments {
+		assignment, err := assignmentCipher.Encrypt(ag.Assignment)
+		if err != nil {
+			return nil, err
+		}
+		encrypted.Assignments = append(encrypted.Assignments, &pb.AssignmentGroup{TicketIds: ag.TicketIds, Assignment: assignment})
+	}
+	return encrypted, nil
+}
+
 func recordTimeToAssignment(ctx context.Context, ticket *pb.Ticket) error {
 	if ticket.Assignment == nil {
 		return fmt.Errorf("assignment for ticket %s is nil", ticket.Id)
@@ -444,3 +1061,16 @@ func recordTimeToAssignment(ctx context.Context, ticket *pb.Ticket) error {
 
 	return nil
 }
+
+// newMmfSlots returns the semaphore bounding concurrent match function calls,
+// or nil if maxConcurrentMmfCalls is not configured.  Calls beyond the limit
+// wait for a running call to finish.
+func newMmfSlots(cfg config.View) chan struct{} {
+	const name = "maxConcurrentMmfCalls"
+
+	if !cfg.IsSet(name) || cfg.GetInt(name) <= 0 {
+		return nil
+	}
+
+	return make(chan struct{}, cfg.GetInt(name))
+}
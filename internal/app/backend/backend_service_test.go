@@ -0,0 +1,812 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/ipb"
+	"open-match.dev/open-match/internal/rpc"
+	"open-match.dev/open-match/internal/statestore"
+	statestoreTesting "open-match.dev/open-match/internal/statestore/testing"
+	"open-match.dev/open-match/internal/util"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestBackfillFillLatency(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	require.NoError(t, view.Register(backfillsCreatedView, backfillSlotsFilledView, backfillFillLatencyView))
+	defer view.Unregister(backfillsCreatedView, backfillSlotsFilledView, backfillFillLatencyView)
+
+	// A new backfill is created by the match function, but isn't a fill.
+	created := &pb.Match{
+		MatchId:      "1",
+		MatchProfile: "profile-a",
+		Tickets:      []*pb.Ticket{{Id: "t1"}},
+		Backfill:     &pb.Backfill{},
+	}
+	require.NoError(t, createOrUpdateBackfill(ctx, created, store))
+	require.Equal(t, float64(1), sumData(t, backfillsCreatedView, "profile-a"))
+	require.Equal(t, float64(0), sumData(t, backfillSlotsFilledView, "profile-a"))
+
+	// Age the backfill so that the fill latency is known.
+	bf, ids, err := store.GetBackfill(ctx, created.Backfill.Id)
+	require.NoError(t, err)
+	bf.CreateTime, err = ptypes.TimestampProto(time.Now().Add(-10 * time.Second))
+	require.NoError(t, err)
+	require.NoError(t, store.UpdateBackfill(ctx, bf, ids))
+
+	filled := &pb.Match{
+		MatchId:      "2",
+		MatchProfile: "profile-a",
+		Tickets:      []*pb.Ticket{{Id: "t2"}},
+		Backfill: &pb.Backfill{
+			Id:         bf.Id,
+			Generation: bf.Generation,
+		},
+	}
+	require.NoError(t, createOrUpdateBackfill(ctx, filled, store))
+	require.Equal(t, float64(1), sumData(t, backfillSlotsFilledView, "profile-a"))
+
+	rows, err := view.RetrieveData(backfillFillLatencyView.Name)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, []tag.Tag{{Key: profileKey, Value: "profile-a"}}, rows[0].Tags)
+
+	dist, ok := rows[0].Data.(*view.DistributionData)
+	require.True(t, ok)
+	require.Equal(t, int64(1), dist.Count)
+	require.GreaterOrEqual(t, dist.Min, float64(10*time.Second/time.Millisecond))
+	require.Less(t, dist.Max, float64(20*time.Second/time.Millisecond))
+}
+
+func TestCreateOrUpdateBackfillDecrementsOpenSlots(t *testing.T) {
+	ctx := context.Background()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+
+	openSlots := func(n int32) map[string]*any.Any {
+		a, err := ptypes.MarshalAny(&wrappers.Int32Value{Value: n})
+		require.NoError(t, err)
+		return map[string]*any.Any{statestore.BackfillOpenSlotsKey: a}
+	}
+	require.NoError(t, store.CreateBackfill(ctx, &pb.Backfill{Id: "1", Generation: 1, Extensions: openSlots(2)}, nil))
+
+	// The proposal claims a slot is left after its fill, the stored count of
+	// 2 is decremented instead.
+	fill := func(tickets ...*pb.Ticket) error {
+		bf, _, err := store.GetBackfill(ctx, "1")
+		require.NoError(t, err)
+		return createOrUpdateBackfill(ctx, &pb.Match{
+			MatchId:  "1",
+			Tickets:  tickets,
+			Backfill: &pb.Backfill{Id: "1", Generation: bf.Generation, Extensions: openSlots(1)},
+		}, store)
+	}
+	require.NoError(t, fill(&pb.Ticket{Id: "t1"}, &pb.Ticket{Id: "t2"}))
+
+	bf, _, err := store.GetBackfill(ctx, "1")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), bf.Generation)
+	require.True(t, proto.Equal(openSlots(0)[statestore.BackfillOpenSlotsKey], bf.Extensions[statestore.BackfillOpenSlotsKey]))
+
+	// No slot is left.
+	require.Equal(t, errBackfillSlotsExhausted, fill(&pb.Ticket{Id: "t3"}))
+}
+
+func sumData(t *testing.T, v *view.View, profile string) float64 {
+	rows, err := view.RetrieveData(v.Name)
+	require.NoError(t, err)
+
+	for _, row := range rows {
+		for _, tg := range row.Tags {
+			if tg.Key == profileKey && tg.Value == profile {
+				return row.Data.(*view.SumData).Value
+			}
+		}
+	}
+	return 0
+}
+
+func TestProposalValidator(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	demo := &pb.Ticket{Id: "demo", SearchFields: &pb.SearchFields{Tags: []string{"mode.demo"}}}
+	other := &pb.Ticket{Id: "other"}
+	assigned := &pb.Ticket{
+		Id:           "assigned",
+		SearchFields: &pb.SearchFields{Tags: []string{"mode.demo"}},
+		Assignment:   &pb.Assignment{Connection: "1.2.3.4"},
+	}
+	for _, ticket := range []*pb.Ticket{demo, other, assigned} {
+		require.NoError(t, store.CreateTicket(ctx, ticket))
+	}
+
+	profile := &pb.MatchProfile{
+		Name:  "profile",
+		Pools: []*pb.Pool{{Name: "demo", TagPresentFilters: []*pb.TagPresentFilter{{Tag: "mode.demo"}}}},
+	}
+
+	s := &backendService{store: store}
+	v, err := s.newProposalValidator(profile)
+	require.NoError(t, err)
+	require.Nil(t, v)
+	reason, err := v.invalidReason(ctx, &pb.Match{Tickets: []*pb.Ticket{other}})
+	require.NoError(t, err)
+	require.Empty(t, reason, "disabled validator accepts every proposal")
+
+	s.validateProposals = true
+	v, err = s.newProposalValidator(profile)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		tickets []*pb.Ticket
+		reason  string
+	}{
+		{[]*pb.Ticket{demo}, ""},
+		{[]*pb.Ticket{demo, other}, "ticket other is not in any pool of profile profile"},
+		{[]*pb.Ticket{assigned}, "ticket assigned is already assigned"},
+		{[]*pb.Ticket{{Id: "missing"}}, "ticket missing does not exist"},
+		// Tickets are checked as stored, so altering them doesn't help.
+		{[]*pb.Ticket{{Id: "other", SearchFields: demo.SearchFields}}, "ticket other is not in any pool of profile profile"},
+	} {
+		reason, err := v.invalidReason(ctx, &pb.Match{Tickets: tc.tickets})
+		require.NoError(t, err)
+		require.Equal(t, tc.reason, reason)
+	}
+}
+
+func TestStats(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	s := &backendService{store: store}
+
+	for _, id := range []string{"1", "2", "3"} {
+		ticket := &pb.Ticket{Id: id}
+		require.NoError(t, store.CreateTicket(ctx, ticket))
+		require.NoError(t, store.IndexTicket(ctx, ticket))
+	}
+	backfill := &pb.Backfill{Id: "bf", Generation: 1}
+	require.NoError(t, store.CreateBackfill(ctx, backfill, []string{}))
+	require.NoError(t, store.IndexBackfill(ctx, backfill))
+
+	require.NoError(t, store.RecordMatchesMade(ctx, 1))
+	require.NoError(t, store.RecordMatchesMade(ctx, 1))
+
+	_, err := doAssignTickets(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:  []string{"1", "2"},
+				Assignment: &pb.Assignment{Connection: "1.2.3.4"},
+			},
+		},
+	}, store, nil)
+	require.NoError(t, err)
+
+	resp, err := s.Stats(ctx, &pb.StatsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.ActiveTickets)
+	require.Equal(t, int64(1), resp.Backfills)
+	require.Equal(t, int64(2), resp.MatchesLastMinute)
+	require.Equal(t, int64(2), resp.TicketsAssignedLastMinute)
+}
+
+func TestDoAssignTicketsEncrypted(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	cfg.Set("assignmentEncryptionKey", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	assignmentCipher, err := util.NewAssignmentCipher(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, store.CreateTicket(ctx, &pb.Ticket{Id: "1"}))
+
+	req := &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:  []string{"1"},
+				Assignment: &pb.Assignment{Connection: "1.2.3.4"},
+			},
+		},
+	}
+	_, err = doAssignTickets(ctx, req, store, assignmentCipher)
+	require.NoError(t, err)
+	// The request, which is passed on to the webhook, keeps the plaintext.
+	require.Equal(t, "1.2.3.4", req.Assignments[0].Assignment.Connection)
+
+	ticket, err := store.GetTicket(ctx, "1")
+	require.NoError(t, err)
+	require.NotContains(t, ticket.Assignment.Connection, "1.2.3.4")
+
+	decrypted, err := assignmentCipher.Decrypt(ticket.Assignment)
+	require.NoError(t, err)
+	require.Equal(t, "1.2.3.4", decrypted.Connection)
+}
+
+func TestDoAssignTicketsDuplicateAcrossGroups(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	for _, id := range []string{"1", "2"} {
+		require.NoError(t, store.CreateTicket(ctx, &pb.Ticket{Id: id}))
+	}
+
+	_, err := doAssignTickets(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:  []string{"1", "2"},
+				Assignment: &pb.Assignment{Connection: "a"},
+			},
+			{
+				TicketIds:  []string{"2"},
+				Assignment: &pb.Assignment{Connection: "b"},
+			},
+		},
+	}, store, nil)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Nothing is assigned when the request is rejected.
+	for _, id := range []string{"1", "2"} {
+		ticket, err := store.GetTicket(ctx, id)
+		require.NoError(t, err)
+		require.Nil(t, ticket.Assignment)
+	}
+}
+
+// stubMmf proposes a fixed set of matches for every profile.
+type stubMmf struct {
+	proposals []*pb.Match
+	// protocolVersion is reported on every response.
+	protocolVersion int32
+	// requestVersion is the protocol version of the last request.
+	requestVersion int32
+}
+
+func (m *stubMmf) Run(req *pb.RunRequest, stream pb.MatchFunction_RunServer) error {
+	m.requestVersion = req.GetProtocolVersion()
+	for _, p := range m.proposals {
+		if err := stream.Send(&pb.RunResponse{Proposal: p, ProtocolVersion: m.protocolVersion}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestMatchAndAssign(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	for _, id := range []string{"1", "2", "3"} {
+		ticket := &pb.Ticket{Id: id}
+		require.NoError(t, store.CreateTicket(ctx, ticket))
+		require.NoError(t, store.IndexTicket(ctx, ticket))
+	}
+
+	score := func(s float64) map[string]*any.Any {
+		a, err := ptypes.MarshalAny(&pb.DefaultEvaluationCriteria{Score: s})
+		require.NoError(t, err)
+		return map[string]*any.Any{"evaluation_input": a}
+	}
+	mmf := &stubMmf{proposals: []*pb.Match{
+		{MatchId: "low", Tickets: []*pb.Ticket{{Id: "1"}, {Id: "2"}}, Extensions: score(1)},
+		{MatchId: "high", Tickets: []*pb.Ticket{{Id: "2"}, {Id: "3"}}, Extensions: score(2)},
+	}}
+
+	s := &backendService{store: store, cc: rpc.NewClientCache(cfg)}
+	req := &pb.MatchAndAssignRequest{
+		Config:     startStubMmf(t, mmf),
+		Profile:    &pb.MatchProfile{Name: "simple"},
+		Assignment: &pb.Assignment{Connection: "1.2.3.4"},
+	}
+
+	resp, err := s.MatchAndAssign(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "high", resp.Match.MatchId)
+	require.Equal(t, "1.2.3.4", resp.Assignment.Connection)
+
+	for id, connection := range map[string]string{"1": "", "2": "1.2.3.4", "3": "1.2.3.4"} {
+		ticket, err := store.GetTicket(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, connection, ticket.GetAssignment().GetConnection())
+	}
+
+	// Assigned tickets are no longer indexed for matchmaking.
+	ids, err := store.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+	require.Contains(t, ids, "1")
+
+	// A proposal with a ticket already pending release is not assigned.
+	require.NoError(t, store.AddTicketsToPendingRelease(ctx, []string{"1"}))
+	mmf.proposals = []*pb.Match{{MatchId: "pending", Tickets: []*pb.Ticket{{Id: "1"}}}}
+	_, err = s.MatchAndAssign(ctx, req)
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	ticket, err := store.GetTicket(ctx, "1")
+	require.NoError(t, err)
+	require.Nil(t, ticket.GetAssignment())
+
+	mmf.proposals = nil
+	_, err = s.MatchAndAssign(ctx, req)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestPreviewMatches(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	for _, id := range []string{"1", "2", "3"} {
+		ticket := &pb.Ticket{Id: id}
+		require.NoError(t, store.CreateTicket(ctx, ticket))
+		require.NoError(t, store.IndexTicket(ctx, ticket))
+	}
+
+	score := func(s float64) map[string]*any.Any {
+		a, err := ptypes.MarshalAny(&pb.DefaultEvaluationCriteria{Score: s})
+		require.NoError(t, err)
+		return map[string]*any.Any{"evaluation_input": a}
+	}
+	mmf := &stubMmf{proposals: []*pb.Match{
+		{MatchId: "low", Tickets: []*pb.Ticket{{Id: "1"}, {Id: "2"}}, Extensions: score(1)},
+		{MatchId: "high", Tickets: []*pb.Ticket{{Id: "2"}, {Id: "3"}}, Extensions: score(2)},
+		{MatchId: "backfill", Tickets: []*pb.Ticket{{Id: "1"}}, Backfill: &pb.Backfill{}},
+	}}
+
+	s := &backendService{store: store, cc: rpc.NewClientCache(cfg)}
+	resp, err := s.PreviewMatches(ctx, &pb.PreviewMatchesRequest{
+		Config:  startStubMmf(t, mmf),
+		Profile: &pb.MatchProfile{Name: "simple"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Previews, 3)
+	for i, expected := range []struct {
+		id       string
+		score    float64
+		selected bool
+	}{
+		{id: "low", score: 1},
+		{id: "high", score: 2, selected: true},
+		{id: "backfill", selected: true},
+	} {
+		require.Equal(t, expected.id, resp.Previews[i].Match.MatchId)
+		require.Equal(t, expected.score, resp.Previews[i].Score)
+		require.Equal(t, expected.selected, resp.Previews[i].Selected)
+	}
+
+	// The preview has no side effects: every ticket is still queryable, none is
+	// pending release, and no backfill was created.
+	ids, err := store.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Len(t, ids, 3)
+	for _, id := range []string{"1", "2", "3"} {
+		_, pending, err := store.GetPendingReleaseTime(ctx, id)
+		require.NoError(t, err)
+		require.False(t, pending)
+	}
+	backfills, err := store.GetIndexedBackfills(ctx)
+	require.NoError(t, err)
+	require.Empty(t, backfills)
+
+	_, err = s.PreviewMatches(ctx, &pb.PreviewMatchesRequest{Profile: &pb.MatchProfile{}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// startStubMmf serves the match function for the duration of the test, and
+// returns its config.
+func TestMmfProtocolVersion(t *testing.T) {
+	ctx := context.Background()
+	s := &backendService{cc: rpc.NewClientCache(viper.New())}
+	mmf := &stubMmf{proposals: []*pb.Match{{MatchId: "1", Tickets: []*pb.Ticket{{Id: "1"}}}}}
+	req := &pb.PreviewMatchesRequest{
+		Config:  startStubMmf(t, mmf),
+		Profile: &pb.MatchProfile{Name: "simple"},
+	}
+
+	// Match functions which don't report a version speak the current one.
+	for _, v := range []int32{0, mmfProtocolVersion} {
+		mmf.protocolVersion = v
+		resp, err := s.PreviewMatches(ctx, req)
+		require.NoError(t, err)
+		require.Len(t, resp.Previews, 1)
+		require.Equal(t, int32(mmfProtocolVersion), mmf.requestVersion)
+	}
+
+	mmf.protocolVersion = mmfProtocolVersion + 1
+	_, err := s.PreviewMatches(ctx, req)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "speaks protocol version 2, but the backend speaks version 1")
+}
+
+func startStubMmf(t *testing.T, mmf pb.MatchFunctionServer) *pb.FunctionConfig {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	return serveStubMmf(t, mmf, lis)
+}
+
+func serveStubMmf(t *testing.T, mmf pb.MatchFunctionServer, lis net.Listener) *pb.FunctionConfig {
+	server := grpc.NewServer()
+	pb.RegisterMatchFunctionServer(server, mmf)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	return &pb.FunctionConfig{
+		Host: "localhost",
+		Port: int32(lis.Addr().(*net.TCPAddr).Port),
+		Type: pb.FunctionConfig_GRPC,
+	}
+}
+
+// countingMmf records the most Run calls it has had in flight at once.
+type countingMmf struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (m *countingMmf) Run(req *pb.RunRequest, stream pb.MatchFunction_RunServer) error {
+	m.mu.Lock()
+	m.inFlight++
+	if m.inFlight > m.peak {
+		m.peak = m.inFlight
+	}
+	m.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	m.mu.Lock()
+	m.inFlight--
+	m.mu.Unlock()
+	return nil
+}
+
+func TestMaxConcurrentMmfCalls(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("maxConcurrentMmfCalls", 2)
+	s := &backendService{cc: rpc.NewClientCache(cfg), mmfSlots: newMmfSlots(cfg)}
+
+	mmf := &countingMmf{}
+	req := &pb.PreviewMatchesRequest{
+		Config:  startStubMmf(t, mmf),
+		Profile: &pb.MatchProfile{Name: "simple"},
+	}
+
+	eg, ctx := errgroup.WithContext(context.Background())
+	for i := 0; i < 10; i++ {
+		eg.Go(func() error {
+			_, err := s.PreviewMatches(ctx, req)
+			return err
+		})
+	}
+	require.NoError(t, eg.Wait())
+	require.Equal(t, 2, mmf.peak)
+	require.Empty(t, s.mmfSlots)
+
+	// Calls waiting for a slot give up when their context ends.
+	s.mmfSlots <- struct{}{}
+	s.mmfSlots <- struct{}{}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := s.PreviewMatches(ctx, req)
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+// countingListener counts the connections it accepts.
+type countingListener struct {
+	net.Listener
+	accepted int32
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		atomic.AddInt32(&l.accepted, 1)
+	}
+	return conn, err
+}
+
+func TestMmfConnectionReuse(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	counting := &countingListener{Listener: lis}
+	req := &pb.FetchMatchesRequest{
+		Config:  serveStubMmf(t, &stubMmf{proposals: []*pb.Match{{MatchId: "1"}}}, counting),
+		Profile: &pb.MatchProfile{Name: "reused"},
+	}
+	cc := rpc.NewClientCache(viper.New())
+
+	for i := 0; i < 5; i++ {
+		proposals := make(chan *pb.Match, 1)
+		require.NoError(t, callMmf(context.Background(), cc, nil, defaultMaxProposalBytes, req, proposals))
+		require.Len(t, proposals, 1)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&counting.accepted))
+}
+
+func TestMmfReceiveLimit(t *testing.T) {
+	const maxProposalBytes = 1024
+	cc := rpc.NewClientCache(viper.New())
+	call := func(maxProposalBytes, size int) error {
+		req := &pb.FetchMatchesRequest{
+			Config:  startStubMmf(t, &stubMmf{proposals: []*pb.Match{{MatchId: strings.Repeat("a", size)}}}),
+			Profile: &pb.MatchProfile{Name: "large"},
+		}
+		return callMmf(context.Background(), cc, nil, maxProposalBytes, req, make(chan *pb.Match, 1))
+	}
+
+	// Slightly oversized proposals are received, for FetchMatches to drop.
+	require.NoError(t, call(maxProposalBytes, 2*maxProposalBytes))
+	require.Equal(t, codes.ResourceExhausted, status.Code(errors.Cause(call(maxProposalBytes, maxProposalBytes+2*mmfRecvHeadroomBytes))))
+
+	// The headroom applies to the default too, which is gRPC's own limit.
+	require.NoError(t, call(defaultMaxProposalBytes, defaultMaxProposalBytes+1024))
+}
+
+func TestNewMmfSlots(t *testing.T) {
+	cfg := viper.New()
+	require.Nil(t, newMmfSlots(cfg))
+	cfg.Set("maxConcurrentMmfCalls", 0)
+	require.Nil(t, newMmfSlots(cfg))
+	cfg.Set("maxConcurrentMmfCalls", 3)
+	require.Equal(t, 3, cap(newMmfSlots(cfg)))
+}
+
+func TestGetTicketPendingRelease(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	s := &backendService{store: store}
+
+	_, err := s.GetTicketPendingRelease(ctx, &pb.GetTicketPendingReleaseRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	for _, id := range []string{"proposed", "idle"} {
+		ticket := &pb.Ticket{Id: id}
+		require.NoError(t, store.CreateTicket(ctx, ticket))
+		require.NoError(t, store.IndexTicket(ctx, ticket))
+	}
+
+	proposedAt := time.Now()
+	require.NoError(t, store.AddTicketsToPendingRelease(ctx, []string{"proposed"}))
+
+	resp, err := s.GetTicketPendingRelease(ctx, &pb.GetTicketPendingReleaseRequest{TicketId: "proposed"})
+	require.NoError(t, err)
+	require.True(t, resp.PendingRelease)
+	releaseTime, err := ptypes.Timestamp(resp.ReleaseTime)
+	require.NoError(t, err)
+	timeout := cfg.GetDuration("pendingReleaseTimeout")
+	require.WithinDuration(t, proposedAt.Add(timeout), releaseTime, timeout/2)
+
+	resp, err = s.GetTicketPendingRelease(ctx, &pb.GetTicketPendingReleaseRequest{TicketId: "idle"})
+	require.NoError(t, err)
+	require.False(t, resp.PendingRelease)
+	require.Nil(t, resp.ReleaseTime)
+
+	time.Sleep(time.Until(releaseTime) + 10*time.Millisecond)
+	resp, err = s.GetTicketPendingRelease(ctx, &pb.GetTicketPendingReleaseRequest{TicketId: "proposed"})
+	require.NoError(t, err)
+	require.False(t, resp.PendingRelease)
+}
+
+func TestReserveTickets(t *testing.T) {
+	ctx := context.Background()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+	s := &backendService{store: store}
+
+	_, err := s.ReserveTickets(ctx, &pb.ReserveTicketsRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.ReserveTickets(ctx, &pb.ReserveTicketsRequest{TicketIds: []string{"reserved"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	for _, id := range []string{"reserved", "confirmed", "idle"} {
+		ticket := &pb.Ticket{Id: id}
+		require.NoError(t, store.CreateTicket(ctx, ticket))
+		require.NoError(t, store.IndexTicket(ctx, ticket))
+	}
+
+	lease := 200 * time.Millisecond
+	resp, err := s.ReserveTickets(ctx, &pb.ReserveTicketsRequest{
+		TicketIds: []string{"reserved", "confirmed"},
+		Lease:     ptypes.DurationProto(lease),
+	})
+	require.NoError(t, err)
+	releaseTime, err := ptypes.Timestamp(resp.ReleaseTime)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(lease), releaseTime, lease/2)
+
+	ids, err := store.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, ids, "reserved")
+	require.NotContains(t, ids, "confirmed")
+	require.Contains(t, ids, "idle")
+
+	_, err = s.AssignTickets(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{{
+			TicketIds:  []string{"confirmed"},
+			Assignment: &pb.Assignment{Connection: "localhost:7777"},
+		}},
+	})
+	require.NoError(t, err)
+
+	// The unconfirmed reservation returns to matchmaking when its lease ends.
+	time.Sleep(time.Until(releaseTime) + 10*time.Millisecond)
+	ids, err = store.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Contains(t, ids, "reserved")
+	require.NotContains(t, ids, "confirmed")
+}
+
+func TestNewReservationLease(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("pendingReleaseTimeout", time.Minute)
+	require.Equal(t, time.Minute, newReservationLease(cfg))
+
+	cfg.Set("ticketReservationLease", 10*time.Second)
+	require.Equal(t, 10*time.Second, newReservationLease(cfg))
+}
+
+// spanRecorder is an OpenCensus trace exporter keeping the ended spans.
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []*trace.SpanData
+}
+
+func (r *spanRecorder) ExportSpan(s *trace.SpanData) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, s)
+}
+
+// recordingSyncStream is a synchronizer stream keeping the sent proposals.
+type recordingSyncStream struct {
+	synchronizerStream
+	sent []*pb.Match
+}
+
+func (s *recordingSyncStream) Send(req *ipb.SynchronizeRequest) error {
+	s.sent = append(s.sent, req.GetProposal())
+	return nil
+}
+
+func (s *recordingSyncStream) CloseSend() error {
+	return nil
+}
+
+func TestSynchronizeSendProposalSpans(t *testing.T) {
+	recorder := &spanRecorder{}
+	trace.RegisterExporter(recorder)
+	defer trace.UnregisterExporter(recorder)
+
+	proposals := make(chan *pb.Match, 2)
+	proposals <- &pb.Match{MatchId: "small", MatchProfile: "profile", Tickets: []*pb.Ticket{{Id: "1"}}}
+	proposals <- &pb.Match{MatchId: "large", MatchProfile: "profile", Tickets: []*pb.Ticket{{Id: strings.Repeat("2", 100)}}}
+	close(proposals)
+
+	ctx, parent := trace.StartSpan(context.Background(), "openmatch.BackendService.FetchMatches", trace.WithSampler(trace.AlwaysSample()))
+	stream := &recordingSyncStream{}
+	err := synchronizeSend(ctx, stream, &sync.Map{}, proposals, &fetchSummary{}, nil, 50)
+	parent.End()
+	require.NoError(t, err)
+	require.Len(t, stream.sent, 1)
+
+	// Each proposal has its own span, a child of the FetchMatches span.
+	outcomes := map[string]interface{}{}
+	for _, s := range recorder.spans {
+		if s.Name != "open-match/backend.Proposal" {
+			continue
+		}
+		require.Equal(t, parent.SpanContext().SpanID, s.ParentSpanID)
+		require.Equal(t, "profile", s.Attributes["matchProfile"])
+		outcomes[s.Attributes["matchId"].(string)] = s.Attributes["outcome"]
+	}
+	require.Equal(t, map[string]interface{}{"small": "sent", "large": "oversized"}, outcomes)
+}
+
+func TestNewMaxProposalBytes(t *testing.T) {
+	cfg := viper.New()
+	n, err := newMaxProposalBytes(cfg)
+	require.NoError(t, err)
+	require.Equal(t, defaultMaxProposalBytes, n)
+
+	cfg.Set("maxProposalBytes", 0)
+	n, err = newMaxProposalBytes(cfg)
+	require.NoError(t, err)
+	require.Equal(t, defaultMaxProposalBytes, n)
+
+	cfg.Set("maxProposalBytes", 1024)
+	n, err = newMaxProposalBytes(cfg)
+	require.NoError(t, err)
+	require.Equal(t, 1024, n)
+
+	cfg.Set("maxProposalBytes", -1)
+	_, err = newMaxProposalBytes(cfg)
+	require.Error(t, err)
+}
+
+func TestAssignTicketsAudit(t *testing.T) {
+	ctx := context.Background()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+	sink := &auditRecorder{}
+	s := &backendService{store: store, auditLog: util.NewAuditLogWithSink(sink)}
+
+	require.NoError(t, store.CreateTicket(ctx, &pb.Ticket{Id: "1"}))
+	_, err := s.AssignTickets(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{TicketIds: []string{"1", "missing"}, Assignment: &pb.Assignment{Connection: "1.2.3.4:5678"}},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.auditLog.Close())
+
+	require.Len(t, sink.events, 1)
+	require.Equal(t, util.AuditAssigned, sink.events[0].Type)
+	require.Equal(t, "1", sink.events[0].TicketID)
+	require.Equal(t, "1.2.3.4:5678", sink.events[0].Connection)
+	require.False(t, sink.events[0].Time.IsZero())
+}
+
+type auditRecorder struct {
+	events []*util.AuditEvent
+}
+
+func (r *auditRecorder) Write(e *util.AuditEvent) error {
+	r.events = append(r.events, e)
+	return nil
+}
+
+func (r *auditRecorder) Close() error {
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/rpc"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	// proposalOrderScore evaluates proposals by descending default evaluator
+	// score.  Proposals without a score come last.
+	proposalOrderScore = "score"
+	// proposalOrderTicketCount evaluates proposals with more tickets first.
+	proposalOrderTicketCount = "ticketCount"
+	// proposalOrderCreateTime evaluates proposals by the creation time of
+	// their oldest ticket, so the longest waiting tickets are matched first.
+	proposalOrderCreateTime = "createTime"
+)
+
+// proposalOrder is the order in which the proposals of a match function are
+// evaluated.  Evaluators resolve collisions greedily, so the order decides
+// which of colliding proposals wins when the evaluator can't tell them apart.
+// A nil proposalOrder keeps the order the match function sent.
+type proposalOrder func(a, b *pb.Match) bool
+
+// newProposalOrder returns the order configured by proposalOrder, or nil if
+// none is configured.
+func newProposalOrder(cfg config.View) (proposalOrder, error) {
+	const name = "proposalOrder"
+
+	switch order := cfg.GetString(name); order {
+	case "":
+		return nil, nil
+	case proposalOrderScore:
+		return func(a, b *pb.Match) bool {
+			return proposalScore(a) > proposalScore(b)
+		}, nil
+	case proposalOrderTicketCount:
+		return func(a, b *pb.Match) bool {
+			return len(a.GetTickets()) > len(b.GetTickets())
+		}, nil
+	case proposalOrderCreateTime:
+		return func(a, b *pb.Match) bool {
+			return oldestTicket(a).Before(oldestTicket(b))
+		}, nil
+	default:
+		return nil, errors.Errorf("unknown %s %q, must be %q, %q or %q", name, order, proposalOrderScore, proposalOrderTicketCount, proposalOrderCreateTime)
+	}
+}
+
+// sort orders the proposals.  Proposals the order can't tell apart keep their
+// relative order.
+func (o proposalOrder) sort(proposals []*pb.Match) {
+	if o == nil {
+		return
+	}
+	sort.SliceStable(proposals, func(i, j int) bool {
+		return o(proposals[i], proposals[j])
+	})
+}
+
+// proposalScore returns the default evaluator score of the proposal, or
+// negative infinity if it has none.
+func proposalScore(m *pb.Match) float64 {
+	a, ok := m.GetExtensions()["evaluation_input"]
+	if !ok {
+		return math.Inf(-1)
+	}
+	inp := &pb.DefaultEvaluationCriteria{}
+	if err := ptypes.UnmarshalAny(a, inp); err != nil {
+		return math.Inf(-1)
+	}
+	return inp.GetScore()
+}
+
+// oldestTicket returns the creation time of the oldest ticket of the proposal.
+// Tickets without a valid creation time are ignored, and proposals without any
+// come last.
+func oldestTicket(m *pb.Match) time.Time {
+	oldest := time.Unix(math.MaxInt64/int64(time.Second), 0)
+	for _, t := range m.GetTickets() {
+		ct, err := ptypes.Timestamp(t.GetCreateTime())
+		if err == nil && ct.Before(oldest) {
+			oldest = ct
+		}
+	}
+	return oldest
+}
+
+// callOrderedMmf runs the match function like callMmf, but holds its
+// proposals back until it is done, and then sends them in order.  Proposals
+// are therefore only sent if the match function finishes within the proposal
+// window.
+func callOrderedMmf(ctx context.Context, cc *rpc.ClientCache, slots chan struct{}, maxProposalBytes int, order proposalOrder, req *pb.FetchMatchesRequest, proposals chan<- *pb.Match) error {
+	defer close(proposals)
+
+	matches, err := collectProposals(ctx, cc, slots, maxProposalBytes, req.GetConfig(), req.GetProfile())
+	if err != nil {
+		return err
+	}
+	order.sort(matches)
+
+	for _, m := range matches {
+		select {
+		case proposals <- m:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/internal/rpc"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func scoredProposal(t *testing.T, id string, score float64, tickets ...*pb.Ticket) *pb.Match {
+	a, err := ptypes.MarshalAny(&pb.DefaultEvaluationCriteria{Score: score})
+	require.NoError(t, err)
+	return &pb.Match{
+		MatchId:    id,
+		Tickets:    tickets,
+		Extensions: map[string]*any.Any{"evaluation_input": a},
+	}
+}
+
+func matchIDs(matches []*pb.Match) []string {
+	var ids []string
+	for _, m := range matches {
+		ids = append(ids, m.GetMatchId())
+	}
+	return ids
+}
+
+func TestNewProposalOrder(t *testing.T) {
+	now := time.Now()
+	ticket := func(id string, age time.Duration) *pb.Ticket {
+		ct, err := ptypes.TimestampProto(now.Add(-age))
+		require.NoError(t, err)
+		return &pb.Ticket{Id: id, CreateTime: ct}
+	}
+	proposals := func() []*pb.Match {
+		return []*pb.Match{
+			scoredProposal(t, "low", 1, ticket("1", time.Minute)),
+			{MatchId: "unscored", Tickets: []*pb.Ticket{ticket("2", time.Second), ticket("3", time.Second)}},
+			scoredProposal(t, "high", 3, ticket("4", time.Hour), ticket("5", 0), ticket("6", 0)),
+			scoredProposal(t, "tied", 1),
+		}
+	}
+
+	for _, tt := range []struct {
+		order    string
+		expected []string
+	}{
+		{"", []string{"low", "unscored", "high", "tied"}},
+		{proposalOrderScore, []string{"high", "low", "tied", "unscored"}},
+		{proposalOrderTicketCount, []string{"high", "unscored", "low", "tied"}},
+		{proposalOrderCreateTime, []string{"high", "low", "unscored", "tied"}},
+	} {
+		tt := tt
+		t.Run(tt.order, func(t *testing.T) {
+			cfg := viper.New()
+			cfg.Set("proposalOrder", tt.order)
+			order, err := newProposalOrder(cfg)
+			require.NoError(t, err)
+
+			p := proposals()
+			order.sort(p)
+			require.Equal(t, tt.expected, matchIDs(p))
+		})
+	}
+
+	cfg := viper.New()
+	cfg.Set("proposalOrder", "random")
+	_, err := newProposalOrder(cfg)
+	require.Error(t, err)
+}
+
+// greedyEvaluate accepts each proposal which doesn't collide with a proposal
+// accepted before it, as a first come first served evaluator would.
+func greedyEvaluate(proposals []*pb.Match) []string {
+	used := map[string]bool{}
+	var accepted []string
+outer:
+	for _, p := range proposals {
+		for _, t := range p.GetTickets() {
+			if used[t.GetId()] {
+				continue outer
+			}
+		}
+		for _, t := range p.GetTickets() {
+			used[t.GetId()] = true
+		}
+		accepted = append(accepted, p.GetMatchId())
+	}
+	return accepted
+}
+
+func TestCallOrderedMmf(t *testing.T) {
+	shared := &pb.Ticket{Id: "shared"}
+	mmf := &stubMmf{proposals: []*pb.Match{
+		scoredProposal(t, "low", 1, shared, &pb.Ticket{Id: "1"}),
+		scoredProposal(t, "high", 5, shared, &pb.Ticket{Id: "2"}),
+		scoredProposal(t, "other", 2, &pb.Ticket{Id: "3"}),
+	}}
+	req := &pb.FetchMatchesRequest{
+		Config:  startStubMmf(t, mmf),
+		Profile: &pb.MatchProfile{Name: "ordered"},
+	}
+	cc := rpc.NewClientCache(viper.New())
+
+	run := func(order proposalOrder) []*pb.Match {
+		proposals := make(chan *pb.Match)
+		errs := make(chan error, 1)
+		go func() {
+			if order == nil {
+				errs <- callMmf(context.Background(), cc, nil, defaultMaxProposalBytes, req, proposals)
+			} else {
+				errs <- callOrderedMmf(context.Background(), cc, nil, defaultMaxProposalBytes, order, req, proposals)
+			}
+		}()
+		var received []*pb.Match
+		for p := range proposals {
+			received = append(received, p)
+		}
+		require.NoError(t, <-errs)
+		return received
+	}
+
+	// In the order received, the lower quality proposal takes the shared
+	// ticket first.
+	require.Equal(t, []string{"low", "other"}, greedyEvaluate(run(nil)))
+
+	cfg := viper.New()
+	cfg.Set("proposalOrder", proposalOrderScore)
+	order, err := newProposalOrder(cfg)
+	require.NoError(t, err)
+	received := run(order)
+	require.Equal(t, []string{"high", "other", "low"}, matchIDs(received))
+	require.Equal(t, []string{"high", "other"}, greedyEvaluate(received))
+}
+
+func TestPreviewMatchesProposalOrder(t *testing.T) {
+	shared := &pb.Ticket{Id: "shared"}
+	// The default evaluator can't tell the proposals apart by score.
+	mmf := &stubMmf{proposals: []*pb.Match{
+		scoredProposal(t, "small", 1, shared),
+		scoredProposal(t, "large", 1, shared, &pb.Ticket{Id: "1"}),
+	}}
+	req := &pb.PreviewMatchesRequest{
+		Config:  startStubMmf(t, mmf),
+		Profile: &pb.MatchProfile{Name: "ordered"},
+	}
+	selected := func(s *backendService) string {
+		resp, err := s.PreviewMatches(context.Background(), req)
+		require.NoError(t, err)
+		for _, p := range resp.Previews {
+			if p.Selected {
+				return p.Match.GetMatchId()
+			}
+		}
+		return ""
+	}
+
+	s := &backendService{cc: rpc.NewClientCache(viper.New())}
+	require.Equal(t, "small", selected(s))
+
+	cfg := viper.New()
+	cfg.Set("proposalOrder", proposalOrderTicketCount)
+	order, err := newProposalOrder(cfg)
+	require.NoError(t, err)
+	s.proposalOrder = order
+	require.Equal(t, "large", selected(s))
+}
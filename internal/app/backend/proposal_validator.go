@@ -0,0 +1,115 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// proposalValidator checks the proposals of a match function against the
+// profile it ran for, so a buggy match function can't match tickets outside
+// of the requested pools, or tickets which are no longer active.  Tickets are
+// checked as stored, not as returned by the match function.
+type proposalValidator struct {
+	store   statestore.Service
+	profile string
+	filters []*filter.PoolFilter
+}
+
+// newProposalValidator returns the validator of the proposals for profile, or
+// nil if validateProposals is not enabled.
+func (s *backendService) newProposalValidator(profile *pb.MatchProfile) (*proposalValidator, error) {
+	if !s.validateProposals {
+		return nil, nil
+	}
+
+	v := &proposalValidator{store: s.store, profile: profile.GetName()}
+	for _, pool := range profile.GetPools() {
+		pf, err := filter.NewPoolFilter(pool)
+		if err != nil {
+			return nil, err
+		}
+		v.filters = append(v.filters, pf)
+	}
+	return v, nil
+}
+
+// invalidReason returns why the proposal is invalid, or an empty string if it
+// is valid.  A nil validator accepts every proposal.
+func (v *proposalValidator) invalidReason(ctx context.Context, p *pb.Match) (string, error) {
+	if v == nil || len(p.GetTickets()) == 0 {
+		return "", nil
+	}
+
+	ids := make([]string, 0, len(p.GetTickets()))
+	for _, t := range p.GetTickets() {
+		ids = append(ids, t.GetId())
+	}
+	stored, err := v.store.GetTickets(ctx, ids)
+	if err != nil {
+		return "", err
+	}
+	byID := make(map[string]*pb.Ticket, len(stored))
+	for _, t := range stored {
+		byID[t.GetId()] = t
+	}
+
+	for _, id := range ids {
+		t, ok := byID[id]
+		if !ok {
+			return fmt.Sprintf("ticket %s does not exist", id), nil
+		}
+		if t.GetAssignment() != nil {
+			return fmt.Sprintf("ticket %s is already assigned", id), nil
+		}
+		if !v.inPools(t) {
+			return fmt.Sprintf("ticket %s is not in any pool of profile %s", id, v.profile), nil
+		}
+	}
+	return "", nil
+}
+
+func (v *proposalValidator) inPools(t *pb.Ticket) bool {
+	for _, pf := range v.filters {
+		if pf.In(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// valid reports whether the proposal is valid, logging why it is dropped if
+// it isn't.
+func (v *proposalValidator) valid(ctx context.Context, p *pb.Match) (bool, error) {
+	reason, err := v.invalidReason(ctx, p)
+	if err != nil {
+		return false, err
+	}
+	if reason != "" {
+		logger.WithFields(logrus.Fields{
+			"matchId": p.GetMatchId(),
+			"profile": v.profile,
+			"reason":  reason,
+		}).Warning("dropping invalid proposal from match function")
+		return false, nil
+	}
+	return true, nil
+}
@@ -52,16 +52,17 @@ type matchInp struct {
 
 // BindService define the initialization steps for this evaluator
 func BindService(p *appmain.Params, b *appmain.Bindings) error {
-	if err := evaluator.BindServiceFor(evaluate)(p, b); err != nil {
+	if err := evaluator.BindServiceFor(Evaluate)(p, b); err != nil {
 		return err
 	}
 	b.RegisterViews(collidedMatchesPerEvaluateView)
 	return nil
 }
 
-// evaluate sorts the matches by DefaultEvaluationCriteria.Score (optional),
+// Evaluate sorts the matches by DefaultEvaluationCriteria.Score (optional),
 // then returns matches which don't collide with previously returned matches.
-func evaluate(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+// Matches with the same score keep the order they were received in.
+func Evaluate(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
 	matches := make([]*matchInp, 0)
 	nilEvaluationInputs := 0
 
@@ -96,7 +97,7 @@ func evaluate(ctx context.Context, in <-chan *pb.Match, out chan<- string) error
 		}).Info("Some matches don't have the optional field evaluation_input set.")
 	}
 
-	sort.Sort(byScore(matches))
+	sort.Stable(byScore(matches))
 
 	d := decollider{
 		ticketsUsed:   make(map[string]*collidingMatch),
@@ -0,0 +1,116 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frontend
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/util"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// backfillCache is a read-through cache of GetBackfill, for game servers
+// polling the same backfill.  Writes made through this frontend invalidate
+// their backfill, while writes from other processes, such as the backend
+// assigning tickets, are seen once the entry expires.
+type backfillCache struct {
+	clock util.Clock
+	ttl   time.Duration
+
+	mu        sync.Mutex
+	entries   map[string]*backfillCacheEntry
+	lastPrune time.Time
+}
+
+type backfillCacheEntry struct {
+	backfill *pb.Backfill
+	expires  time.Time
+}
+
+// newBackfillCache returns the cache configured by backfillCacheTTL, or nil if
+// it is disabled, which is the default.
+func newBackfillCache(cfg config.View, clock util.Clock) *backfillCache {
+	ttl := cfg.GetDuration("backfillCacheTTL")
+	if ttl <= 0 {
+		return nil
+	}
+
+	return &backfillCache{
+		clock:     clock,
+		ttl:       ttl,
+		entries:   make(map[string]*backfillCacheEntry),
+		lastPrune: clock.Now(),
+	}
+}
+
+// get returns the cached backfill of id, or calls load and caches its result.
+// Errors are not cached.  A nil cache always calls load.
+func (c *backfillCache) get(id string, load func() (*pb.Backfill, error)) (*pb.Backfill, error) {
+	if c == nil {
+		return load()
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[id]
+	if ok && c.clock.Now().Before(e.expires) {
+		c.mu.Unlock()
+		return proto.Clone(e.backfill).(*pb.Backfill), nil
+	}
+	c.mu.Unlock()
+
+	bf, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	c.prune(now)
+	c.entries[id] = &backfillCacheEntry{
+		backfill: proto.Clone(bf).(*pb.Backfill),
+		expires:  now.Add(c.ttl),
+	}
+	return bf, nil
+}
+
+// invalidate drops the cached backfill of id.
+func (c *backfillCache) invalidate(id string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, id)
+}
+
+// prune drops the expired entries.  It runs at most once per ttl.
+func (c *backfillCache) prune(now time.Time) {
+	if now.Sub(c.lastPrune) < c.ttl {
+		return
+	}
+	c.lastPrune = now
+
+	for id, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, id)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frontend
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/internal/util"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// fallbackAssignmentLock is the lock held by the frontend replica assigning
+// the fallback assignment.
+const fallbackAssignmentLock = "fallbackAssignment"
+
+// fallbackAssignmentPageSize is the number of Tickets assigned at a time.
+const fallbackAssignmentPageSize = 1000
+
+// fallbackAssigner assigns Tickets which no backend assigned within
+// fallbackAssignment.timeout of their creation to
+// fallbackAssignment.connection, for example a lobby server, so that players
+// aren't left waiting.  Tickets pending release are left to the backend which
+// proposed or reserved them.  Every frontend replica may run one, a Redis lock
+// lets a single replica assign at a time.
+type fallbackAssigner struct {
+	store      statestore.Service
+	cipher     *util.AssignmentCipher
+	auditLog   *util.AuditLog
+	webhook    *util.AssignmentWebhook
+	connection string
+	timeout    time.Duration
+	interval   time.Duration
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// newFallbackAssigner starts the assigner, or returns nil if no
+// fallbackAssignment.connection and fallbackAssignment.timeout are configured.
+// Expired Tickets are looked for every fallbackAssignment.interval, which
+// defaults to a tenth of the timeout.
+func newFallbackAssigner(cfg config.View, store statestore.Service, cipher *util.AssignmentCipher, auditLog *util.AuditLog, webhook *util.AssignmentWebhook) *fallbackAssigner {
+	connection := cfg.GetString("fallbackAssignment.connection")
+	timeout := cfg.GetDuration("fallbackAssignment.timeout")
+	if connection == "" || timeout <= 0 {
+		return nil
+	}
+
+	interval := cfg.GetDuration("fallbackAssignment.interval")
+	if interval <= 0 {
+		interval = timeout / 10
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &fallbackAssigner{
+		store:      store,
+		cipher:     cipher,
+		auditLog:   auditLog,
+		webhook:    webhook,
+		connection: connection,
+		timeout:    timeout,
+		interval:   interval,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	go f.run(ctx)
+	return f
+}
+
+func (f *fallbackAssigner) run(ctx context.Context) {
+	defer close(f.done)
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := f.store.RunLocked(ctx, fallbackAssignmentLock, f.assignExpired)
+			if err != nil && ctx.Err() == nil {
+				logger.WithError(err).Warning("failed to assign the fallback assignment")
+			}
+		}
+	}
+}
+
+// assignExpired assigns the fallback connection to the unassigned Tickets
+// created more than the timeout ago.  Only Tickets still unassigned when they
+// are written are assigned, so assignments made by a backend meanwhile stay.
+func (f *fallbackAssigner) assignExpired(ctx context.Context) error {
+	ids, err := f.store.GetTicketIDsCreatedBefore(ctx, time.Now().Add(-f.timeout))
+	if err != nil {
+		return err
+	}
+
+	assignment := &pb.Assignment{Connection: f.connection}
+	stored := assignment
+	if f.cipher != nil {
+		if stored, err = f.cipher.Encrypt(assignment); err != nil {
+			return err
+		}
+	}
+
+	for len(ids) > 0 {
+		page := ids
+		if len(page) > fallbackAssignmentPageSize {
+			page = page[:fallbackAssignmentPageSize]
+		}
+		ids = ids[len(page):]
+
+		assigned, err := f.store.AssignUnassignedTickets(ctx, page, stored)
+		if err != nil {
+			return err
+		}
+		if len(assigned) == 0 {
+			continue
+		}
+
+		assignedIDs := make([]string, 0, len(assigned))
+		for _, t := range assigned {
+			if err = f.store.DeindexTicket(ctx, t.GetId()); err != nil {
+				logger.WithError(err).Errorf("failed to deindex ticket %s after the fallback assignment", t.GetId())
+			}
+			assignedIDs = append(assignedIDs, t.GetId())
+		}
+
+		// As for the backend's assignments, the webhook and the audit log
+		// are given the plaintext assignment.
+		req := &pb.AssignTicketsRequest{
+			Assignments: []*pb.AssignmentGroup{{TicketIds: assignedIDs, Assignment: assignment}},
+		}
+		resp := &pb.AssignTicketsResponse{}
+		f.webhook.Notify(req, resp)
+		f.auditLog.Assigned(req, resp)
+		stats.Record(ctx, ticketsFallbackAssigned.M(int64(len(assigned))))
+	}
+	return nil
+}
+
+// close stops the assigner, waiting for a running assignment to finish.
+func (f *fallbackAssigner) close() {
+	f.cancel()
+	<-f.done
+}
@@ -17,10 +17,12 @@ package frontend
 import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"google.golang.org/grpc"
 	"open-match.dev/open-match/internal/appmain"
 	"open-match.dev/open-match/internal/statestore"
 	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/internal/util"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -29,6 +31,10 @@ var (
 	searchFieldsPerTicket   = stats.Int64("open-match.dev/frontend/searchfields_per_ticket", "Searchfields per ticket", stats.UnitDimensionless)
 	totalBytesPerBackfill   = stats.Int64("open-match.dev/frontend/total_bytes_per_backfill", "Total bytes per backfill", stats.UnitBytes)
 	searchFieldsPerBackfill = stats.Int64("open-match.dev/frontend/searchfields_per_backfill", "Searchfields per backfill", stats.UnitDimensionless)
+	ticketsShed             = stats.Int64("open-match.dev/frontend/tickets_shed", "Number of tickets rejected because their pool is saturated", stats.UnitDimensionless)
+	ticketsFallbackAssigned = stats.Int64("open-match.dev/frontend/tickets_fallback_assigned", "Number of tickets given the fallback assignment", stats.UnitDimensionless)
+
+	poolKey = tag.MustNewKey("pool")
 
 	totalBytesPerTicketView = &view.View{
 		Measure:     totalBytesPerTicket,
@@ -54,13 +60,52 @@ var (
 		Description: "SearchFields per backfill",
 		Aggregation: telemetry.DefaultCountDistribution,
 	}
+	ticketsShedView = &view.View{
+		Measure:     ticketsShed,
+		Name:        "open-match.dev/frontend/tickets_shed",
+		Description: "Number of tickets rejected because their pool is saturated",
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{poolKey},
+	}
+	ticketsFallbackAssignedView = &view.View{
+		Measure:     ticketsFallbackAssigned,
+		Name:        "open-match.dev/frontend/tickets_fallback_assigned",
+		Description: "Number of tickets given the fallback assignment",
+		Aggregation: view.Sum(),
+	}
 )
 
 // BindService creates the frontend service and binds it to the serving harness.
 func BindService(p *appmain.Params, b *appmain.Bindings) error {
+	assignmentCipher, err := util.NewAssignmentCipher(p.Config())
+	if err != nil {
+		return err
+	}
+	auditLog, err := util.NewAuditLog(p.Config())
+	if err != nil {
+		return err
+	}
+
 	service := &frontendService{
-		cfg:   p.Config(),
-		store: statestore.New(p.Config()),
+		cfg:              p.Config(),
+		store:            statestore.New(p.Config()),
+		watchSlots:       newWatchSlots(p.Config()),
+		watchLimiter:     newWatchLimiter(p.Config(), util.RealClock()),
+		assignmentCipher: assignmentCipher,
+		backfillCache:    newBackfillCache(p.Config(), util.RealClock()),
+		watchOptions:     newWatchOptions(p.Config()),
+		maxWatchDuration: p.Config().GetDuration("maxWatchAssignmentsDuration"),
+		auditLog:         auditLog,
+		webhook:          util.NewAssignmentWebhook(p.Config()),
+	}
+	if auditLog != nil {
+		b.AddCloserErr(auditLog.Close)
+	}
+	if fallback := newFallbackAssigner(p.Config(), service.store, assignmentCipher, auditLog, service.webhook); fallback != nil {
+		b.AddCloser(fallback.close)
+	}
+	if service.webhook != nil {
+		b.AddCloser(service.webhook.Close)
 	}
 
 	b.AddHealthCheckFunc(service.store.HealthCheck)
@@ -72,6 +117,8 @@ func BindService(p *appmain.Params, b *appmain.Bindings) error {
 		searchFieldsPerTicketView,
 		totalBytesPerBackfillView,
 		searchFieldsPerBackfillView,
+		ticketsShedView,
+		ticketsFallbackAssignedView,
 	)
 	return nil
 }
@@ -16,18 +16,29 @@ package frontend
 
 import (
 	"context"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/rs/xid"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/filter"
 	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/internal/util"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -36,8 +47,74 @@ import (
 type frontendService struct {
 	cfg   config.View
 	store statestore.Service
+	// watchSlots bounds the number of concurrent WatchAssignments streams.
+	// It is nil when the number of streams is unbounded.
+	watchSlots chan struct{}
+	// watchLimiter limits how quickly each peer opens WatchAssignments
+	// streams.  It is nil when disabled.
+	watchLimiter *watchLimiter
+	// assignmentCipher decrypts assignment connections read from storage.
+	// It is nil when assignments are stored in plaintext.
+	assignmentCipher *util.AssignmentCipher
+	// backfillCache caches GetBackfill reads.  It is nil when disabled.
+	backfillCache *backfillCache
+	// watchOptions configures how assignment watches poll the store, and how
+	// long they may run.
+	watchOptions watchOptions
+	// maxWatchDuration ends WatchAssignments streams which run longer with
+	// Unavailable, so clients still interested reconnect.  Zero leaves
+	// streams unbounded.
+	maxWatchDuration time.Duration
+	// auditLog records Ticket assignments and deletions.  It is nil when no
+	// audit sink is configured.
+	auditLog *util.AuditLog
+	// webhook is notified of the assignments made by the frontend.  It is nil
+	// when no webhook is configured.
+	webhook *util.AssignmentWebhook
 }
 
+// watchOptions configures doWatchAssignments.
+type watchOptions struct {
+	// healthTimeout is how long an assignment watch may go without a
+	// successful poll before it fails with Unavailable.  0 disables it.
+	healthTimeout time.Duration
+	// pollInterval is how often the assignment is read from the store.  0
+	// uses the store's backoff.initialInterval.
+	pollInterval time.Duration
+	// timeout ends the watch with DeadlineExceeded once it runs that long.
+	// 0 leaves the watch unbounded.
+	timeout time.Duration
+}
+
+const (
+	// duplicatePlayerTicketsReject rejects creating a Ticket for a player which
+	// already has an active Ticket, one which exists and isn't assigned yet.
+	duplicatePlayerTicketsReject = "reject"
+	// duplicatePlayerTicketsReplace deletes the player's active Ticket before
+	// creating the new one.
+	duplicatePlayerTicketsReplace = "replace"
+
+	// watchRetryDelay is the retry hint given to clients whose WatchAssignments
+	// stream is refused because too many streams are open.
+	watchRetryDelay = time.Second
+
+	// poolSaturatedRetryDelay is the retry hint given to clients whose Ticket
+	// is refused because its pool is saturated.
+	poolSaturatedRetryDelay = 5 * time.Second
+
+	// defaultMaxBackfillGeneration is far beyond the generation a backfill
+	// reaches in normal use, so only a runaway update loop hits it.
+	defaultMaxBackfillGeneration = 1000000000
+
+	// defaultMaxCreateTicketsBatchSize bounds the Tickets of a BulkCreateTickets
+	// call unless maxCreateTicketsBatchSize is configured.
+	defaultMaxCreateTicketsBatchSize = 1000
+
+	// defaultQueryPageSize is the number of Backfills QueryBackfills reads at a
+	// time unless queryPageSize is configured, matching the query service.
+	defaultQueryPageSize = 1000
+)
+
 var (
 	logger = logrus.WithFields(logrus.Fields{
 		"app":       "openmatch",
@@ -49,19 +126,350 @@ var (
 // A ticket is considered as ready for matchmaking once it is created.
 //   - If a TicketId exists in a Ticket request, an auto-generated TicketId will override this field.
 //   - If SearchFields exist in a Ticket, CreateTicket will also index these fields such that one can query the ticket with query.QueryTickets function.
+//   - Configured default SearchFields are added to the Ticket where it does not set them.
+//   - If poolSaturation.maxTickets is configured and the Ticket's pool already holds that many tickets, CreateTicket fails with ResourceExhausted.
 func (s *frontendService) CreateTicket(ctx context.Context, req *pb.CreateTicketRequest) (*pb.Ticket, error) {
+	ticket, err := s.prepareTicket(ctx, req.Ticket, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = &pb.CreateTicketRequest{Ticket: ticket}
+
+	mode, err := getDuplicatePlayerTickets(s.cfg)
+	if err != nil {
+		return nil, err
+	}
+	if mode != "" && req.Ticket.PlayerId != "" {
+		return doCreatePlayerTicket(ctx, req, mode, s.store, s.auditLog)
+	}
+
+	return doCreateTicket(ctx, req, s.store)
+}
+
+// prepareTicket validates a Ticket to be created, and returns it with the
+// configured default SearchFields applied.  It fails with ResourceExhausted if
+// the Ticket's pool is saturated, counting the pending Tickets per pool which
+// are about to be written along with the stored ones.
+func (s *frontendService) prepareTicket(ctx context.Context, ticket *pb.Ticket, pending map[string]int64) (*pb.Ticket, error) {
 	// Perform input validation.
-	if req.Ticket == nil {
+	if ticket == nil {
 		return nil, status.Errorf(codes.InvalidArgument, ".ticket is required")
 	}
-	if req.Ticket.Assignment != nil {
+	if ticket.Assignment != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "tickets cannot be created with an assignment")
 	}
-	if req.Ticket.CreateTime != nil {
+	if ticket.CreateTime != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "tickets cannot be created with create time set")
 	}
 
-	return doCreateTicket(ctx, req, s.store)
+	defaults, err := getTicketDefaults(s.cfg)
+	if err != nil {
+		return nil, err
+	}
+	if defaults != nil {
+		var ok bool
+		ticket, ok = proto.Clone(ticket).(*pb.Ticket)
+		if !ok {
+			return nil, status.Error(codes.Internal, "failed to clone input ticket proto")
+		}
+		applySearchFieldDefaults(ticket, defaults)
+	}
+
+	if err = checkPoolSaturation(ctx, s.cfg, s.store, ticket, pending); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// BulkCreateTickets creates the Tickets as CreateTicket does, writing those
+// which don't need the duplicate player check to state storage together.
+// Failing Tickets are reported in the response rather than failing the call.
+func (s *frontendService) BulkCreateTickets(ctx context.Context, req *pb.CreateTicketsRequest) (*pb.CreateTicketsResponse, error) {
+	n := len(req.GetTickets())
+	if n == 0 {
+		return nil, status.Error(codes.InvalidArgument, ".tickets is required")
+	}
+	if max := getMaxCreateTicketsBatchSize(s.cfg); n > max {
+		return nil, status.Errorf(codes.InvalidArgument, "%d tickets exceed the maximum batch size of %d", n, max)
+	}
+
+	mode, err := getDuplicatePlayerTickets(s.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, n)
+	errs := make([]error, n)
+	var batch []*pb.Ticket
+	var batchIndexes []int
+	// The batch is written at the end, so the pool sizes read from the store
+	// don't include its Tickets yet.
+	pending := make(map[string]int64)
+	for i, t := range req.Tickets {
+		ticket, err := s.prepareTicket(ctx, t, pending)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		if mode != "" && ticket.PlayerId != "" {
+			// The player's active Ticket is checked under the player's lock,
+			// so these Tickets are created one at a time.
+			created, err := doCreatePlayerTicket(ctx, &pb.CreateTicketRequest{Ticket: ticket}, mode, s.store, s.auditLog)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			ids[i] = created.Id
+			continue
+		}
+		batch = append(batch, ticket)
+		batchIndexes = append(batchIndexes, i)
+		pending[statestore.SaturationPool(s.cfg, ticket)]++
+	}
+
+	created, err := doCreateTickets(ctx, batch, s.store)
+	for j, i := range batchIndexes {
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		ids[i] = created[j].Id
+	}
+
+	resp := &pb.CreateTicketsResponse{TicketIds: ids}
+	for _, err := range errs {
+		if err == nil {
+			resp.Errors = append(resp.Errors, status.New(codes.OK, "").Proto())
+			continue
+		}
+		resp.Errors = append(resp.Errors, status.Convert(err).Proto())
+	}
+	return resp, nil
+}
+
+// getMaxCreateTicketsBatchSize returns the most Tickets a BulkCreateTickets
+// call may create.
+func getMaxCreateTicketsBatchSize(cfg config.View) int {
+	const name = "maxCreateTicketsBatchSize"
+
+	if !cfg.IsSet(name) || cfg.GetInt(name) <= 0 {
+		return defaultMaxCreateTicketsBatchSize
+	}
+	return cfg.GetInt(name)
+}
+
+// doCreatePlayerTicket creates the Ticket while making sure the player has at
+// most one active Ticket, according to the duplicate player tickets mode.
+func doCreatePlayerTicket(ctx context.Context, req *pb.CreateTicketRequest, mode string, store statestore.Service, auditLog *util.AuditLog) (*pb.Ticket, error) {
+	playerID := req.Ticket.PlayerId
+
+	m := store.NewMutex("player/" + playerID)
+	err := m.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, errUnlock := m.Unlock(ctx); errUnlock != nil {
+			logger.WithFields(logrus.Fields{
+				"error": errUnlock.Error(),
+			}).Error("error on mutex unlock")
+		}
+	}()
+
+	existingID, err := store.GetPlayerTicketID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingID != "" {
+		var existing *pb.Ticket
+		existing, err = store.GetTicket(ctx, existingID)
+		switch {
+		case err == nil && existing.GetAssignment() != nil:
+			// The player was matched, their assigned Ticket is no longer active.
+		case err == nil:
+			if mode == duplicatePlayerTicketsReject {
+				return nil, status.Errorf(codes.AlreadyExists, "player %s already has an active ticket %s", playerID, existingID)
+			}
+			if err = doDeleteTicket(ctx, existingID, store, auditLog); err != nil {
+				return nil, err
+			}
+		case status.Code(err) != codes.NotFound:
+			return nil, err
+		}
+	}
+
+	ticket, err := doCreateTicket(ctx, req, store)
+	if err != nil {
+		return nil, err
+	}
+
+	err = store.SetPlayerTicketID(ctx, playerID, ticket.Id)
+	if err != nil {
+		// Without the player's record the Ticket would escape the check, so it
+		// is removed rather than left in the pool.
+		deleteUnrecordedTicket(ctx, ticket.Id, store)
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// deleteUnrecordedTicket removes a Ticket which was created but couldn't be
+// recorded as its player's.
+func deleteUnrecordedTicket(ctx context.Context, id string, store statestore.Service) {
+	if err := store.DeindexTicket(ctx, id); err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"id":    id,
+		}).Error("failed to deindex the unrecorded ticket")
+	}
+	if err := store.DeleteTicket(ctx, id); err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"id":    id,
+		}).Error("failed to delete the unrecorded ticket")
+	}
+}
+
+// getDuplicatePlayerTickets returns how CreateTicket handles a Ticket for a
+// player which already has an active Ticket.  An empty mode disables the check.
+func getDuplicatePlayerTickets(cfg config.View) (string, error) {
+	const name = "duplicatePlayerTickets"
+
+	if !cfg.IsSet(name) {
+		return "", nil
+	}
+
+	switch mode := cfg.GetString(name); mode {
+	case "", duplicatePlayerTicketsReject, duplicatePlayerTicketsReplace:
+		return mode, nil
+	default:
+		return "", status.Errorf(codes.FailedPrecondition, "invalid %s configuration %q, expecting %q or %q", name, mode, duplicatePlayerTicketsReject, duplicatePlayerTicketsReplace)
+	}
+}
+
+// checkPoolSaturation sheds the Ticket if the pool it would join, as chosen by
+// poolSaturation.tags, already holds poolSaturation.maxTickets indexed tickets,
+// counting the pending tickets not written yet.  The check is not atomic with the creation, so concurrent creates may
+// overshoot the limit slightly.
+func checkPoolSaturation(ctx context.Context, cfg config.View, store statestore.Service, ticket *pb.Ticket, pending map[string]int64) error {
+	const name = "poolSaturation.maxTickets"
+
+	if !cfg.IsSet(name) || cfg.GetInt64(name) <= 0 {
+		return nil
+	}
+	pool := statestore.SaturationPool(cfg, ticket)
+	if pool == "" {
+		return nil
+	}
+
+	count, err := store.GetPoolTicketCount(ctx, pool)
+	if err != nil {
+		return err
+	}
+	if count+pending[pool] < cfg.GetInt64(name) {
+		return nil
+	}
+
+	err = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(poolKey, pool)}, ticketsShed.M(1))
+	if err != nil {
+		logger.WithError(err).Error("failed to record shed ticket")
+	}
+	return resourceExhaustedError("pool "+pool+" is saturated, retry later", poolSaturatedRetryDelay)
+}
+
+// getTicketDefaults returns the SearchFields added to Tickets at creation
+// where they are absent, or nil if none are configured.  Tags are listed as
+// is, string and double args as "key=value" entries:
+//
+//	ticketDefaults:
+//	  tags: ["platform.any"]
+//	  stringArgs: ["region=any"]
+//	  doubleArgs: ["mmr=1000"]
+//
+// Lists are used rather than maps since the configuration treats dots in keys
+// as nesting.
+func getTicketDefaults(cfg config.View) (*pb.SearchFields, error) {
+	const name = "ticketDefaults"
+
+	tags := cfg.GetStringSlice(name + ".tags")
+	stringArgs := cfg.GetStringSlice(name + ".stringArgs")
+	doubleArgs := cfg.GetStringSlice(name + ".doubleArgs")
+	if len(tags) == 0 && len(stringArgs) == 0 && len(doubleArgs) == 0 {
+		return nil, nil
+	}
+
+	defaults := &pb.SearchFields{
+		Tags:       tags,
+		StringArgs: make(map[string]string),
+		DoubleArgs: make(map[string]float64),
+	}
+	for _, entry := range stringArgs {
+		k, v, ok := splitDefault(entry)
+		if !ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "invalid %s.stringArgs entry %q, expecting key=value", name, entry)
+		}
+		defaults.StringArgs[k] = v
+	}
+	for _, entry := range doubleArgs {
+		k, v, ok := splitDefault(entry)
+		if !ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "invalid %s.doubleArgs entry %q, expecting key=value", name, entry)
+		}
+		d, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "invalid %s.doubleArgs entry %q, expecting a number value", name, entry)
+		}
+		defaults.DoubleArgs[k] = d
+	}
+
+	return defaults, nil
+}
+
+func splitDefault(entry string) (string, string, bool) {
+	i := strings.Index(entry, "=")
+	if i <= 0 {
+		return "", "", false
+	}
+	return entry[:i], entry[i+1:], true
+}
+
+// applySearchFieldDefaults adds the defaults to the ticket's SearchFields,
+// keeping any value the ticket already sets.
+func applySearchFieldDefaults(ticket *pb.Ticket, defaults *pb.SearchFields) {
+	if ticket.SearchFields == nil {
+		ticket.SearchFields = &pb.SearchFields{}
+	}
+	sf := ticket.SearchFields
+
+	for k, v := range defaults.StringArgs {
+		if _, ok := sf.StringArgs[k]; !ok {
+			if sf.StringArgs == nil {
+				sf.StringArgs = make(map[string]string)
+			}
+			sf.StringArgs[k] = v
+		}
+	}
+	for k, v := range defaults.DoubleArgs {
+		if _, ok := sf.DoubleArgs[k]; !ok {
+			if sf.DoubleArgs == nil {
+				sf.DoubleArgs = make(map[string]float64)
+			}
+			sf.DoubleArgs[k] = v
+		}
+	}
+
+	present := make(map[string]struct{}, len(sf.Tags))
+	for _, tag := range sf.Tags {
+		present[tag] = struct{}{}
+	}
+	for _, tag := range defaults.Tags {
+		if _, ok := present[tag]; !ok {
+			sf.Tags = append(sf.Tags, tag)
+			present[tag] = struct{}{}
+		}
+	}
 }
 
 func doCreateTicket(ctx context.Context, req *pb.CreateTicketRequest, store statestore.Service) (*pb.Ticket, error) {
@@ -94,6 +502,35 @@ func doCreateTicket(ctx context.Context, req *pb.CreateTicketRequest, store stat
 	return ticket, nil
 }
 
+// doCreateTickets generates an id for each Ticket, and creates them in state
+// storage together.  The created Tickets are returned in the input order.
+func doCreateTickets(ctx context.Context, tickets []*pb.Ticket, store statestore.Service) ([]*pb.Ticket, error) {
+	created := make([]*pb.Ticket, 0, len(tickets))
+	for _, t := range tickets {
+		ticket, ok := proto.Clone(t).(*pb.Ticket)
+		if !ok {
+			return nil, status.Error(codes.Internal, "failed to clone input ticket proto")
+		}
+
+		ticket.Id = xid.New().String()
+		ticket.CreateTime = ptypes.TimestampNow()
+
+		sfCount := 0
+		sfCount += len(ticket.GetSearchFields().GetDoubleArgs())
+		sfCount += len(ticket.GetSearchFields().GetStringArgs())
+		sfCount += len(ticket.GetSearchFields().GetTags())
+		stats.Record(ctx, searchFieldsPerTicket.M(int64(sfCount)))
+		stats.Record(ctx, totalBytesPerTicket.M(int64(proto.Size(ticket))))
+
+		created = append(created, ticket)
+	}
+
+	if err := store.CreateTickets(ctx, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
 // CreateBackfill creates a new Backfill object.
 // it assigns an unique Id to the input Backfill and record it in state storage.
 // Set initial LastAcknowledge time for this Backfill.
@@ -144,6 +581,8 @@ func doCreateBackfill(ctx context.Context, req *pb.CreateBackfillRequest, store
 }
 
 // UpdateBackfill updates a Backfill object, if present.
+// It fails with NotFound if the Backfill does not exist, including when it is
+// deleted while being updated, so that a deleted Backfill is never recreated.
 // Update would increment generation in Redis.
 // Only Extensions and SearchFields would be updated.
 // CreateTime is not changed on Update
@@ -164,6 +603,12 @@ func (s *frontendService) UpdateBackfill(ctx context.Context, req *pb.UpdateBack
 	if bfID == "" {
 		return nil, status.Error(codes.InvalidArgument, "backfill ID should exist")
 	}
+	paths := req.GetUpdateMask().GetPaths()
+	for _, path := range paths {
+		if _, _, err := parseBackfillUpdatePath(path); err != nil {
+			return nil, err
+		}
+	}
 	m := s.store.NewMutex(bfID)
 
 	err := m.Lock(ctx)
@@ -175,16 +620,24 @@ func (s *frontendService) UpdateBackfill(ctx context.Context, req *pb.UpdateBack
 			logger.WithError(err).Error("error on mutex unlock")
 		}
 	}()
+	defer s.backfillCache.invalidate(bfID)
 	bfStored, associatedTickets, err := s.store.GetBackfill(ctx, bfID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Update generation here, because Frontend is used by GameServer only
-	bfStored.SearchFields = backfill.SearchFields
-	bfStored.Extensions = backfill.Extensions
+	if len(paths) == 0 {
+		bfStored.SearchFields = backfill.SearchFields
+		bfStored.Extensions = backfill.Extensions
+	} else {
+		applyBackfillUpdateMask(bfStored, backfill, paths)
+	}
 	// Autoincrement generation, input backfill generation validation is performed
 	// on Backend only (after MMF round)
+	if max := getMaxBackfillGeneration(s.cfg); bfStored.Generation >= max {
+		return nil, status.Errorf(codes.FailedPrecondition, "backfill %s reached the maximum generation %d, it may be updated in a loop", bfID, max)
+	}
 	bfStored.Generation++
 	err = s.store.UpdateBackfill(ctx, bfStored, []string{})
 	if err != nil {
@@ -207,6 +660,89 @@ func (s *frontendService) UpdateBackfill(ctx context.Context, req *pb.UpdateBack
 	return bfStored, nil
 }
 
+// parseBackfillUpdatePath splits an UpdateBackfill field mask path into the
+// updated field and, for paths naming a single map entry, the entry's key.
+func parseBackfillUpdatePath(path string) (field string, key string, err error) {
+	for _, f := range []string{
+		"search_fields.double_args",
+		"search_fields.string_args",
+		"extensions",
+	} {
+		if strings.HasPrefix(path, f+".") && len(path) > len(f)+1 {
+			return f, path[len(f)+1:], nil
+		}
+	}
+
+	switch path {
+	case "search_fields", "search_fields.tags", "search_fields.double_args", "search_fields.string_args", "extensions":
+		return path, "", nil
+	}
+
+	return "", "", status.Errorf(codes.InvalidArgument, "invalid update_mask path %q", path)
+}
+
+// applyBackfillUpdateMask copies the fields listed in paths from update into
+// stored, leaving every other field of stored unchanged.  The paths must
+// have been validated by parseBackfillUpdatePath.
+func applyBackfillUpdateMask(stored *pb.Backfill, update *pb.Backfill, paths []string) {
+	for _, path := range paths {
+		field, key, _ := parseBackfillUpdatePath(path)
+
+		if field == "extensions" {
+			if key == "" {
+				stored.Extensions = update.GetExtensions()
+				continue
+			}
+			if v, ok := update.GetExtensions()[key]; ok {
+				if stored.Extensions == nil {
+					stored.Extensions = map[string]*any.Any{}
+				}
+				stored.Extensions[key] = v
+			} else {
+				delete(stored.Extensions, key)
+			}
+			continue
+		}
+
+		if field == "search_fields" {
+			stored.SearchFields = update.GetSearchFields()
+			continue
+		}
+
+		if stored.SearchFields == nil {
+			stored.SearchFields = &pb.SearchFields{}
+		}
+		sf := stored.SearchFields
+
+		switch field {
+		case "search_fields.tags":
+			sf.Tags = update.GetSearchFields().GetTags()
+		case "search_fields.double_args":
+			if key == "" {
+				sf.DoubleArgs = update.GetSearchFields().GetDoubleArgs()
+			} else if v, ok := update.GetSearchFields().GetDoubleArgs()[key]; ok {
+				if sf.DoubleArgs == nil {
+					sf.DoubleArgs = map[string]float64{}
+				}
+				sf.DoubleArgs[key] = v
+			} else {
+				delete(sf.DoubleArgs, key)
+			}
+		case "search_fields.string_args":
+			if key == "" {
+				sf.StringArgs = update.GetSearchFields().GetStringArgs()
+			} else if v, ok := update.GetSearchFields().GetStringArgs()[key]; ok {
+				if sf.StringArgs == nil {
+					sf.StringArgs = map[string]string{}
+				}
+				sf.StringArgs[key] = v
+			} else {
+				delete(sf.StringArgs, key)
+			}
+		}
+	}
+}
+
 // DeleteBackfill deletes a Backfill by its ID.
 func (s *frontendService) DeleteBackfill(ctx context.Context, req *pb.DeleteBackfillRequest) (*empty.Empty, error) {
 	bfID := req.GetBackfillId()
@@ -214,6 +750,7 @@ func (s *frontendService) DeleteBackfill(ctx context.Context, req *pb.DeleteBack
 		return nil, status.Errorf(codes.InvalidArgument, ".BackfillId is required")
 	}
 	err := doDeleteBackfill(ctx, bfID, s.store)
+	s.backfillCache.invalidate(bfID)
 	if err != nil {
 		return nil, err
 	}
@@ -266,24 +803,98 @@ func doDeleteBackfill(ctx context.Context, id string, store statestore.Service)
 	return nil
 }
 
+// CancelBackfill deletes a Backfill and returns its associated Tickets to the matchmaking pool.
+// Tickets which have already been assigned or deleted are not re-queued.
+func (s *frontendService) CancelBackfill(ctx context.Context, req *pb.CancelBackfillRequest) (*pb.CancelBackfillResponse, error) {
+	bfID := req.GetBackfillId()
+	if bfID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, ".BackfillId is required")
+	}
+	ids, err := doCancelBackfill(ctx, bfID, s.store)
+	s.backfillCache.invalidate(bfID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CancelBackfillResponse{TicketIds: ids}, nil
+}
+
+func doCancelBackfill(ctx context.Context, id string, store statestore.Service) ([]string, error) {
+	m := store.NewMutex(id)
+	err := m.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, errUnlock := m.Unlock(ctx); errUnlock != nil {
+			logger.WithFields(logrus.Fields{
+				"error": errUnlock.Error(),
+			}).Error("error on mutex unlock")
+		}
+	}()
+
+	_, associatedTickets, err := store.GetBackfill(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets, err := store.GetTickets(ctx, associatedTickets)
+	if err != nil {
+		return nil, err
+	}
+
+	requeued := []string{}
+	for _, t := range tickets {
+		if t.GetAssignment() != nil {
+			continue
+		}
+		err = store.IndexTicket(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		requeued = append(requeued, t.GetId())
+	}
+
+	err = store.DeleteTicketsFromPendingRelease(ctx, associatedTickets)
+	if err != nil {
+		return nil, err
+	}
+	err = store.DeleteBackfill(ctx, id)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"id":    id,
+		}).Error("failed to delete the backfill")
+	}
+	err = store.DeindexBackfill(ctx, id)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"id":    id,
+		}).Error("failed to deindex the backfill")
+	}
+
+	return requeued, nil
+}
+
 // DeleteTicket immediately stops Open Match from using the Ticket for matchmaking and removes the Ticket from state storage.
 // The client must delete the Ticket when finished matchmaking with it.
 //   - If SearchFields exist in a Ticket, DeleteTicket will deindex the fields lazily.
 // Users may still be able to assign/get a ticket after calling DeleteTicket on it.
 func (s *frontendService) DeleteTicket(ctx context.Context, req *pb.DeleteTicketRequest) (*empty.Empty, error) {
-	err := doDeleteTicket(ctx, req.GetTicketId(), s.store)
+	err := doDeleteTicket(ctx, req.GetTicketId(), s.store, s.auditLog)
 	if err != nil {
 		return nil, err
 	}
 	return &empty.Empty{}, nil
 }
 
-func doDeleteTicket(ctx context.Context, id string, store statestore.Service) error {
+func doDeleteTicket(ctx context.Context, id string, store statestore.Service, auditLog *util.AuditLog) error {
 	// Deindex this Ticket to remove it from matchmaking pool.
 	err := store.DeindexTicket(ctx, id)
 	if err != nil {
 		return err
 	}
+	auditLog.Deleted(id)
 
 	//'lazy' ticket delete that should be called after a ticket
 	// has been deindexed.
@@ -310,16 +921,197 @@ func doDeleteTicket(ctx context.Context, id string, store statestore.Service) er
 	return nil
 }
 
-// GetTicket get the Ticket associated with the specified TicketId.
-func (s *frontendService) GetTicket(ctx context.Context, req *pb.GetTicketRequest) (*pb.Ticket, error) {
-	return s.store.GetTicket(ctx, req.GetTicketId())
-}
+// DeleteTickets deletes the Tickets with the requested ids, or the indexed
+// Tickets which match all the Filters of the Pool, along with their indexing.
+// Tickets are deleted a page of queryPageSize at a time, and Tickets matching
+// the Pool are read a page at a time to find them.
+func (s *frontendService) DeleteTickets(ctx context.Context, req *pb.DeleteTicketsRequest) (*pb.DeleteTicketsResponse, error) {
+	ids, pool := req.GetTicketIds(), req.GetPool()
+	if (len(ids) == 0) == (pool == nil) {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of .ticket_ids and .pool is required")
+	}
 
-// WatchAssignments stream back Assignment of the specified TicketId if it is updated.
-//   - If the Assignment is not updated, GetAssignment will retry using the configured backoff strategy.
-func (s *frontendService) WatchAssignments(req *pb.WatchAssignmentsRequest, stream pb.FrontendService_WatchAssignmentsServer) error {
-	ctx := stream.Context()
-	for {
+	var pf *filter.PoolFilter
+	if pool != nil {
+		var err error
+		pf, err = filter.NewPoolFilter(pool)
+		if err != nil {
+			return nil, err
+		}
+
+		index, err := s.store.GetIndexedIDSet(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ids = make([]string, 0, len(index))
+		for id := range index {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+	}
+
+	resp := &pb.DeleteTicketsResponse{}
+	pSize := getQueryPageSize(s.cfg)
+	for start := 0; start < len(ids); start += pSize {
+		end := start + pSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		page := ids[start:end]
+
+		if pf != nil {
+			tickets, err := s.store.GetTickets(ctx, page)
+			if err != nil {
+				return nil, err
+			}
+			page = nil
+			for _, ticket := range tickets {
+				if pf.In(ticket) {
+					page = append(page, ticket.GetId())
+				}
+			}
+		}
+
+		deleted, err := doDeleteTickets(ctx, page, s.store, s.auditLog)
+		if err != nil {
+			return nil, err
+		}
+		resp.DeletedCount += int32(len(deleted))
+	}
+
+	return resp, nil
+}
+
+// doDeleteTickets deletes the Tickets and their indexing, and returns the ids
+// of the Tickets which existed.
+func doDeleteTickets(ctx context.Context, ids []string, store statestore.Service, auditLog *util.AuditLog) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	deleted, err := store.DeleteTickets(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range deleted {
+		auditLog.Deleted(id)
+	}
+
+	err = store.DeleteTicketsFromPendingRelease(ctx, ids)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ids":   ids,
+		}).Error("failed to delete the tickets from pendingRelease")
+	}
+	return deleted, nil
+}
+
+// GetTicket get the Ticket associated with the specified TicketId.
+func (s *frontendService) GetTicket(ctx context.Context, req *pb.GetTicketRequest) (*pb.Ticket, error) {
+	ticket, err := s.store.GetTicket(ctx, req.GetTicketId())
+	if err != nil {
+		return nil, err
+	}
+
+	ticket.Assignment, err = s.assignmentCipher.Decrypt(ticket.Assignment)
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// GetTicketsByPlayer gets the active Tickets of the specified player id.
+func (s *frontendService) GetTicketsByPlayer(ctx context.Context, req *pb.GetTicketsByPlayerRequest) (*pb.GetTicketsByPlayerResponse, error) {
+	if req.GetPlayerId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, ".player_id is required")
+	}
+
+	ids, err := s.store.GetIndexedPlayerTicketIDs(ctx, req.GetPlayerId())
+	if err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.store.GetTickets(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetTicketsByPlayerResponse{Tickets: tickets}, nil
+}
+
+// UpdateTicket replaces the search fields of the Ticket associated with the specified TicketId.
+//   - The id and create time of the Ticket are kept, so it keeps its place in pools ordered by create time.
+//   - Query caches refetch the Ticket as its revision changes.
+//   - If the Ticket is assigned or deleted, even while it is being updated, UpdateTicket fails with NotFound.
+func (s *frontendService) UpdateTicket(ctx context.Context, req *pb.UpdateTicketRequest) (*pb.Ticket, error) {
+	if req.GetTicketId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, ".ticket_id is required")
+	}
+
+	return doUpdateTicket(ctx, req, s.store)
+}
+
+func doUpdateTicket(ctx context.Context, req *pb.UpdateTicketRequest, store statestore.Service) (*pb.Ticket, error) {
+	m := store.NewMutex("ticket/" + req.TicketId)
+	err := m.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, errUnlock := m.Unlock(ctx); errUnlock != nil {
+			logger.WithFields(logrus.Fields{
+				"error": errUnlock.Error(),
+			}).Error("error on mutex unlock")
+		}
+	}()
+
+	ticket, err := store.GetTicket(ctx, req.TicketId)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.Assignment != nil {
+		return nil, status.Errorf(codes.NotFound, "ticket %s is already assigned", req.TicketId)
+	}
+
+	ticket.SearchFields = req.SearchFields
+	err = store.UpdateTicket(ctx, ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// WatchAssignments stream back Assignment of the specified TicketId if it is updated.
+//   - If the Assignment is not updated, GetAssignment will retry using the configured backoff strategy.
+//   - The Assignment is read every frontend.watchAssignmentsPollInterval if it is configured.
+//   - If no retry succeeds within the configured watchAssignmentsHealthTimeout, WatchAssignments fails with Unavailable.
+//   - If frontend.watchAssignmentsTimeout is configured, WatchAssignments fails with DeadlineExceeded once the stream runs that long.
+//   - If the number of concurrent streams is at the configured maximum, WatchAssignments fails with ResourceExhausted.
+//   - If watchAssignmentsRateLimit.perSecond is configured, a peer opening streams faster fails with ResourceExhausted.  It is off by default.
+//   - If the Ticket expires after the configured ticketTTL, WatchAssignments fails with DeadlineExceeded and "ticket expired".
+//   - If maxWatchAssignmentsDuration is configured, the stream fails with Unavailable once it runs that long, and the client may reconnect.
+func (s *frontendService) WatchAssignments(req *pb.WatchAssignmentsRequest, stream pb.FrontendService_WatchAssignmentsServer) error {
+	if !s.watchLimiter.allow(stream.Context()) {
+		return watchesExhaustedError("WatchAssignments streams opened too quickly, retry later")
+	}
+	if s.watchSlots != nil {
+		select {
+		case s.watchSlots <- struct{}{}:
+			defer func() { <-s.watchSlots }()
+		default:
+			return watchesExhaustedError("too many concurrent WatchAssignments streams, retry later")
+		}
+	}
+
+	ctx := stream.Context()
+	if s.maxWatchDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.maxWatchDuration)
+		defer cancel()
+	}
+	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -327,22 +1119,209 @@ func (s *frontendService) WatchAssignments(req *pb.WatchAssignmentsRequest, stre
 			sender := func(assignment *pb.Assignment) error {
 				return stream.Send(&pb.WatchAssignmentsResponse{Assignment: assignment})
 			}
-			return doWatchAssignments(ctx, req.GetTicketId(), sender, s.store)
+			err := doWatchAssignments(ctx, req.GetTicketId(), sender, s.store, s.assignmentCipher, s.watchOptions)
+			// Reaching the maximum duration isn't the client's deadline, so
+			// it is reported as a status the client can resume from.
+			if ctx.Err() == context.DeadlineExceeded && stream.Context().Err() == nil {
+				return status.Errorf(codes.Unavailable, "WatchAssignments stream reached the maximum duration of %s, reconnect to keep watching", s.maxWatchDuration)
+			}
+			return err
 		}
 	}
 }
 
-func doWatchAssignments(ctx context.Context, id string, sender func(*pb.Assignment) error, store statestore.Service) error {
+// CreateAndWatch creates a Ticket for each request received on the stream, and
+// streams back the updated Assignments of every Ticket created on it.  The
+// stream takes a single WatchAssignments slot however many Tickets it creates.
+// Tickets requested with DeleteOnClose are deleted once the stream ends.
+func (s *frontendService) CreateAndWatch(stream pb.FrontendService_CreateAndWatchServer) error {
+	if !s.watchLimiter.allow(stream.Context()) {
+		return watchesExhaustedError("WatchAssignments streams opened too quickly, retry later")
+	}
+	if s.watchSlots != nil {
+		select {
+		case s.watchSlots <- struct{}{}:
+			defer func() { <-s.watchSlots }()
+		default:
+			return watchesExhaustedError("too many concurrent WatchAssignments streams, retry later")
+		}
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	var watches sync.WaitGroup
+	var deleteOnClose []string
+	defer func() {
+		cancel()
+		watches.Wait()
+		for _, id := range deleteOnClose {
+			if err := doDeleteTicket(context.Background(), id, s.store, s.auditLog); err != nil {
+				logger.WithFields(logrus.Fields{
+					"error": err.Error(),
+					"id":    id,
+				}).Error("failed to delete the ticket of a closed CreateAndWatch stream")
+			}
+		}
+	}()
+
+	var sendMu sync.Mutex
+	send := func(resp *pb.CreateAndWatchResponse) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.Send(resp)
+	}
+
+	// Requests are received in their own goroutine, so a failed watch can end
+	// the stream while Recv blocks.  Recv returns once the handler does.
+	reqs := make(chan *pb.CreateAndWatchRequest)
+	recvErrs := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErrs <- err
+				return
+			}
+			select {
+			case reqs <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	watchErrs := make(chan error, 1)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-watchErrs:
+			return err
+		case err := <-recvErrs:
+			if err != io.EOF {
+				return err
+			}
+			// The client is done creating Tickets, but still watches them.
+			recvErrs = nil
+		case req := <-reqs:
+			ticket, err := s.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: req.GetTicket()})
+			if err != nil {
+				return err
+			}
+			if req.GetDeleteOnClose() {
+				deleteOnClose = append(deleteOnClose, ticket.GetId())
+			}
+			if err = send(&pb.CreateAndWatchResponse{Ticket: ticket}); err != nil {
+				return err
+			}
+
+			id := ticket.GetId()
+			sender := func(assignment *pb.Assignment) error {
+				return send(&pb.CreateAndWatchResponse{TicketId: id, Assignment: assignment})
+			}
+			watches.Add(1)
+			go func() {
+				defer watches.Done()
+				err := doWatchAssignments(ctx, id, sender, s.store, s.assignmentCipher, s.watchOptions)
+				if err != nil && ctx.Err() == nil {
+					select {
+					case watchErrs <- err:
+					default:
+					}
+				}
+			}()
+		}
+	}
+}
+
+func watchesExhaustedError(msg string) error {
+	return resourceExhaustedError(msg, watchRetryDelay)
+}
+
+// resourceExhaustedError returns a ResourceExhausted error hinting the client
+// to retry after retryDelay.
+func resourceExhaustedError(msg string, retryDelay time.Duration) error {
+	st := status.New(codes.ResourceExhausted, msg)
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(retryDelay)})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// newWatchOptions reads the watchOptions from the configuration.
+func newWatchOptions(cfg config.View) watchOptions {
+	return watchOptions{
+		healthTimeout: cfg.GetDuration("watchAssignmentsHealthTimeout"),
+		pollInterval:  cfg.GetDuration("frontend.watchAssignmentsPollInterval"),
+		timeout:       cfg.GetDuration("frontend.watchAssignmentsTimeout"),
+	}
+}
+
+// newWatchSlots returns the semaphore bounding concurrent WatchAssignments
+// streams, or nil if maxConcurrentWatches is not configured.
+func newWatchSlots(cfg config.View) chan struct{} {
+	const name = "maxConcurrentWatches"
+
+	if !cfg.IsSet(name) || cfg.GetInt(name) <= 0 {
+		return nil
+	}
+
+	return make(chan struct{}, cfg.GetInt(name))
+}
+
+// getMaxBackfillGeneration returns the generation beyond which UpdateBackfill
+// rejects updates, read from maxBackfillGeneration.
+func getMaxBackfillGeneration(cfg config.View) int64 {
+	const name = "maxBackfillGeneration"
+
+	if !cfg.IsSet(name) || cfg.GetInt64(name) <= 0 {
+		return defaultMaxBackfillGeneration
+	}
+
+	return cfg.GetInt64(name)
+}
+
+// doWatchAssignments sends every change to the assignment of the ticket until
+// the sender or the store fails.  With a healthTimeout, a watch whose store
+// polls stop succeeding, such as after losing its connection to redis, fails
+// with Unavailable once healthTimeout passed since the last successful poll.
+// With a timeout, the watch fails with DeadlineExceeded once it runs that long.
+func doWatchAssignments(ctx context.Context, id string, sender func(*pb.Assignment) error, store statestore.Service, assignmentCipher *util.AssignmentCipher, opts watchOptions) error {
+	if opts.timeout <= 0 {
+		return watchAssignments(ctx, id, sender, store, assignmentCipher, opts)
+	}
+
+	watchCtx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+	err := watchAssignments(watchCtx, id, sender, store, assignmentCipher, opts)
+	if watchCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		return status.Errorf(codes.DeadlineExceeded, "watching the assignment of ticket %s reached the timeout of %s", id, opts.timeout)
+	}
+	return err
+}
+
+func watchAssignments(ctx context.Context, id string, sender func(*pb.Assignment) error, store statestore.Service, assignmentCipher *util.AssignmentCipher, opts watchOptions) error {
 	var currAssignment *pb.Assignment
 	var ok bool
 	callback := func(assignment *pb.Assignment) error {
+		// The store polls until the callback fails, so stop once the caller
+		// is gone even if the assignment never changes.
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		assignment, err := assignmentCipher.Decrypt(assignment)
+		if err != nil {
+			return err
+		}
+
 		if (currAssignment == nil && assignment != nil) || !proto.Equal(currAssignment, assignment) {
 			currAssignment, ok = proto.Clone(assignment).(*pb.Assignment)
 			if !ok {
 				return status.Error(codes.Internal, "failed to cast the assignment object")
 			}
 
-			err := sender(currAssignment)
+			err = sender(currAssignment)
 			if err != nil {
 				return status.Errorf(codes.Aborted, err.Error())
 			}
@@ -350,17 +1329,240 @@ func doWatchAssignments(ctx context.Context, id string, sender func(*pb.Assignme
 		return nil
 	}
 
-	return store.GetAssignments(ctx, id, callback)
+	if opts.healthTimeout <= 0 {
+		return store.GetAssignments(ctx, id, opts.pollInterval, callback)
+	}
+	return watchWithHealthTimeout(ctx, id, callback, store, opts.pollInterval, opts.healthTimeout)
+}
+
+// watchWithHealthTimeout runs store.GetAssignments, failing with Unavailable
+// if the callback isn't called for healthTimeout.  The store may be stuck
+// on a dead connection, so it isn't waited for, but the callback is never
+// called once this returns.
+func watchWithHealthTimeout(ctx context.Context, id string, callback func(*pb.Assignment) error, store statestore.Service, pollInterval, healthTimeout time.Duration) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// mu is held while the callback runs, so no callback is in flight once
+	// the watch is cancelled and mu is taken.
+	var mu sync.Mutex
+	polled := make(chan struct{}, 1)
+	healthy := func(assignment *pb.Assignment) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		select {
+		case polled <- struct{}{}:
+		default:
+		}
+		return callback(assignment)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- store.GetAssignments(ctx, id, pollInterval, healthy)
+	}()
+
+	stop := func() {
+		cancel()
+		mu.Lock()
+		mu.Unlock()
+	}
+
+	timer := time.NewTimer(healthTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-polled:
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(healthTimeout)
+		case <-timer.C:
+			stop()
+			return status.Errorf(codes.Unavailable, "no assignment poll of ticket %s succeeded for %s, retry the watch", id, healthTimeout)
+		case <-ctx.Done():
+			stop()
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
 }
 
 // AcknowledgeBackfill is used to notify OpenMatch about GameServer connection info.
 // This triggers an assignment process.
+//   - The acknowledgement time of the Backfill is updated, Backfills which are not
+//     acknowledged within backfillAckTimeout are no longer returned by queries.
+//   - The Tickets associated with the Backfill are assigned the requested Assignment,
+//     and leave the Backfill, so that retrying only updates the acknowledgement time.
 func (s *frontendService) AcknowledgeBackfill(ctx context.Context, req *pb.AcknowledgeBackfillRequest) (*pb.Backfill, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented")
+	bfID := req.GetBackfillId()
+	if bfID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, ".BackfillId is required")
+	}
+	if req.GetAssignment() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, ".Assignment is required")
+	}
+
+	bf, err := doAcknowledgeBackfill(ctx, bfID, req.GetAssignment(), s.store, s.assignmentCipher, s.auditLog, s.webhook)
+	s.backfillCache.invalidate(bfID)
+	if err != nil {
+		return nil, err
+	}
+	return bf, nil
+}
+
+func doAcknowledgeBackfill(ctx context.Context, id string, assignment *pb.Assignment, store statestore.Service, assignmentCipher *util.AssignmentCipher, auditLog *util.AuditLog, webhook *util.AssignmentWebhook) (*pb.Backfill, error) {
+	m := store.NewMutex(id)
+	err := m.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, errUnlock := m.Unlock(ctx); errUnlock != nil {
+			logger.WithFields(logrus.Fields{
+				"error": errUnlock.Error(),
+			}).Error("error on mutex unlock")
+		}
+	}()
+
+	bf, associatedTickets, err := store.GetBackfill(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = store.AcknowledgeBackfill(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(associatedTickets) == 0 {
+		return bf, nil
+	}
+
+	encrypted, err := assignmentCipher.Encrypt(assignment)
+	if err != nil {
+		return nil, err
+	}
+	resp, assigned, err := store.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{{TicketIds: associatedTickets, Assignment: encrypted}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// As for the backend's assignments, the webhook and the audit log are
+	// given the plaintext assignment.
+	assignReq := &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{{TicketIds: associatedTickets, Assignment: assignment}},
+	}
+	webhook.Notify(assignReq, resp)
+	auditLog.Assigned(assignReq, resp)
+	for _, t := range assigned {
+		if err = store.DeindexTicket(ctx, t.GetId()); err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"id":    t.GetId(),
+			}).Error("failed to deindex the assigned ticket")
+		}
+	}
+
+	// The assigned Tickets leave the Backfill, so that a retry doesn't
+	// assign them again.
+	err = store.UpdateBackfill(ctx, bf, []string{})
+	if err != nil {
+		return nil, err
+	}
+	err = store.DeleteTicketsFromPendingRelease(ctx, associatedTickets)
+	if err != nil {
+		return nil, err
+	}
+	return bf, nil
 }
 
 // GetBackfill fetches a Backfill object by its ID.
 func (s *frontendService) GetBackfill(ctx context.Context, req *pb.GetBackfillRequest) (*pb.Backfill, error) {
-	bf, _, err := s.store.GetBackfill(ctx, req.GetBackfillId())
-	return bf, err
+	return s.backfillCache.get(req.GetBackfillId(), func() (*pb.Backfill, error) {
+		bf, _, err := s.store.GetBackfill(ctx, req.GetBackfillId())
+		return bf, err
+	})
+}
+
+// QueryBackfills streams back the Backfills which match all the Filters of the
+// Pool, and whose generation is within the requested range.  Backfills are read
+// from state storage a page of queryPageSize at a time, and the matching ones
+// of each page are sent before the next is read, so large results are never
+// held in memory at once.
+func (s *frontendService) QueryBackfills(req *pb.QueryBackfillsRequest, stream pb.FrontendService_QueryBackfillsServer) error {
+	ctx := stream.Context()
+	pool := req.GetPool()
+	if pool == nil {
+		return status.Error(codes.InvalidArgument, ".pool is required")
+	}
+
+	pf, err := filter.NewPoolFilter(pool)
+	if err != nil {
+		return err
+	}
+	generation := req.GetGeneration()
+	if generation.GetMin() > generation.GetMax() {
+		return status.Error(codes.InvalidArgument, ".generation.min is greater than .generation.max")
+	}
+
+	index, err := s.store.GetIndexedBackfills(ctx)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(index))
+	for id := range index {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	pSize := getQueryPageSize(s.cfg)
+	for start := 0; start < len(ids); start += pSize {
+		end := start + pSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		backfills, err := s.store.GetBackfills(ctx, ids[start:end])
+		if err != nil {
+			return err
+		}
+
+		var matched []*pb.Backfill
+		for _, backfill := range backfills {
+			if generation != nil && (backfill.Generation < generation.Min || backfill.Generation > generation.Max) {
+				continue
+			}
+			if pf.In(backfill) {
+				matched = append(matched, backfill)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		if err = stream.Send(&pb.QueryBackfillsResponse{Backfills: matched}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getQueryPageSize returns the number of Backfills QueryBackfills reads, and of
+// Tickets DeleteTickets deletes, at a time, read from queryPageSize as the
+// query service does.
+func getQueryPageSize(cfg config.View) int {
+	const name = "queryPageSize"
+
+	if !cfg.IsSet(name) || cfg.GetInt(name) <= 0 {
+		return defaultQueryPageSize
+	}
+
+	return cfg.GetInt(name)
 }
@@ -16,19 +16,34 @@ package frontend
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"open-match.dev/open-match/internal/statestore"
 	statestoreTesting "open-match.dev/open-match/internal/statestore/testing"
+	"open-match.dev/open-match/internal/util"
 	utilTesting "open-match.dev/open-match/internal/util/testing"
 	"open-match.dev/open-match/pkg/pb"
 )
@@ -77,6 +92,7 @@ func TestDoCreateTickets(t *testing.T) {
 			ctx, cancel := context.WithCancel(utilTesting.NewContext(t))
 			test.preAction(cancel)
 
+			before := time.Now()
 			res, err := doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: test.ticket}, store)
 			require.Equal(t, test.wantCode.String(), status.Convert(err).Code().String())
 			if err == nil {
@@ -84,17 +100,301 @@ func TestDoCreateTickets(t *testing.T) {
 				require.True(t, matched)
 				require.Nil(t, err)
 				require.Equal(t, test.ticket.SearchFields.DoubleArgs["test-arg"], res.SearchFields.DoubleArgs["test-arg"])
+
+				require.NotNil(t, res.CreateTime)
+				createTime, err := ptypes.Timestamp(res.CreateTime)
+				require.NoError(t, err)
+				require.False(t, createTime.Before(before))
+				require.False(t, createTime.After(time.Now()))
+				require.Nil(t, test.ticket.CreateTime)
+
+				stored, err := store.GetTicket(ctx, res.Id)
+				require.NoError(t, err)
+				require.True(t, proto.Equal(res.CreateTime, stored.CreateTime))
 			}
 		})
 	}
 }
 
+func TestCreateTicketDuplicatePlayer(t *testing.T) {
+	ticket := &pb.Ticket{
+		PlayerId: "player-1",
+		SearchFields: &pb.SearchFields{
+			DoubleArgs: map[string]float64{
+				"test-arg": 1,
+			},
+		},
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		cfg := viper.New()
+		store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+		defer closer()
+		fs := frontendService{cfg: cfg, store: store}
+		ctx := utilTesting.NewContext(t)
+
+		first, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: ticket})
+		require.NoError(t, err)
+		second, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: ticket})
+		require.NoError(t, err)
+		require.NotEqual(t, first.Id, second.Id)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		cfg := viper.New()
+		cfg.Set("duplicatePlayerTickets", duplicatePlayerTicketsReject)
+		store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+		defer closer()
+		fs := frontendService{cfg: cfg, store: store}
+		ctx := utilTesting.NewContext(t)
+
+		first, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: ticket})
+		require.NoError(t, err)
+
+		_, err = fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: ticket})
+		require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+		// Tickets of other players are not affected.
+		_, err = fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{PlayerId: "player-2"}})
+		require.NoError(t, err)
+
+		// Once the first ticket is deleted, the player can create a new one.
+		require.NoError(t, store.DeleteTicket(ctx, first.Id))
+		second, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: ticket})
+		require.NoError(t, err)
+		require.NotEqual(t, first.Id, second.Id)
+
+		// Once the player is matched, they can queue again.
+		_, _, err = store.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+			Assignments: []*pb.AssignmentGroup{{TicketIds: []string{second.Id}, Assignment: &pb.Assignment{Connection: "a"}}},
+		})
+		require.NoError(t, err)
+		third, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: ticket})
+		require.NoError(t, err)
+		require.NotEqual(t, second.Id, third.Id)
+
+		// The assigned ticket is left for its player to read.
+		_, err = store.GetTicket(ctx, second.Id)
+		require.NoError(t, err)
+	})
+
+	t.Run("player not recorded", func(t *testing.T) {
+		cfg := viper.New()
+		cfg.Set("duplicatePlayerTickets", duplicatePlayerTicketsReject)
+		store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+		defer closer()
+		fs := frontendService{cfg: cfg, store: &unrecordedPlayerStore{Service: store}}
+		ctx := utilTesting.NewContext(t)
+
+		_, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: ticket})
+		require.Equal(t, codes.Unavailable, status.Code(err))
+
+		// The ticket is removed rather than left unrecorded in the pool.
+		ids, err := store.GetIndexedIDSet(ctx)
+		require.NoError(t, err)
+		require.Empty(t, ids)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		cfg := viper.New()
+		cfg.Set("duplicatePlayerTickets", duplicatePlayerTicketsReplace)
+		store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+		defer closer()
+		fs := frontendService{cfg: cfg, store: store}
+		ctx := utilTesting.NewContext(t)
+
+		first, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: ticket})
+		require.NoError(t, err)
+		second, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: ticket})
+		require.NoError(t, err)
+		require.NotEqual(t, first.Id, second.Id)
+
+		ids, err := store.GetIndexedIDSet(ctx)
+		require.NoError(t, err)
+		require.Equal(t, map[string]struct{}{second.Id: {}}, ids)
+
+		id, err := store.GetPlayerTicketID(ctx, ticket.PlayerId)
+		require.NoError(t, err)
+		require.Equal(t, second.Id, id)
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		cfg := viper.New()
+		cfg.Set("duplicatePlayerTickets", "ignore")
+		store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+		defer closer()
+		fs := frontendService{cfg: cfg, store: store}
+
+		_, err := fs.CreateTicket(utilTesting.NewContext(t), &pb.CreateTicketRequest{Ticket: ticket})
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestCreateTicketSearchFieldDefaults(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("ticketDefaults.tags", []string{"platform.any"})
+	cfg.Set("ticketDefaults.stringArgs", []string{"region=any", "mode=casual"})
+	cfg.Set("ticketDefaults.doubleArgs", []string{"mmr=1000"})
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	fs := frontendService{cfg: cfg, store: store}
+	ctx := utilTesting.NewContext(t)
+
+	t.Run("missing fields get the default", func(t *testing.T) {
+		ticket, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"platform.any"}, ticket.SearchFields.Tags)
+		require.Equal(t, map[string]string{"region": "any", "mode": "casual"}, ticket.SearchFields.StringArgs)
+		require.Equal(t, map[string]float64{"mmr": 1000}, ticket.SearchFields.DoubleArgs)
+	})
+
+	t.Run("present fields are untouched", func(t *testing.T) {
+		req := &pb.CreateTicketRequest{Ticket: &pb.Ticket{
+			SearchFields: &pb.SearchFields{
+				Tags:       []string{"platform.pc", "platform.any"},
+				StringArgs: map[string]string{"region": "europe-west1"},
+				DoubleArgs: map[string]float64{"mmr": 1500},
+			},
+		}}
+		ticket, err := fs.CreateTicket(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, []string{"platform.pc", "platform.any"}, ticket.SearchFields.Tags)
+		require.Equal(t, map[string]string{"region": "europe-west1", "mode": "casual"}, ticket.SearchFields.StringArgs)
+		require.Equal(t, map[string]float64{"mmr": 1500}, ticket.SearchFields.DoubleArgs)
+
+		// The request itself is not modified.
+		require.Len(t, req.Ticket.SearchFields.StringArgs, 1)
+	})
+
+	t.Run("invalid entry", func(t *testing.T) {
+		cfg := viper.New()
+		cfg.Set("ticketDefaults.doubleArgs", []string{"mmr=high"})
+		fs := frontendService{cfg: cfg, store: store}
+
+		_, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestCreateTicketPoolSaturation(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("poolSaturation.tags", []string{"mode.ctf"})
+	cfg.Set("poolSaturation.maxTickets", 2)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	fs := frontendService{cfg: cfg, store: store}
+	ctx := utilTesting.NewContext(t)
+
+	create := func(tags ...string) (*pb.Ticket, error) {
+		return fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{
+			SearchFields: &pb.SearchFields{Tags: tags},
+		}})
+	}
+
+	var admitted []*pb.Ticket
+	for i := 0; i < 2; i++ {
+		ticket, err := create("mode.ctf")
+		require.NoError(t, err)
+		admitted = append(admitted, ticket)
+	}
+
+	_, err := create("mode.ctf")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	retryInfo, ok := details[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	require.Equal(t, poolSaturatedRetryDelay, retryInfo.GetRetryDelay().AsDuration())
+
+	// Tickets outside of the saturated pool are still admitted.
+	_, err = create("mode.dm")
+	require.NoError(t, err)
+
+	// Deleting a ticket makes room in its pool again.
+	require.NoError(t, doDeleteTicket(ctx, admitted[0].Id, store, nil))
+	_, err = create("mode.ctf")
+	require.NoError(t, err)
+}
+
+func TestBulkCreateTickets(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("maxCreateTicketsBatchSize", 3)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	fs := frontendService{cfg: cfg, store: store}
+	ctx := utilTesting.NewContext(t)
+
+	resp, err := fs.BulkCreateTickets(ctx, &pb.CreateTicketsRequest{Tickets: []*pb.Ticket{
+		{SearchFields: &pb.SearchFields{Tags: []string{"first"}}},
+		{Assignment: &pb.Assignment{Connection: "1.2.3.4:1234"}},
+		{SearchFields: &pb.SearchFields{Tags: []string{"third"}}},
+	}})
+	require.NoError(t, err)
+	require.Len(t, resp.TicketIds, 3)
+	require.Len(t, resp.Errors, 3)
+
+	// A failing Ticket doesn't fail the others in the batch.
+	require.Empty(t, resp.TicketIds[1])
+	require.Equal(t, int32(codes.InvalidArgument), resp.Errors[1].Code)
+
+	ids, err := store.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	for i, tag := range map[int]string{0: "first", 2: "third"} {
+		require.Equal(t, int32(codes.OK), resp.Errors[i].Code)
+		require.Regexp(t, `^[0-9a-v]{20}$`, resp.TicketIds[i])
+		require.Contains(t, ids, resp.TicketIds[i])
+
+		ticket, err := store.GetTicket(ctx, resp.TicketIds[i])
+		require.NoError(t, err)
+		require.Equal(t, []string{tag}, ticket.SearchFields.Tags)
+		require.NotNil(t, ticket.CreateTime)
+	}
+
+	_, err = fs.BulkCreateTickets(ctx, &pb.CreateTicketsRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = fs.BulkCreateTickets(ctx, &pb.CreateTicketsRequest{Tickets: make([]*pb.Ticket, 4)})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestBulkCreateTicketsPoolSaturation(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("poolSaturation.tags", []string{"mode.ctf"})
+	cfg.Set("poolSaturation.maxTickets", 3)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	fs := frontendService{cfg: cfg, store: store}
+	ctx := utilTesting.NewContext(t)
+
+	ctf := func() *pb.Ticket {
+		return &pb.Ticket{SearchFields: &pb.SearchFields{Tags: []string{"mode.ctf"}}}
+	}
+	_, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: ctf()})
+	require.NoError(t, err)
+
+	// The pool has room for two more, so the batch's own Tickets fill it.
+	resp, err := fs.BulkCreateTickets(ctx, &pb.CreateTicketsRequest{Tickets: []*pb.Ticket{
+		ctf(),
+		ctf(),
+		ctf(),
+		{SearchFields: &pb.SearchFields{Tags: []string{"mode.dm"}}},
+	}})
+	require.NoError(t, err)
+	for i, code := range []codes.Code{codes.OK, codes.OK, codes.ResourceExhausted, codes.OK} {
+		require.Equal(t, int32(code), resp.Errors[i].Code, "ticket %d", i)
+	}
+
+	count, err := store.GetPoolTicketCount(ctx, "mode.ctf")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+}
+
 func TestCreateBackfill(t *testing.T) {
 	cfg := viper.New()
 	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
 	defer closer()
 	ctx := utilTesting.NewContext(t)
-	fs := frontendService{cfg, store}
+	fs := frontendService{cfg: cfg, store: store}
 	var testCases = []struct {
 		description     string
 		request         *pb.CreateBackfillRequest
@@ -156,7 +456,7 @@ func TestCreateBackfill(t *testing.T) {
 
 	// expect error with canceled context
 	store, closer = statestoreTesting.NewStoreServiceForTesting(t, cfg)
-	fs = frontendService{cfg, store}
+	fs = frontendService{cfg: cfg, store: store}
 	defer closer()
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
@@ -178,7 +478,7 @@ func TestUpdateBackfill(t *testing.T) {
 	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
 	defer closer()
 	ctx := utilTesting.NewContext(t)
-	fs := frontendService{cfg, store}
+	fs := frontendService{cfg: cfg, store: store}
 	res, err := fs.CreateBackfill(ctx, &pb.CreateBackfillRequest{
 		Backfill: &pb.Backfill{
 			SearchFields: &pb.SearchFields{
@@ -245,7 +545,7 @@ func TestUpdateBackfill(t *testing.T) {
 
 	// expect error with canceled context
 	store, closer = statestoreTesting.NewStoreServiceForTesting(t, cfg)
-	fs = frontendService{cfg, store}
+	fs = frontendService{cfg: cfg, store: store}
 	defer closer()
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
@@ -263,6 +563,196 @@ func TestUpdateBackfill(t *testing.T) {
 	require.Nil(t, res)
 }
 
+// unrecordedPlayerStore fails to record the Tickets of players, as an
+// unavailable Redis would.
+type unrecordedPlayerStore struct {
+	statestore.Service
+}
+
+func (s *unrecordedPlayerStore) SetPlayerTicketID(ctx context.Context, playerID string, ticketID string) error {
+	return status.Error(codes.Unavailable, "redis is unavailable")
+}
+
+// deletingStore deletes each Backfill right after it is read, as a concurrent
+// deletion would.
+type deletingStore struct {
+	statestore.Service
+}
+
+func (s *deletingStore) GetBackfill(ctx context.Context, id string) (*pb.Backfill, []string, error) {
+	backfill, ids, err := s.Service.GetBackfill(ctx, id)
+	if err == nil {
+		err = s.Service.DeleteBackfill(ctx, id)
+	}
+	return backfill, ids, err
+}
+
+func TestUpdateDeletedBackfill(t *testing.T) {
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	ctx := utilTesting.NewContext(t)
+	fs := frontendService{cfg: cfg, store: store}
+
+	t.Run("deleted before the update", func(t *testing.T) {
+		created, err := fs.CreateBackfill(ctx, &pb.CreateBackfillRequest{Backfill: &pb.Backfill{}})
+		require.NoError(t, err)
+		_, err = fs.DeleteBackfill(ctx, &pb.DeleteBackfillRequest{BackfillId: created.Id})
+		require.NoError(t, err)
+
+		_, err = fs.UpdateBackfill(ctx, &pb.UpdateBackfillRequest{Backfill: created})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("deleted between read and update", func(t *testing.T) {
+		created, err := fs.CreateBackfill(ctx, &pb.CreateBackfillRequest{Backfill: &pb.Backfill{}})
+		require.NoError(t, err)
+
+		racing := frontendService{cfg: cfg, store: &deletingStore{Service: store}}
+		_, err = racing.UpdateBackfill(ctx, &pb.UpdateBackfillRequest{Backfill: created})
+		require.Equal(t, codes.NotFound, status.Code(err))
+
+		// The backfill is not recreated.
+		_, _, err = store.GetBackfill(ctx, created.Id)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestUpdateBackfillMaxGeneration(t *testing.T) {
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	cfg.Set("maxBackfillGeneration", 3)
+	ctx := utilTesting.NewContext(t)
+	fs := frontendService{cfg: cfg, store: store}
+
+	created, err := fs.CreateBackfill(ctx, &pb.CreateBackfillRequest{Backfill: &pb.Backfill{}})
+	require.NoError(t, err)
+
+	req := &pb.UpdateBackfillRequest{Backfill: &pb.Backfill{Id: created.Id}}
+	for generation := created.Generation + 1; generation <= 3; generation++ {
+		res, err := fs.UpdateBackfill(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, generation, res.Generation)
+	}
+
+	_, err = fs.UpdateBackfill(ctx, req)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "maximum generation 3")
+
+	stored, _, err := store.GetBackfill(ctx, created.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), stored.Generation)
+}
+
+func TestUpdateBackfillWithMask(t *testing.T) {
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	ctx := utilTesting.NewContext(t)
+	fs := frontendService{cfg: cfg, store: store}
+
+	openSlots := func(n int32) *any.Any {
+		v, err := ptypes.MarshalAny(&wrappers.Int32Value{Value: n})
+		require.NoError(t, err)
+		return v
+	}
+
+	searchFields := &pb.SearchFields{
+		StringArgs: map[string]string{"mode": "ctf"},
+		Tags:       []string{"crossplay"},
+	}
+	created, err := fs.CreateBackfill(ctx, &pb.CreateBackfillRequest{
+		Backfill: &pb.Backfill{
+			SearchFields: searchFields,
+			Extensions: map[string]*any.Any{
+				"open-slots": openSlots(4),
+				"map":        openSlots(7),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Only open slots are sent, everything else must be preserved.
+	updated, err := fs.UpdateBackfill(ctx, &pb.UpdateBackfillRequest{
+		Backfill: &pb.Backfill{
+			Id: created.Id,
+			Extensions: map[string]*any.Any{
+				"open-slots": openSlots(2),
+			},
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"extensions.open-slots"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, created.Generation+1, updated.Generation)
+
+	stored, _, err := store.GetBackfill(ctx, created.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(searchFields, stored.SearchFields))
+	require.True(t, proto.Equal(openSlots(2), stored.Extensions["open-slots"]))
+	require.True(t, proto.Equal(openSlots(7), stored.Extensions["map"]))
+
+	// A single search field is updated, and a missing key removes it.
+	_, err = fs.UpdateBackfill(ctx, &pb.UpdateBackfillRequest{
+		Backfill: &pb.Backfill{
+			Id: created.Id,
+			SearchFields: &pb.SearchFields{
+				DoubleArgs: map[string]float64{"mmr": 1200},
+			},
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"search_fields.double_args.mmr", "search_fields.string_args.mode"}},
+	})
+	require.NoError(t, err)
+
+	stored, _, err = store.GetBackfill(ctx, created.Id)
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"mmr": 1200}, stored.SearchFields.DoubleArgs)
+	require.Empty(t, stored.SearchFields.StringArgs)
+	require.Equal(t, []string{"crossplay"}, stored.SearchFields.Tags)
+	require.True(t, proto.Equal(openSlots(2), stored.Extensions["open-slots"]))
+
+	_, err = fs.UpdateBackfill(ctx, &pb.UpdateBackfillRequest{
+		Backfill:   &pb.Backfill{Id: created.Id},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"generation"}},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// BenchmarkDoCreateTicket measures creating tickets with and without search
+// fields.  Indexing a ticket is a single write whatever its search fields, as
+// they are only filtered on by the query cache.
+func BenchmarkDoCreateTicket(b *testing.B) {
+	for _, fields := range []int{0, 10} {
+		fields := fields
+		b.Run(fmt.Sprintf("%d-fields", fields), func(b *testing.B) {
+			cfg := viper.New()
+			store, closer := statestoreTesting.NewStoreServiceForTesting(b, cfg)
+			defer closer()
+			ctx := context.Background()
+
+			sf := &pb.SearchFields{DoubleArgs: map[string]float64{}, StringArgs: map[string]string{}}
+			for i := 0; i < fields; i++ {
+				switch i % 3 {
+				case 0:
+					sf.DoubleArgs[fmt.Sprintf("region-%d", i)] = float64(i)
+				case 1:
+					sf.StringArgs[fmt.Sprintf("playlist-%d", i)] = "ranked"
+				default:
+					sf.Tags = append(sf.Tags, fmt.Sprintf("tag-%d", i))
+				}
+			}
+			req := &pb.CreateTicketRequest{Ticket: &pb.Ticket{SearchFields: sf}}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := doCreateTicket(ctx, req, store); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 func TestDoWatchAssignments(t *testing.T) {
 	testTicket := &pb.Ticket{
 		Id: "test-id",
@@ -329,7 +819,7 @@ func TestDoWatchAssignments(t *testing.T) {
 			gotAssignments := []*pb.Assignment{}
 
 			test.preAction(ctx, t, store, test.wantAssignments, &wg)
-			err := doWatchAssignments(ctx, testTicket.GetId(), senderGenerator(gotAssignments, len(test.wantAssignments)), store)
+			err := doWatchAssignments(ctx, testTicket.GetId(), senderGenerator(gotAssignments, len(test.wantAssignments)), store, nil, watchOptions{})
 			require.Equal(t, test.wantCode.String(), status.Convert(err).Code().String())
 
 			wg.Wait()
@@ -340,6 +830,709 @@ func TestDoWatchAssignments(t *testing.T) {
 	}
 }
 
+func TestWatchAssignmentsEncrypted(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("assignmentEncryptionKey", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	assignmentCipher, err := util.NewAssignmentCipher(cfg)
+	require.NoError(t, err)
+	fs := frontendService{cfg: cfg, store: store, assignmentCipher: assignmentCipher}
+
+	ctx, cancel := context.WithCancel(utilTesting.NewContext(t))
+	defer cancel()
+
+	const connection = "1.2.3.4:5678?token=secret"
+	require.NoError(t, store.CreateTicket(ctx, &pb.Ticket{Id: "1"}))
+
+	// Write the assignment as the backend does.
+	encrypted, err := assignmentCipher.Encrypt(&pb.Assignment{Connection: connection})
+	require.NoError(t, err)
+	_, _, err = store.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{{TicketIds: []string{"1"}, Assignment: encrypted}},
+	})
+	require.NoError(t, err)
+
+	raw, err := store.GetTicket(ctx, "1")
+	require.NoError(t, err)
+	require.NotContains(t, raw.Assignment.Connection, "secret")
+
+	ticket, err := fs.GetTicket(ctx, &pb.GetTicketRequest{TicketId: "1"})
+	require.NoError(t, err)
+	require.Equal(t, connection, ticket.Assignment.Connection)
+
+	var got *pb.Assignment
+	err = doWatchAssignments(ctx, "1", func(a *pb.Assignment) error {
+		got = a
+		cancel()
+		return nil
+	}, store, assignmentCipher, watchOptions{})
+	require.Error(t, err)
+	require.Equal(t, connection, got.GetConnection())
+}
+
+// stalledAssignmentsStore polls the assignment once, then hangs as if its
+// connection to redis died, ignoring the context.
+type stalledAssignmentsStore struct {
+	statestore.Service
+	dead chan struct{}
+}
+
+func (s *stalledAssignmentsStore) GetAssignments(ctx context.Context, id string, pollInterval time.Duration, callback func(*pb.Assignment) error) error {
+	if err := callback(nil); err != nil {
+		return err
+	}
+	<-s.dead
+	return callback(&pb.Assignment{Connection: "too late"})
+}
+
+func TestWatchAssignmentsHealthTimeout(t *testing.T) {
+	const healthTimeout = 200 * time.Millisecond
+
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+	ctx := utilTesting.NewContext(t)
+	require.NoError(t, store.CreateTicket(ctx, &pb.Ticket{Id: "1"}))
+
+	stalled := &stalledAssignmentsStore{Service: store, dead: make(chan struct{})}
+	var sent []*pb.Assignment
+	sender := func(a *pb.Assignment) error {
+		sent = append(sent, a)
+		return nil
+	}
+
+	start := time.Now()
+	err := doWatchAssignments(ctx, "1", sender, stalled, nil, watchOptions{healthTimeout: healthTimeout})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Less(t, int64(time.Since(start)), int64(2*healthTimeout))
+
+	// Once the watch failed, the store coming back doesn't reach the sender.
+	close(stalled.dead)
+	time.Sleep(50 * time.Millisecond)
+	require.Empty(t, sent)
+
+	// A healthy store keeps polling past the timeout.
+	ctx, cancel := context.WithTimeout(ctx, 3*healthTimeout)
+	defer cancel()
+	err = doWatchAssignments(ctx, "1", sender, store, nil, watchOptions{healthTimeout: healthTimeout})
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestWatchAssignmentsMaxDuration(t *testing.T) {
+	const maxDuration = 200 * time.Millisecond
+
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+	ctx := utilTesting.NewContext(t)
+	ticket := &pb.Ticket{Id: "1"}
+	require.NoError(t, store.CreateTicket(ctx, ticket))
+	fs := frontendService{cfg: viper.New(), store: store, maxWatchDuration: maxDuration}
+
+	start := time.Now()
+	err := fs.WatchAssignments(&pb.WatchAssignmentsRequest{TicketId: ticket.Id}, &blockingWatchStream{ctx: ctx})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "maximum duration")
+	elapsed := time.Since(start)
+	require.GreaterOrEqual(t, int64(elapsed), int64(maxDuration))
+	require.Less(t, int64(elapsed), int64(5*maxDuration))
+
+	// The client's own deadline isn't reported as the maximum duration.
+	clientCtx, cancel := context.WithTimeout(ctx, maxDuration/2)
+	defer cancel()
+	err = fs.WatchAssignments(&pb.WatchAssignmentsRequest{TicketId: ticket.Id}, &blockingWatchStream{ctx: clientCtx})
+	require.Error(t, err)
+	require.NotContains(t, status.Convert(err).Message(), "maximum duration")
+
+	// Streams are unbounded by default.
+	fs.maxWatchDuration = 0
+	clientCtx, cancel = context.WithTimeout(ctx, 2*maxDuration)
+	defer cancel()
+	start = time.Now()
+	err = fs.WatchAssignments(&pb.WatchAssignmentsRequest{TicketId: ticket.Id}, &blockingWatchStream{ctx: clientCtx})
+	require.Error(t, err)
+	require.NotContains(t, status.Convert(err).Message(), "maximum duration")
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(2*maxDuration))
+}
+
+// pollIntervalStore records the poll interval assignments are watched with.
+type pollIntervalStore struct {
+	statestore.Service
+	pollInterval time.Duration
+}
+
+func (s *pollIntervalStore) GetAssignments(ctx context.Context, id string, pollInterval time.Duration, callback func(*pb.Assignment) error) error {
+	s.pollInterval = pollInterval
+	return s.Service.GetAssignments(ctx, id, pollInterval, callback)
+}
+
+func TestWatchAssignmentsTimeout(t *testing.T) {
+	const (
+		timeout      = 200 * time.Millisecond
+		pollInterval = 50 * time.Millisecond
+	)
+
+	cfg := viper.New()
+	cfg.Set("frontend.watchAssignmentsTimeout", timeout)
+	cfg.Set("frontend.watchAssignmentsPollInterval", pollInterval)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	ctx := utilTesting.NewContext(t)
+	ticket := &pb.Ticket{Id: "1"}
+	require.NoError(t, store.CreateTicket(ctx, ticket))
+	polling := &pollIntervalStore{Service: store}
+	fs := frontendService{cfg: cfg, store: polling, watchOptions: newWatchOptions(cfg)}
+
+	start := time.Now()
+	err := fs.WatchAssignments(&pb.WatchAssignmentsRequest{TicketId: ticket.Id}, &blockingWatchStream{ctx: ctx})
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "timeout")
+	elapsed := time.Since(start)
+	require.GreaterOrEqual(t, int64(elapsed), int64(timeout))
+	require.Less(t, int64(elapsed), int64(5*timeout))
+	require.Equal(t, pollInterval, polling.pollInterval)
+
+	// The client's own deadline isn't reported as the timeout.
+	clientCtx, cancel := context.WithTimeout(ctx, timeout/2)
+	defer cancel()
+	err = fs.WatchAssignments(&pb.WatchAssignmentsRequest{TicketId: ticket.Id}, &blockingWatchStream{ctx: clientCtx})
+	require.Error(t, err)
+	require.NotContains(t, status.Convert(err).Message(), "timeout")
+}
+
+func TestWatchAssignmentsLimit(t *testing.T) {
+	const maxWatches = 2
+
+	cfg := viper.New()
+	cfg.Set("maxConcurrentWatches", maxWatches)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	fs := frontendService{cfg: cfg, store: store, watchSlots: newWatchSlots(cfg)}
+
+	ctx, cancel := context.WithCancel(utilTesting.NewContext(t))
+	defer cancel()
+
+	ticket := &pb.Ticket{
+		Id:         "1",
+		Assignment: &pb.Assignment{Connection: "1.2.3.4"},
+	}
+	require.NoError(t, store.CreateTicket(ctx, ticket))
+
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	watch := func() *blockingWatchStream {
+		stream := &blockingWatchStream{ctx: ctx, sent: make(chan struct{}), release: release}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = fs.WatchAssignments(&pb.WatchAssignmentsRequest{TicketId: ticket.Id}, stream)
+		}()
+		return stream
+	}
+
+	// Fill every slot with a watch which is blocked sending its assignment.
+	for i := 0; i < maxWatches; i++ {
+		<-watch().sent
+	}
+
+	err := fs.WatchAssignments(&pb.WatchAssignmentsRequest{TicketId: ticket.Id}, &blockingWatchStream{ctx: ctx})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	retryInfo, ok := details[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	require.Equal(t, watchRetryDelay, retryInfo.GetRetryDelay().AsDuration())
+
+	// Finishing the open watches frees their slots.
+	cancel()
+	close(release)
+	wg.Wait()
+	require.Len(t, fs.watchSlots, 0)
+}
+
+func TestWatchAssignmentsRateLimit(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("watchAssignmentsRateLimit.perSecond", 1)
+	cfg.Set("watchAssignmentsRateLimit.burst", 3)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	clock := utilTesting.NewFakeClock(time.Now())
+	fs := frontendService{cfg: cfg, store: store, watchLimiter: newWatchLimiter(cfg, clock)}
+
+	// Admitted watches end immediately with the canceled context.
+	ctx, cancel := context.WithCancel(utilTesting.NewContext(t))
+	cancel()
+	watch := func(ip string, port int) error {
+		peerCtx := peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: port}})
+		return fs.WatchAssignments(&pb.WatchAssignmentsRequest{TicketId: "1"}, &blockingWatchStream{ctx: peerCtx})
+	}
+
+	// Reconnecting from new ports doesn't escape the peer's limit.
+	for port := 1000; port < 1003; port++ {
+		require.Equal(t, context.Canceled, watch("10.0.0.1", port))
+	}
+	err := watch("10.0.0.1", 1003)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	_, ok := details[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+
+	// Other peers are not throttled.
+	require.Equal(t, context.Canceled, watch("10.0.0.2", 1000))
+
+	clock.Advance(time.Second)
+	require.Equal(t, context.Canceled, watch("10.0.0.1", 1004))
+	require.Equal(t, codes.ResourceExhausted, status.Code(watch("10.0.0.1", 1005)))
+}
+
+func TestNewWatchLimiter(t *testing.T) {
+	// The limiter is off by default.
+	cfg := viper.New()
+	require.Nil(t, newWatchLimiter(cfg, utilTesting.NewFakeClock(time.Now())))
+
+	cfg.Set("watchAssignmentsRateLimit.perSecond", 0)
+	require.Nil(t, newWatchLimiter(cfg, utilTesting.NewFakeClock(time.Now())))
+
+	cfg.Set("watchAssignmentsRateLimit.perSecond", 50)
+	l := newWatchLimiter(cfg, utilTesting.NewFakeClock(time.Now()))
+	require.NotNil(t, l)
+	require.Equal(t, float64(50), l.perSecond)
+	require.Equal(t, float64(100), l.burst)
+}
+
+// countingBackfillStore counts the GetBackfill calls reaching the store.
+type countingBackfillStore struct {
+	statestore.Service
+	gets int
+}
+
+func (s *countingBackfillStore) GetBackfill(ctx context.Context, id string) (*pb.Backfill, []string, error) {
+	s.gets++
+	return s.Service.GetBackfill(ctx, id)
+}
+
+func TestGetBackfillCache(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("backfillCacheTTL", "1s")
+	ctx := utilTesting.NewContext(t)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	clock := utilTesting.NewFakeClock(time.Now())
+	counting := &countingBackfillStore{Service: store}
+	fs := &frontendService{
+		cfg:           cfg,
+		store:         counting,
+		backfillCache: newBackfillCache(cfg, clock),
+	}
+
+	created, err := fs.CreateBackfill(ctx, &pb.CreateBackfillRequest{Backfill: &pb.Backfill{}})
+	require.NoError(t, err)
+	get := &pb.GetBackfillRequest{BackfillId: created.Id}
+
+	bf, err := fs.GetBackfill(ctx, get)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), bf.Generation)
+	require.Equal(t, 1, counting.gets)
+
+	bf, err = fs.GetBackfill(ctx, get)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), bf.Generation)
+	require.Equal(t, 1, counting.gets, "cached read should not hit the store")
+
+	_, err = fs.UpdateBackfill(ctx, &pb.UpdateBackfillRequest{Backfill: &pb.Backfill{Id: created.Id}})
+	require.NoError(t, err)
+	gets := counting.gets
+
+	bf, err = fs.GetBackfill(ctx, get)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), bf.Generation, "update should invalidate the cache")
+	require.Equal(t, gets+1, counting.gets)
+
+	clock.Advance(time.Second)
+	_, err = fs.GetBackfill(ctx, get)
+	require.NoError(t, err)
+	require.Equal(t, gets+2, counting.gets, "expired entry should be reloaded")
+
+	_, err = fs.DeleteBackfill(ctx, &pb.DeleteBackfillRequest{BackfillId: created.Id})
+	require.NoError(t, err)
+	_, err = fs.GetBackfill(ctx, get)
+	require.Equal(t, codes.NotFound.String(), status.Convert(err).Code().String())
+}
+
+func TestNewBackfillCache(t *testing.T) {
+	cfg := viper.New()
+	require.Nil(t, newBackfillCache(cfg, utilTesting.NewFakeClock(time.Now())))
+
+	cfg.Set("backfillCacheTTL", "500ms")
+	c := newBackfillCache(cfg, utilTesting.NewFakeClock(time.Now()))
+	require.NotNil(t, c)
+	require.Equal(t, 500*time.Millisecond, c.ttl)
+}
+
+func TestFallbackAssignment(t *testing.T) {
+	const (
+		connection = "lobby:7777"
+		timeout    = 200 * time.Millisecond
+	)
+
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	require.Nil(t, newFallbackAssigner(cfg, store, nil, nil, nil))
+
+	notified := make(chan *pb.AssignTicketsRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := &pb.AssignTicketsRequest{}
+		require.NoError(t, jsonpb.UnmarshalString(string(body), req))
+		notified <- req
+	}))
+	defer server.Close()
+
+	cfg.Set("assignmentWebhook.url", server.URL)
+	cfg.Set("fallbackAssignment.connection", connection)
+	cfg.Set("fallbackAssignment.timeout", timeout)
+	cfg.Set("fallbackAssignment.interval", 20*time.Millisecond)
+	fs := frontendService{cfg: cfg, store: store}
+	ctx, cancel := context.WithCancel(utilTesting.NewContext(t))
+	defer cancel()
+
+	waiting, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.NoError(t, err)
+	proposed, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.NoError(t, err)
+	require.NoError(t, store.AddTicketsToPendingRelease(ctx, []string{proposed.Id}))
+	matched, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.NoError(t, err)
+	_, _, err = store.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{{TicketIds: []string{matched.Id}, Assignment: &pb.Assignment{Connection: "game:1"}}},
+	})
+	require.NoError(t, err)
+
+	sink := &auditRecorder{}
+	auditLog := util.NewAuditLogWithSink(sink)
+	webhook := util.NewAssignmentWebhook(cfg)
+	defer webhook.Close()
+	fallback := newFallbackAssigner(cfg, store, nil, auditLog, webhook)
+	require.NotNil(t, fallback)
+
+	start := time.Now()
+	var got *pb.Assignment
+	err = doWatchAssignments(ctx, waiting.Id, func(a *pb.Assignment) error {
+		got = a
+		cancel()
+		return nil
+	}, store, nil, watchOptions{})
+	require.Error(t, err)
+	require.Equal(t, connection, got.GetConnection())
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(timeout/2))
+	fallback.close()
+
+	ids, err := store.GetIndexedIDSet(utilTesting.NewContext(t))
+	require.NoError(t, err)
+	require.NotContains(t, ids, waiting.Id)
+
+	// Tickets pending release are left to the backend.
+	ticket, err := store.GetTicket(utilTesting.NewContext(t), proposed.Id)
+	require.NoError(t, err)
+	require.Nil(t, ticket.Assignment)
+
+	// Tickets a backend assigned keep their assignment.
+	ticket, err = store.GetTicket(utilTesting.NewContext(t), matched.Id)
+	require.NoError(t, err)
+	require.Equal(t, "game:1", ticket.Assignment.GetConnection())
+
+	// The fallback assignment is audited and posted to the webhook.
+	require.NoError(t, auditLog.Close())
+	require.Len(t, sink.events, 1)
+	require.Equal(t, util.AuditAssigned, sink.events[0].Type)
+	require.Equal(t, waiting.Id, sink.events[0].TicketID)
+	require.Equal(t, connection, sink.events[0].Connection)
+
+	select {
+	case req := <-notified:
+		want := &pb.AssignTicketsRequest{
+			Assignments: []*pb.AssignmentGroup{{TicketIds: []string{waiting.Id}, Assignment: &pb.Assignment{Connection: connection}}},
+		}
+		require.True(t, proto.Equal(want, req), "got %v", req)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "webhook was not called")
+	}
+}
+
+// blockingWatchStream is a WatchAssignments stream which signals sent, then
+// blocks until release is closed, on every Send.
+type blockingWatchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	sent    chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *blockingWatchStream) Send(*pb.WatchAssignmentsResponse) error {
+	s.sent <- struct{}{}
+	<-s.release
+	return nil
+}
+
+// queryBackfillsStream records the responses sent on a QueryBackfills stream.
+type queryBackfillsStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*pb.QueryBackfillsResponse
+}
+
+func (s *queryBackfillsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *queryBackfillsStream) Send(resp *pb.QueryBackfillsResponse) error {
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+func TestQueryBackfills(t *testing.T) {
+	const pageSize = 2
+
+	cfg := viper.New()
+	cfg.Set("queryPageSize", pageSize)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	ctx := utilTesting.NewContext(t)
+	fs := frontendService{cfg: cfg, store: store}
+
+	regions := []string{"region.eu", "region.eu", "region.eu", "region.us", "region.us"}
+	want := map[string][]string{}
+	for _, region := range regions {
+		bf, err := fs.CreateBackfill(ctx, &pb.CreateBackfillRequest{Backfill: &pb.Backfill{
+			SearchFields: &pb.SearchFields{Tags: []string{region}},
+		}})
+		require.NoError(t, err)
+		want[region] = append(want[region], bf.Id)
+	}
+
+	query := func(req *pb.QueryBackfillsRequest) ([]string, error) {
+		stream := &queryBackfillsStream{ctx: ctx}
+		err := fs.QueryBackfills(req, stream)
+		var ids []string
+		for _, resp := range stream.responses {
+			require.NotEmpty(t, resp.Backfills)
+			require.LessOrEqual(t, len(resp.Backfills), pageSize)
+			for _, bf := range resp.Backfills {
+				ids = append(ids, bf.Id)
+			}
+		}
+		return ids, err
+	}
+
+	ids, err := query(&pb.QueryBackfillsRequest{Pool: &pb.Pool{
+		TagPresentFilters: []*pb.TagPresentFilter{{Tag: "region.eu"}},
+	}})
+	require.NoError(t, err)
+	require.ElementsMatch(t, want["region.eu"], ids)
+
+	// A Pool without Filters matches every Backfill.
+	ids, err = query(&pb.QueryBackfillsRequest{Pool: &pb.Pool{}})
+	require.NoError(t, err)
+	require.ElementsMatch(t, append(want["region.eu"], want["region.us"]...), ids)
+
+	ids, err = query(&pb.QueryBackfillsRequest{Pool: &pb.Pool{}, Generation: &pb.GenerationRange{Min: 2, Max: 3}})
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	_, err = query(&pb.QueryBackfillsRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = query(&pb.QueryBackfillsRequest{Pool: &pb.Pool{}, Generation: &pb.GenerationRange{Min: 2, Max: 1}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDeleteTickets(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("queryPageSize", 2)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	ctx := utilTesting.NewContext(t)
+	fs := frontendService{cfg: cfg, store: store}
+
+	regions := []string{"region.eu", "region.eu", "region.eu", "region.eu", "region.us"}
+	created := map[string][]string{}
+	for _, region := range regions {
+		ticket, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{
+			SearchFields: &pb.SearchFields{Tags: []string{region}},
+		}})
+		require.NoError(t, err)
+		created[region] = append(created[region], ticket.Id)
+	}
+	eu, us := created["region.eu"], created["region.us"]
+
+	// Tickets which don't exist are not counted.
+	resp, err := fs.DeleteTickets(ctx, &pb.DeleteTicketsRequest{TicketIds: []string{eu[0], "missing"}})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), resp.DeletedCount)
+	_, err = store.GetTicket(ctx, eu[0])
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// The matching Tickets span several pages.
+	resp, err = fs.DeleteTickets(ctx, &pb.DeleteTicketsRequest{Pool: &pb.Pool{
+		TagPresentFilters: []*pb.TagPresentFilter{{Tag: "region.eu"}},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), resp.DeletedCount)
+	for _, id := range eu {
+		_, err = store.GetTicket(ctx, id)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	}
+
+	indexed, err := store.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{us[0]: {}}, indexed)
+
+	for _, req := range []*pb.DeleteTicketsRequest{
+		{},
+		{TicketIds: us, Pool: &pb.Pool{}},
+	} {
+		_, err = fs.DeleteTickets(ctx, req)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestDoCancelBackfill(t *testing.T) {
+	cfg := viper.New()
+	ctx := utilTesting.NewContext(t)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	_, err := doCancelBackfill(ctx, "missing", store)
+	require.Equal(t, codes.NotFound.String(), status.Convert(err).Code().String())
+
+	waiting := &pb.Ticket{Id: "waiting"}
+	assigned := &pb.Ticket{Id: "assigned", Assignment: &pb.Assignment{Connection: "127.0.0.1:7777"}}
+	for _, ticket := range []*pb.Ticket{waiting, assigned} {
+		require.NoError(t, store.CreateTicket(ctx, ticket))
+	}
+	require.NoError(t, store.IndexTicket(ctx, waiting))
+
+	ticketIDs := []string{waiting.Id, assigned.Id, "deleted"}
+	bf := &pb.Backfill{Id: "bf1", Generation: 1}
+	require.NoError(t, store.CreateBackfill(ctx, bf, ticketIDs))
+	require.NoError(t, store.IndexBackfill(ctx, bf))
+	require.NoError(t, store.AddTicketsToPendingRelease(ctx, ticketIDs))
+
+	ids, err := store.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, ids, waiting.Id)
+
+	requeued, err := doCancelBackfill(ctx, bf.Id, store)
+	require.NoError(t, err)
+	require.Equal(t, []string{waiting.Id}, requeued)
+
+	ids, err = store.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Contains(t, ids, waiting.Id)
+	require.NotContains(t, ids, assigned.Id)
+
+	_, _, err = store.GetBackfill(ctx, bf.Id)
+	require.Equal(t, codes.NotFound.String(), status.Convert(err).Code().String())
+}
+
+func TestAcknowledgeBackfill(t *testing.T) {
+	cfg := viper.New()
+	ctx := utilTesting.NewContext(t)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	notified := make(chan *pb.AssignTicketsRequest, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := &pb.AssignTicketsRequest{}
+		require.NoError(t, jsonpb.UnmarshalString(string(body), req))
+		notified <- req
+	}))
+	defer server.Close()
+	cfg.Set("assignmentWebhook.url", server.URL)
+	webhook := util.NewAssignmentWebhook(cfg)
+	defer webhook.Close()
+
+	sink := &auditRecorder{}
+	auditLog := util.NewAuditLogWithSink(sink)
+	fs := frontendService{cfg: cfg, store: store, auditLog: auditLog, webhook: webhook}
+
+	assignment := &pb.Assignment{Connection: "127.0.0.1:7777"}
+	_, err := fs.AcknowledgeBackfill(ctx, &pb.AcknowledgeBackfillRequest{BackfillId: "missing", Assignment: assignment})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	for _, req := range []*pb.AcknowledgeBackfillRequest{
+		{Assignment: assignment},
+		{BackfillId: "bf1"},
+	} {
+		_, err = fs.AcknowledgeBackfill(ctx, req)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	var ticketIDs []string
+	for _, id := range []string{"t1", "t2"} {
+		ticket := &pb.Ticket{Id: id}
+		require.NoError(t, store.CreateTicket(ctx, ticket))
+		require.NoError(t, store.IndexTicket(ctx, ticket))
+		ticketIDs = append(ticketIDs, id)
+	}
+	bf := &pb.Backfill{Id: "bf1", Generation: 1}
+	require.NoError(t, store.CreateBackfill(ctx, bf, ticketIDs))
+	require.NoError(t, store.IndexBackfill(ctx, bf))
+	require.NoError(t, store.AddTicketsToPendingRelease(ctx, ticketIDs))
+
+	// Retrying leaves the Backfill and its Tickets as the first call did.
+	for i := 0; i < 2; i++ {
+		resp, err := fs.AcknowledgeBackfill(ctx, &pb.AcknowledgeBackfillRequest{BackfillId: bf.Id, Assignment: assignment})
+		require.NoError(t, err)
+		require.Equal(t, bf.Id, resp.Id)
+		require.Equal(t, bf.Generation, resp.Generation)
+
+		for _, id := range ticketIDs {
+			ticket, err := store.GetTicket(ctx, id)
+			require.NoError(t, err)
+			require.Equal(t, assignment.Connection, ticket.GetAssignment().GetConnection())
+		}
+
+		_, associated, err := store.GetBackfill(ctx, bf.Id)
+		require.NoError(t, err)
+		require.Empty(t, associated)
+
+		ids, err := store.GetIndexedIDSet(ctx)
+		require.NoError(t, err)
+		require.Empty(t, ids)
+	}
+
+	// Only the first call assigns the Tickets, and its assignment is audited
+	// and posted to the webhook.
+	require.NoError(t, auditLog.Close())
+	require.Len(t, sink.events, len(ticketIDs))
+	for i, e := range sink.events {
+		require.Equal(t, util.AuditAssigned, e.Type)
+		require.Equal(t, ticketIDs[i], e.TicketID)
+		require.Equal(t, assignment.Connection, e.Connection)
+	}
+
+	select {
+	case req := <-notified:
+		want := &pb.AssignTicketsRequest{
+			Assignments: []*pb.AssignmentGroup{{TicketIds: ticketIDs, Assignment: assignment}},
+		}
+		require.True(t, proto.Equal(want, req), "got %v", req)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "webhook was not called")
+	}
+}
+
 func TestDoDeleteTicket(t *testing.T) {
 	fakeTicket := &pb.Ticket{
 		Id: "1",
@@ -385,12 +1578,44 @@ func TestDoDeleteTicket(t *testing.T) {
 
 			test.preAction(ctx, cancel, store)
 
-			err := doDeleteTicket(ctx, fakeTicket.GetId(), store)
+			err := doDeleteTicket(ctx, fakeTicket.GetId(), store, nil)
 			require.Equal(t, test.wantCode.String(), status.Convert(err).Code().String())
 		})
 	}
 }
 
+func TestDeleteTicketAudit(t *testing.T) {
+	ctx := utilTesting.NewContext(t)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+	sink := &auditRecorder{}
+	fs := frontendService{cfg: viper.New(), store: store, auditLog: util.NewAuditLogWithSink(sink)}
+
+	require.NoError(t, store.CreateTicket(ctx, &pb.Ticket{Id: "1"}))
+	_, err := fs.DeleteTicket(ctx, &pb.DeleteTicketRequest{TicketId: "1"})
+	require.NoError(t, err)
+	require.NoError(t, fs.auditLog.Close())
+
+	require.Len(t, sink.events, 1)
+	require.Equal(t, util.AuditDeleted, sink.events[0].Type)
+	require.Equal(t, "1", sink.events[0].TicketID)
+	require.Empty(t, sink.events[0].Connection)
+	require.False(t, sink.events[0].Time.IsZero())
+}
+
+type auditRecorder struct {
+	events []*util.AuditEvent
+}
+
+func (r *auditRecorder) Write(e *util.AuditEvent) error {
+	r.events = append(r.events, e)
+	return nil
+}
+
+func (r *auditRecorder) Close() error {
+	return nil
+}
+
 func TestDoGetTicket(t *testing.T) {
 	fakeTicket := &pb.Ticket{
 		Id: "1",
@@ -450,6 +1675,139 @@ func TestDoGetTicket(t *testing.T) {
 	}
 }
 
+func TestDoUpdateTicket(t *testing.T) {
+	createTime := ptypes.TimestampNow()
+	fakeTicket := &pb.Ticket{
+		Id: "1",
+		SearchFields: &pb.SearchFields{
+			DoubleArgs: map[string]float64{
+				"mmr": 10,
+			},
+		},
+		CreateTime: createTime,
+	}
+	update := &pb.UpdateTicketRequest{
+		TicketId: fakeTicket.Id,
+		SearchFields: &pb.SearchFields{
+			DoubleArgs: map[string]float64{
+				"mmr": 80,
+			},
+		},
+	}
+
+	tests := []struct {
+		description string
+		preAction   func(context.Context, statestore.Service)
+		wantCode    codes.Code
+	}{
+		{
+			description: "expect not found code since ticket does not exist",
+			preAction:   func(_ context.Context, _ statestore.Service) {},
+			wantCode:    codes.NotFound,
+		},
+		{
+			description: "expect not found code since ticket is assigned",
+			preAction: func(ctx context.Context, store statestore.Service) {
+				assigned, ok := proto.Clone(fakeTicket).(*pb.Ticket)
+				require.True(t, ok)
+				assigned.Assignment = &pb.Assignment{Connection: "1.2.3.4"}
+				require.NoError(t, store.CreateTicket(ctx, assigned))
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			description: "expect ok code with updated search fields",
+			preAction: func(ctx context.Context, store statestore.Service) {
+				require.NoError(t, store.CreateTicket(ctx, fakeTicket))
+				require.NoError(t, store.IndexTicket(ctx, fakeTicket))
+			},
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			ctx := utilTesting.NewContext(t)
+			store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+			defer closer()
+
+			test.preAction(ctx, store)
+
+			ticket, err := doUpdateTicket(ctx, update, store)
+			require.Equal(t, test.wantCode.String(), status.Convert(err).Code().String())
+			if err != nil {
+				return
+			}
+
+			require.Equal(t, update.SearchFields.DoubleArgs, ticket.SearchFields.DoubleArgs)
+
+			stored, err := store.GetTicket(ctx, fakeTicket.Id)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(ticket, stored))
+			require.True(t, proto.Equal(createTime, stored.CreateTime))
+
+			revisions, err := store.GetTicketRevisions(ctx)
+			require.NoError(t, err)
+			require.Equal(t, 1, revisions[fakeTicket.Id])
+		})
+	}
+}
+
+func TestUpdateTicketDeleteRace(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("poolSaturation.tags", []string{"mode.ctf"})
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	ctx := utilTesting.NewContext(t)
+	fs := frontendService{cfg: cfg, store: store}
+
+	update := func(id string) error {
+		_, err := fs.UpdateTicket(ctx, &pb.UpdateTicketRequest{
+			TicketId:     id,
+			SearchFields: &pb.SearchFields{Tags: []string{"mode.ctf"}},
+		})
+		return err
+	}
+
+	// A Ticket deleted but not yet lazily removed from storage isn't updated.
+	ticket, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.NoError(t, err)
+	require.NoError(t, store.DeindexTicket(ctx, ticket.Id))
+	require.Equal(t, codes.NotFound, status.Code(update(ticket.Id)))
+
+	for i := 0; i < 20; i++ {
+		ticket, err := fs.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		var updateErr, deleteErr error
+		go func() {
+			defer wg.Done()
+			updateErr = update(ticket.Id)
+		}()
+		go func() {
+			defer wg.Done()
+			_, deleteErr = fs.DeleteTicket(ctx, &pb.DeleteTicketRequest{TicketId: ticket.Id})
+		}()
+		wg.Wait()
+
+		require.NoError(t, deleteErr)
+		if updateErr != nil {
+			require.Equal(t, codes.NotFound, status.Code(updateErr))
+		}
+	}
+
+	// However the races went, no deleted Ticket is left indexed.
+	ids, err := store.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+	count, err := store.GetPoolTicketCount(ctx, "mode.ctf")
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
 func TestGetBackfill(t *testing.T) {
 	fakeBackfill := &pb.Backfill{
 		Id: "1",
@@ -496,7 +1854,7 @@ func TestGetBackfill(t *testing.T) {
 			ctx, cancel := context.WithCancel(utilTesting.NewContext(t))
 			store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
 			defer closer()
-			fs := frontendService{cfg, store}
+			fs := frontendService{cfg: cfg, store: store}
 
 			test.preAction(ctx, cancel, store)
 
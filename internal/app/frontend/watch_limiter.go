@@ -0,0 +1,130 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frontend
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/peer"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/util"
+)
+
+// watchLimiter limits how quickly each peer may open WatchAssignments
+// streams, using a token bucket per peer host.  Peers are identified by
+// address, so clients behind the same proxy share a bucket.  This includes
+// every REST client, which reaches gRPC through the in-process gateway, so
+// the limiter is off unless configured.
+type watchLimiter struct {
+	clock     util.Clock
+	perSecond float64
+	burst     float64
+
+	mu        sync.Mutex
+	buckets   map[string]*watchBucket
+	lastPrune time.Time
+}
+
+type watchBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newWatchLimiter returns the limiter configured by watchAssignmentsRateLimit,
+// or nil if no positive perSecond is configured, which is the default.  The
+// burst defaults to twice the rate.
+func newWatchLimiter(cfg config.View, clock util.Clock) *watchLimiter {
+	const name = "watchAssignmentsRateLimit"
+
+	perSecond := cfg.GetFloat64(name + ".perSecond")
+	if !cfg.IsSet(name+".perSecond") || perSecond <= 0 {
+		return nil
+	}
+
+	burst := 2 * perSecond
+	if cfg.IsSet(name+".burst") && cfg.GetInt(name+".burst") > 0 {
+		burst = float64(cfg.GetInt(name + ".burst"))
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &watchLimiter{
+		clock:     clock,
+		perSecond: perSecond,
+		burst:     burst,
+		buckets:   make(map[string]*watchBucket),
+		lastPrune: clock.Now(),
+	}
+}
+
+// allow takes a token from the bucket of the peer of ctx, and reports whether
+// there was one.  A nil limiter allows everything.
+func (l *watchLimiter) allow(ctx context.Context) bool {
+	if l == nil {
+		return true
+	}
+
+	key := ""
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		key = p.Addr.String()
+		if host, _, err := net.SplitHostPort(key); err == nil {
+			key = host
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	l.prune(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &watchBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * l.perSecond
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// prune forgets the buckets which have refilled, as a new bucket is
+// equivalent.  It runs at most once per refill period.
+func (l *watchLimiter) prune(now time.Time) {
+	refill := time.Duration(l.burst / l.perSecond * float64(time.Second))
+	if now.Sub(l.lastPrune) < refill {
+		return
+	}
+	l.lastPrune = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
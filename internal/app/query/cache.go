@@ -121,21 +121,33 @@ collectAllWaiting:
 }
 
 func newTicketCache(b *appmain.Bindings, store statestore.Service) *cache {
+	c := makeTicketCache(store)
+	b.AddHealthCheckFunc(c.store.HealthCheck)
+
+	return c
+}
+
+func makeTicketCache(store statestore.Service) *cache {
+	// Revisions of the cached tickets, only written by update.
+	revisions := make(map[string]int)
 	c := &cache{
 		store:           store,
 		requests:        make(chan *cacheRequest),
 		startRunRequest: make(chan struct{}, 1),
 		value:           make(map[string]*pb.Ticket),
-		update:          updateTicketCache,
+		update: func(store statestore.Service, value interface{}) error {
+			return updateTicketCache(store, value, revisions)
+		},
 	}
 
 	c.startRunRequest <- struct{}{}
-	b.AddHealthCheckFunc(c.store.HealthCheck)
 
 	return c
 }
 
-func updateTicketCache(store statestore.Service, value interface{}) error {
+// updateTicketCache fetches newly indexed tickets, and refetches tickets which
+// were updated since they were cached, as told by their revision.
+func updateTicketCache(store statestore.Service, value interface{}, revisions map[string]int) error {
 	if value == nil {
 		return status.Error(codes.InvalidArgument, "value is required")
 	}
@@ -152,11 +164,19 @@ func updateTicketCache(store statestore.Service, value interface{}) error {
 		return err
 	}
 
+	currentRevisions, err := store.GetTicketRevisions(context.Background())
+	if err != nil {
+		return err
+	}
+
 	deletedCount := 0
 	for id := range tickets {
 		if _, ok := currentAll[id]; !ok {
 			delete(tickets, id)
+			delete(revisions, id)
 			deletedCount++
+		} else if currentRevisions[id] != revisions[id] {
+			delete(tickets, id)
 		}
 	}
 
@@ -174,6 +194,13 @@ func updateTicketCache(store statestore.Service, value interface{}) error {
 
 	for _, t := range newTickets {
 		tickets[t.Id] = t
+		// The ticket may have been updated again after reading the revisions,
+		// in which case it is just fetched once more on the next update.
+		if r, ok := currentRevisions[t.Id]; ok {
+			revisions[t.Id] = r
+		} else {
+			delete(revisions, t.Id)
+		}
 	}
 
 	stats.Record(context.Background(), cacheTotalItems.M(int64(previousCount)))
@@ -0,0 +1,79 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"math/rand"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// validateOrder fails with InvalidArgument if the pool's order is unknown.
+func validateOrder(pool *pb.Pool) error {
+	if _, ok := pb.Pool_Order_name[int32(pool.GetOrder())]; !ok {
+		return status.Errorf(codes.InvalidArgument, ".pool.order %d is unknown", pool.GetOrder())
+	}
+	return nil
+}
+
+// orderTickets sorts the tickets in the pool's order.  Tickets are first put
+// in id order, so that the RANDOM order only depends on the seed and the set
+// of tickets, and tickets created at the same time are in a stable order.
+// pf is the filter of the pool, which ranks the tickets in the AFFINITY order.
+func orderTickets(pool *pb.Pool, pf *filter.PoolFilter, tickets []*pb.Ticket) {
+	if pool.GetOrder() == pb.Pool_INDEX {
+		return
+	}
+
+	sort.Slice(tickets, func(i, j int) bool {
+		return tickets[i].GetId() < tickets[j].GetId()
+	})
+
+	switch pool.GetOrder() {
+	case pb.Pool_OLDEST_FIRST:
+		sort.SliceStable(tickets, func(i, j int) bool {
+			return createdBefore(tickets[i], tickets[j])
+		})
+	case pb.Pool_NEWEST_FIRST:
+		sort.SliceStable(tickets, func(i, j int) bool {
+			return createdBefore(tickets[j], tickets[i])
+		})
+	case pb.Pool_RANDOM:
+		r := rand.New(rand.NewSource(pool.GetOrderSeed()))
+		r.Shuffle(len(tickets), func(i, j int) {
+			tickets[i], tickets[j] = tickets[j], tickets[i]
+		})
+	case pb.Pool_AFFINITY:
+		affinity := make(map[string]int, len(tickets))
+		for _, t := range tickets {
+			affinity[t.GetId()] = pf.Affinity(t)
+		}
+		sort.SliceStable(tickets, func(i, j int) bool {
+			return affinity[tickets[i].GetId()] > affinity[tickets[j].GetId()]
+		})
+	}
+}
+
+func createdBefore(a, b *pb.Ticket) bool {
+	at, bt := a.GetCreateTime(), b.GetCreateTime()
+	if at.GetSeconds() != bt.GetSeconds() {
+		return at.GetSeconds() < bt.GetSeconds()
+	}
+	return at.GetNanos() < bt.GetNanos()
+}
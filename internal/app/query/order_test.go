@@ -0,0 +1,110 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestOrderTickets(t *testing.T) {
+	// Ticket ids don't follow create times, and c and d were created at once.
+	created := map[string]*timestamp.Timestamp{
+		"a": {Seconds: 30},
+		"b": {Seconds: 10},
+		"c": {Seconds: 20, Nanos: 5},
+		"d": {Seconds: 20, Nanos: 5},
+		"e": {Seconds: 20},
+	}
+	tickets := func() []*pb.Ticket {
+		var tickets []*pb.Ticket
+		for _, id := range []string{"d", "a", "e", "c", "b"} {
+			tickets = append(tickets, &pb.Ticket{Id: id, CreateTime: created[id]})
+		}
+		return tickets
+	}
+	ordered := func(pool *pb.Pool) []string {
+		ts := tickets()
+		orderTickets(pool, nil, ts)
+		var ids []string
+		for _, t := range ts {
+			ids = append(ids, t.Id)
+		}
+		return ids
+	}
+
+	require.Equal(t, []string{"d", "a", "e", "c", "b"}, ordered(&pb.Pool{}))
+	require.Equal(t, []string{"b", "e", "c", "d", "a"}, ordered(&pb.Pool{Order: pb.Pool_OLDEST_FIRST}))
+	require.Equal(t, []string{"a", "c", "d", "e", "b"}, ordered(&pb.Pool{Order: pb.Pool_NEWEST_FIRST}))
+
+	// The same seed gives the same order whatever the order of the input,
+	// and another seed gives another order.
+	random := ordered(&pb.Pool{Order: pb.Pool_RANDOM, OrderSeed: 42})
+	require.ElementsMatch(t, []string{"a", "b", "c", "d", "e"}, random)
+	reversed := tickets()
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	orderTickets(&pb.Pool{Order: pb.Pool_RANDOM, OrderSeed: 42}, nil, reversed)
+	for i, ticket := range reversed {
+		require.Equal(t, random[i], ticket.Id)
+	}
+	require.NotEqual(t, random, ordered(&pb.Pool{Order: pb.Pool_RANDOM, OrderSeed: 7}))
+}
+
+func TestOrderTicketsAffinity(t *testing.T) {
+	pool := &pb.Pool{
+		Order: pb.Pool_AFFINITY,
+		StringEqualsFilters: []*pb.StringEqualsFilter{
+			{StringArg: "region", Value: "europe"},
+			{StringArg: "platform", Value: "pc", Optional: true},
+		},
+		TagPresentFilters: []*pb.TagPresentFilter{{Tag: "voice", Optional: true}},
+	}
+	pf, err := filter.NewPoolFilter(pool)
+	require.NoError(t, err)
+
+	ticket := func(id, platform string, tags ...string) *pb.Ticket {
+		return &pb.Ticket{Id: id, SearchFields: &pb.SearchFields{
+			StringArgs: map[string]string{"region": "europe", "platform": platform},
+			Tags:       tags,
+		}}
+	}
+	tickets := []*pb.Ticket{
+		ticket("a", "console"),
+		ticket("b", "pc"),
+		ticket("c", "console", "voice"),
+		ticket("d", "pc", "voice"),
+		ticket("e", "pc"),
+	}
+	orderTickets(pool, pf, tickets)
+
+	var ids []string
+	for _, t := range tickets {
+		ids = append(ids, t.Id)
+	}
+	require.Equal(t, []string{"d", "b", "c", "e", "a"}, ids)
+}
+
+func TestValidateOrder(t *testing.T) {
+	require.NoError(t, validateOrder(&pb.Pool{Order: pb.Pool_NEWEST_FIRST}))
+	require.Equal(t, codes.InvalidArgument, status.Code(validateOrder(&pb.Pool{Order: pb.Pool_Order(42)})))
+}
@@ -0,0 +1,186 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/rs/xid"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/stats"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	defaultProbeTimeout = 30 * time.Second
+	probePollInterval   = 10 * time.Millisecond
+	probeTicketIDPrefix = "probe-"
+	probeTag            = "open-match.dev/ingest-probe"
+)
+
+// ingestProbe measures how long a created Ticket takes to become queryable.
+// Every interval it creates a synthetic Ticket, polls a pool selecting it
+// until the ticket cache returns it, and deletes it again.  Synthetic Tickets
+// are hidden from QueryTickets and QueryTicketIds so that they are never
+// matched.
+type ingestProbe struct {
+	store    statestore.Service
+	tc       *cache
+	interval time.Duration
+	timeout  time.Duration
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// newIngestProbe starts the probe, or returns nil if no ingestProbe.interval
+// is configured.
+func newIngestProbe(cfg config.View, store statestore.Service, tc *cache) *ingestProbe {
+	interval := cfg.GetDuration("ingestProbe.interval")
+	if interval <= 0 {
+		return nil
+	}
+
+	timeout := cfg.GetDuration("ingestProbe.timeout")
+	if timeout <= 0 {
+		timeout = defaultProbeTimeout
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &ingestProbe{
+		store:    store,
+		tc:       tc,
+		interval: interval,
+		timeout:  timeout,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go p.run(ctx)
+	return p
+}
+
+func (p *ingestProbe) run(ctx context.Context) {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			latency, err := p.probe(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				stats.Record(ctx, probeFailures.M(1))
+				logger.WithError(err).Warning("ingest probe failed")
+				continue
+			}
+			stats.Record(ctx, probeLatency.M(float64(latency)/float64(time.Millisecond)))
+		}
+	}
+}
+
+// probe creates a synthetic Ticket and returns the time until it was
+// queryable.
+func (p *ingestProbe) probe(ctx context.Context) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	pf, err := filter.NewPoolFilter(&pb.Pool{
+		Name:              "ingest-probe",
+		TagPresentFilters: []*pb.TagPresentFilter{{Tag: probeTag}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	ticket := &pb.Ticket{
+		Id:           probeTicketIDPrefix + xid.New().String(),
+		SearchFields: &pb.SearchFields{Tags: []string{probeTag}},
+		CreateTime:   ptypes.TimestampNow(),
+	}
+
+	start := time.Now()
+	if err = p.store.CreateTicket(ctx, ticket); err != nil {
+		return 0, err
+	}
+	defer p.delete(ticket.Id)
+	if err = p.store.IndexTicket(ctx, ticket); err != nil {
+		return 0, err
+	}
+
+	for {
+		found := false
+		err = p.tc.request(ctx, func(value interface{}) {
+			tickets, ok := value.(map[string]*pb.Ticket)
+			if !ok {
+				logger.Errorf("expecting value type map[string]*pb.Ticket, but got: %T", value)
+				return
+			}
+			t, ok := tickets[ticket.Id]
+			found = ok && pf.In(t)
+		})
+		if err != nil {
+			return 0, err
+		}
+		if found {
+			return time.Since(start), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(probePollInterval):
+		}
+	}
+}
+
+// delete removes a synthetic Ticket, even once the probe has timed out.
+func (p *ingestProbe) delete(id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	if err := p.store.DeindexTicket(ctx, id); err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"id":    id,
+		}).Error("failed to deindex the ingest probe ticket")
+		return
+	}
+	if err := p.store.DeleteTicket(ctx, id); err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"id":    id,
+		}).Error("failed to delete the ingest probe ticket")
+	}
+}
+
+// close stops the probe, waiting for a running probe to finish.
+func (p *ingestProbe) close() {
+	p.cancel()
+	<-p.done
+}
+
+// isProbeTicket returns whether the ticket was created by the ingest probe.
+func isProbeTicket(ticket *pb.Ticket) bool {
+	return strings.HasPrefix(ticket.Id, probeTicketIDPrefix)
+}
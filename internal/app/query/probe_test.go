@@ -0,0 +1,68 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	statestoreTesting "open-match.dev/open-match/internal/statestore/testing"
+)
+
+func TestIngestProbeRecordsLatency(t *testing.T) {
+	require.NoError(t, view.Register(probeLatencyView, probeFailuresView))
+	defer view.Unregister(probeLatencyView, probeFailuresView)
+
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	cfg.Set("ingestProbe.interval", "10ms")
+	cfg.Set("ingestProbe.timeout", "5s")
+	probe := newIngestProbe(cfg, store, makeTicketCache(store))
+	require.NotNil(t, probe)
+
+	var dist *view.DistributionData
+	require.Eventually(t, func() bool {
+		rows, err := view.RetrieveData(probeLatencyView.Name)
+		require.NoError(t, err)
+		if len(rows) == 0 {
+			return false
+		}
+		dist = rows[0].Data.(*view.DistributionData)
+		return dist.Count >= 3
+	}, 5*time.Second, 10*time.Millisecond)
+	probe.close()
+
+	require.Greater(t, dist.Min, float64(0))
+	require.Less(t, dist.Max, float64(5*time.Second/time.Millisecond))
+
+	rows, err := view.RetrieveData(probeFailuresView.Name)
+	require.NoError(t, err)
+	require.Empty(t, rows)
+
+	// Synthetic tickets are deleted once they have been seen.
+	ids, err := store.GetIndexedIDSet(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
+
+func TestIngestProbeDisabled(t *testing.T) {
+	require.Nil(t, newIngestProbe(viper.New(), nil, nil))
+}
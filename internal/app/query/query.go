@@ -17,10 +17,12 @@ package query
 import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"google.golang.org/grpc"
 	"open-match.dev/open-match/internal/appmain"
 	"open-match.dev/open-match/internal/statestore"
 	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/internal/util"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -31,6 +33,14 @@ var (
 	cacheFetchedItems   = stats.Int64("open-match.dev/query/fetched_items", "Number of fetched items in total", stats.UnitDimensionless)
 	cacheWaitingQueries = stats.Int64("open-match.dev/query/waiting_queries", "Number of waiting queries in the last update", stats.UnitDimensionless)
 	cacheUpdateLatency  = stats.Float64("open-match.dev/query/update_latency", "Time elapsed of each query cache update", stats.UnitMilliseconds)
+	filterCandidates    = stats.Int64("open-match.dev/query/filter_candidates", "Number of candidates remaining after a pool filter, recorded when queryDiagnostics is enabled", stats.UnitDimensionless)
+	probeLatency        = stats.Float64("open-match.dev/query/ingest_probe_latency", "Time from creating a synthetic ticket until it is queryable", stats.UnitMilliseconds)
+	probeFailures       = stats.Int64("open-match.dev/query/ingest_probe_failures", "Number of synthetic tickets which did not become queryable", stats.UnitDimensionless)
+
+	filterKey = tag.MustNewKey("filter")
+	// poolKey is the name of the queried pool.  Pools are named by the match
+	// profiles, which keeps the number of values bounded.
+	poolKey = tag.MustNewKey("pool")
 
 	ticketsPerQueryView = &view.View{
 		Measure:     ticketsPerQuery,
@@ -74,15 +84,44 @@ var (
 		Description: "Time elapsed of each query cache update",
 		Aggregation: telemetry.DefaultMillisecondsDistribution,
 	}
+	filterCandidatesView = &view.View{
+		Measure:     filterCandidates,
+		Name:        "open-match.dev/query/filter_candidates",
+		Description: "Candidates remaining after each pool filter",
+		Aggregation: telemetry.DefaultCountDistribution,
+		TagKeys:     []tag.Key{filterKey, poolKey},
+	}
+	probeLatencyView = &view.View{
+		Measure:     probeLatency,
+		Name:        "open-match.dev/query/ingest_probe_latency",
+		Description: "Time from creating a synthetic ticket until it is queryable",
+		Aggregation: telemetry.DefaultMillisecondsDistribution,
+	}
+	probeFailuresView = &view.View{
+		Measure:     probeFailures,
+		Name:        "open-match.dev/query/ingest_probe_failures",
+		Description: "Number of synthetic tickets which did not become queryable",
+		Aggregation: view.Sum(),
+	}
 )
 
 // BindService creates the query service and binds it to the serving harness.
 func BindService(p *appmain.Params, b *appmain.Bindings) error {
+	assignmentCipher, err := util.NewAssignmentCipher(p.Config())
+	if err != nil {
+		return err
+	}
+
 	store := statestore.New(p.Config())
 	service := &queryService{
-		cfg: p.Config(),
-		tc:  newTicketCache(b, store),
-		bc:  newBackfillCache(b, store),
+		cfg:              p.Config(),
+		store:            store,
+		tc:               newTicketCache(b, store),
+		bc:               newBackfillCache(b, store),
+		assignmentCipher: assignmentCipher,
+	}
+	if probe := newIngestProbe(p.Config(), store, service.tc); probe != nil {
+		b.AddCloser(probe.close)
 	}
 
 	b.AddHandleFunc(func(s *grpc.Server) {
@@ -96,6 +135,9 @@ func BindService(p *appmain.Params, b *appmain.Bindings) error {
 		cacheFetchedItemsView,
 		cacheWaitingQueriesView,
 		cacheUpdateLatencyView,
+		filterCandidatesView,
+		probeLatencyView,
+		probeFailuresView,
 	)
 	return nil
 }
@@ -15,14 +15,21 @@
 package query
 
 import (
+	"context"
+
 	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/internal/util"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -36,9 +43,13 @@ var (
 // queryService API provides utility functions for common MMF functionality such
 // as retreiving Tickets from state storage.
 type queryService struct {
-	cfg config.View
-	tc  *cache
-	bc  *cache
+	cfg   config.View
+	store statestore.Service
+	tc    *cache
+	bc    *cache
+	// assignmentCipher decrypts assignment connections read from storage.
+	// It is nil when assignments are stored in plaintext.
+	assignmentCipher *util.AssignmentCipher
 }
 
 func (s *queryService) QueryTickets(req *pb.QueryTicketsRequest, responseServer pb.QueryService_QueryTicketsServer) error {
@@ -52,6 +63,11 @@ func (s *queryService) QueryTickets(req *pb.QueryTicketsRequest, responseServer
 	if err != nil {
 		return err
 	}
+	if err = validateOrder(pool); err != nil {
+		return err
+	}
+
+	m := newPoolMatcher(s.cfg, pool, pf)
 
 	var results []*pb.Ticket
 	err = s.tc.request(ctx, func(value interface{}) {
@@ -62,7 +78,10 @@ func (s *queryService) QueryTickets(req *pb.QueryTicketsRequest, responseServer
 		}
 
 		for _, ticket := range tickets {
-			if pf.In(ticket) {
+			if isProbeTicket(ticket) {
+				continue
+			}
+			if m.in(ticket) {
 				results = append(results, ticket)
 			}
 		}
@@ -71,7 +90,16 @@ func (s *queryService) QueryTickets(req *pb.QueryTicketsRequest, responseServer
 		err = errors.Wrap(err, "QueryTickets: failed to run request")
 		return err
 	}
+	m.recordDiagnostics(ctx)
 	stats.Record(ctx, ticketsPerQuery.M(int64(len(results))))
+	orderTickets(pool, pf, results)
+
+	if req.GetIncludeAssignments() {
+		results, err = withAssignments(ctx, s.store, s.assignmentCipher, results)
+		if err != nil {
+			return err
+		}
+	}
 
 	pSize := getPageSize(s.cfg)
 	for start := 0; start < len(results); start += pSize {
@@ -91,6 +119,37 @@ func (s *queryService) QueryTickets(req *pb.QueryTicketsRequest, responseServer
 	return nil
 }
 
+// withAssignments returns copies of the tickets carrying their current
+// Assignment in state storage, decrypted as the frontend does.  The cache only
+// refetches tickets when they are updated, which assigning a ticket is not, so
+// cached tickets may not show their assignment.
+func withAssignments(ctx context.Context, store statestore.Service, assignmentCipher *util.AssignmentCipher, tickets []*pb.Ticket) ([]*pb.Ticket, error) {
+	ids := make([]string, 0, len(tickets))
+	for _, t := range tickets {
+		ids = append(ids, t.Id)
+	}
+
+	current, err := store.GetTickets(ctx, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "QueryTickets: failed to get ticket assignments")
+	}
+	assignments := make(map[string]*pb.Assignment, len(current))
+	for _, t := range current {
+		assignments[t.Id], err = assignmentCipher.Decrypt(t.Assignment)
+		if err != nil {
+			return nil, errors.Wrapf(err, "QueryTickets: failed to decrypt the assignment of ticket %s", t.Id)
+		}
+	}
+
+	results := make([]*pb.Ticket, 0, len(tickets))
+	for _, t := range tickets {
+		copied := proto.Clone(t).(*pb.Ticket)
+		copied.Assignment = assignments[t.Id]
+		results = append(results, copied)
+	}
+	return results, nil
+}
+
 func (s *queryService) QueryTicketIds(req *pb.QueryTicketIdsRequest, responseServer pb.QueryService_QueryTicketIdsServer) error {
 	ctx := responseServer.Context()
 	pool := req.GetPool()
@@ -102,8 +161,13 @@ func (s *queryService) QueryTicketIds(req *pb.QueryTicketIdsRequest, responseSer
 	if err != nil {
 		return err
 	}
+	if err = validateOrder(pool); err != nil {
+		return err
+	}
+
+	m := newPoolMatcher(s.cfg, pool, pf)
 
-	var results []string
+	var matched []*pb.Ticket
 	err = s.tc.request(ctx, func(value interface{}) {
 		tickets, ok := value.(map[string]*pb.Ticket)
 		if !ok {
@@ -111,9 +175,12 @@ func (s *queryService) QueryTicketIds(req *pb.QueryTicketIdsRequest, responseSer
 			return
 		}
 
-		for id, ticket := range tickets {
-			if pf.In(ticket) {
-				results = append(results, id)
+		for _, ticket := range tickets {
+			if isProbeTicket(ticket) {
+				continue
+			}
+			if m.in(ticket) {
+				matched = append(matched, ticket)
 			}
 		}
 	})
@@ -121,7 +188,14 @@ func (s *queryService) QueryTicketIds(req *pb.QueryTicketIdsRequest, responseSer
 		err = errors.Wrap(err, "QueryTicketIds: failed to run request")
 		return err
 	}
-	stats.Record(ctx, ticketsPerQuery.M(int64(len(results))))
+	m.recordDiagnostics(ctx)
+	stats.Record(ctx, ticketsPerQuery.M(int64(len(matched))))
+	orderTickets(pool, pf, matched)
+
+	results := make([]string, 0, len(matched))
+	for _, ticket := range matched {
+		results = append(results, ticket.GetId())
+	}
 
 	pSize := getPageSize(s.cfg)
 	for start := 0; start < len(results); start += pSize {
@@ -152,6 +226,12 @@ func (s *queryService) QueryBackfills(req *pb.QueryBackfillsRequest, responseSer
 	if err != nil {
 		return err
 	}
+	generation := req.GetGeneration()
+	if generation.GetMin() > generation.GetMax() {
+		return status.Error(codes.InvalidArgument, ".generation.min is greater than .generation.max")
+	}
+
+	m := newPoolMatcher(s.cfg, pool, pf)
 
 	var results []*pb.Backfill
 	err = s.bc.request(ctx, func(value interface{}) {
@@ -162,7 +242,10 @@ func (s *queryService) QueryBackfills(req *pb.QueryBackfillsRequest, responseSer
 		}
 
 		for _, backfill := range backfills {
-			if pf.In(backfill) {
+			if generation != nil && (backfill.Generation < generation.Min || backfill.Generation > generation.Max) {
+				continue
+			}
+			if m.in(backfill) {
 				results = append(results, backfill)
 			}
 		}
@@ -171,6 +254,7 @@ func (s *queryService) QueryBackfills(req *pb.QueryBackfillsRequest, responseSer
 		err = errors.Wrap(err, "QueryBackfills: failed to run request")
 		return err
 	}
+	m.recordDiagnostics(ctx)
 	stats.Record(ctx, backfillsPerQuery.M(int64(len(results))))
 
 	pSize := getPageSize(s.cfg)
@@ -222,3 +306,75 @@ func getPageSize(cfg config.View) int {
 
 	return pSize
 }
+
+// getDiagnosticsEnabled returns whether queries should record how many
+// candidates remain after each filter of the pool.  This is intended for
+// tuning pools which unexpectedly return nothing, and is off by default.
+func getDiagnosticsEnabled(cfg config.View) bool {
+	const name = "queryDiagnostics"
+
+	if !cfg.IsSet(name) {
+		return false
+	}
+
+	return cfg.GetBool(name)
+}
+
+// candidate is a Ticket or Backfill considered for a pool.
+type candidate interface {
+	GetId() string
+	GetSearchFields() *pb.SearchFields
+	GetCreateTime() *timestamp.Timestamp
+}
+
+// poolMatcher decides which candidates belong to the pool of a query.  When
+// queryDiagnostics is enabled, it also counts the candidates remaining after
+// each filter of the pool.
+type poolMatcher struct {
+	pool *pb.Pool
+	pf   *filter.PoolFilter
+	d    *filter.Diagnostics
+}
+
+func newPoolMatcher(cfg config.View, pool *pb.Pool, pf *filter.PoolFilter) *poolMatcher {
+	m := &poolMatcher{pool: pool, pf: pf}
+	if getDiagnosticsEnabled(cfg) {
+		m.d = pf.NewDiagnostics()
+	}
+	return m
+}
+
+// in returns true if the candidate belongs to the pool.
+func (m *poolMatcher) in(c candidate) bool {
+	if m.d != nil {
+		return m.d.In(c)
+	}
+	return m.pf.In(c)
+}
+
+// recordDiagnostics logs and records the number of candidates remaining after
+// each filter, tagged with the name of the filter and of the pool.  It does
+// nothing unless queryDiagnostics is enabled.
+func (m *poolMatcher) recordDiagnostics(ctx context.Context) {
+	if m.d == nil {
+		return
+	}
+	counts := m.d.Counts()
+
+	fields := logrus.Fields{
+		"pool":  m.pool.GetName(),
+		"total": m.d.Total(),
+	}
+	for _, c := range counts {
+		fields[c.Filter] = c.Count
+	}
+	logger.WithFields(fields).Debug("candidates remaining after each pool filter")
+
+	for _, c := range counts {
+		tags := []tag.Mutator{tag.Upsert(filterKey, c.Filter), tag.Upsert(poolKey, m.pool.GetName())}
+		err := stats.RecordWithTags(ctx, tags, filterCandidates.M(int64(c.Count)))
+		if err != nil {
+			logger.WithError(err).Warning("failed to record filter diagnostics")
+		}
+	}
+}
@@ -15,11 +15,23 @@
 package query
 
 import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"sync/atomic"
 	"testing"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"google.golang.org/grpc"
 	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/filter"
+	statestoreTesting "open-match.dev/open-match/internal/statestore/testing"
+	"open-match.dev/open-match/internal/util"
+	"open-match.dev/open-match/pkg/matchfunction"
+	"open-match.dev/open-match/pkg/pb"
 )
 
 func TestGetPageSize(t *testing.T) {
@@ -66,3 +78,156 @@ func TestGetPageSize(t *testing.T) {
 		})
 	}
 }
+
+func TestWithAssignmentsEncrypted(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	cfg.Set("assignmentEncryptionKey", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	assignmentCipher, err := util.NewAssignmentCipher(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, assignmentCipher)
+
+	tickets := []*pb.Ticket{{Id: "assigned"}, {Id: "waiting"}}
+	for _, ticket := range tickets {
+		require.NoError(t, store.CreateTicket(ctx, ticket))
+	}
+	encrypted, err := assignmentCipher.Encrypt(&pb.Assignment{Connection: "1.2.3.4:5678"})
+	require.NoError(t, err)
+	_, _, err = store.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{{TicketIds: []string{"assigned"}, Assignment: encrypted}},
+	})
+	require.NoError(t, err)
+
+	got, err := withAssignments(ctx, store, assignmentCipher, tickets)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, "1.2.3.4:5678", got[0].GetAssignment().GetConnection())
+	require.Nil(t, got[1].GetAssignment())
+}
+
+// BenchmarkQueryTicketsPageSize measures querying a pool of 10k tickets over
+// gRPC with different queryPageSize values, reporting the number of messages
+// streamed per query.  QueryPool unpacks the pages.
+func BenchmarkQueryTicketsPageSize(b *testing.B) {
+	const tickets = 10000
+
+	for _, pageSize := range []int{10, 100, 1000} {
+		pageSize := pageSize
+		b.Run(fmt.Sprintf("page-%d", pageSize), func(b *testing.B) {
+			ctx := context.Background()
+			cfg := viper.New()
+			store, closer := statestoreTesting.NewStoreServiceForTesting(b, cfg)
+			defer closer()
+			cfg.Set("queryPageSize", pageSize)
+
+			for i := 0; i < tickets; i++ {
+				t := &pb.Ticket{
+					Id:           fmt.Sprintf("%d", i),
+					SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": float64(i)}},
+				}
+				if err := store.CreateTicket(ctx, t); err != nil {
+					b.Fatal(err)
+				}
+				if err := store.IndexTicket(ctx, t); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			l, err := net.Listen("tcp", "localhost:0")
+			if err != nil {
+				b.Fatal(err)
+			}
+			var messages int64
+			s := grpc.NewServer(grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				return handler(srv, &countingServerStream{ServerStream: ss, messages: &messages})
+			}))
+			pb.RegisterQueryServiceServer(s, &queryService{cfg: cfg, store: store, tc: makeTicketCache(store)})
+			go func() {
+				_ = s.Serve(l)
+			}()
+			defer s.Stop()
+
+			conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer conn.Close()
+			client := pb.NewQueryServiceClient(conn)
+			pool := &pb.Pool{DoubleRangeFilters: []*pb.DoubleRangeFilter{{DoubleArg: "mmr", Min: 0, Max: tickets}}}
+
+			b.ResetTimer()
+			atomic.StoreInt64(&messages, 0)
+			for i := 0; i < b.N; i++ {
+				got, err := matchfunction.QueryPool(ctx, client, pool)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(got) != tickets {
+					b.Fatalf("got %d tickets, want %d", len(got), tickets)
+				}
+			}
+			b.ReportMetric(float64(atomic.LoadInt64(&messages))/float64(b.N), "msgs/op")
+		})
+	}
+}
+
+// countingServerStream counts the messages sent on the stream.
+type countingServerStream struct {
+	grpc.ServerStream
+	messages *int64
+}
+
+func (s *countingServerStream) SendMsg(m interface{}) error {
+	atomic.AddInt64(s.messages, 1)
+	return s.ServerStream.SendMsg(m)
+}
+
+func TestPoolMatcherDiagnosticsTaggedByPool(t *testing.T) {
+	require.NoError(t, view.Register(filterCandidatesView))
+	defer view.Unregister(filterCandidatesView)
+
+	cfg := viper.New()
+	ctx := context.Background()
+	tickets := []*pb.Ticket{
+		{SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 1}}},
+		{SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 5}}},
+	}
+	pools := []*pb.Pool{
+		{Name: "low", DoubleRangeFilters: []*pb.DoubleRangeFilter{{DoubleArg: "mmr", Min: 0, Max: 2}}},
+		{Name: "high", DoubleRangeFilters: []*pb.DoubleRangeFilter{{DoubleArg: "mmr", Min: 4, Max: 10}}},
+	}
+
+	// Diagnostics are off by default.
+	pf, err := filter.NewPoolFilter(pools[0])
+	require.NoError(t, err)
+	m := newPoolMatcher(cfg, pools[0], pf)
+	require.Nil(t, m.d)
+	require.True(t, m.in(tickets[0]))
+	require.False(t, m.in(tickets[1]))
+
+	cfg.Set("queryDiagnostics", true)
+	for _, pool := range pools {
+		pf, err := filter.NewPoolFilter(pool)
+		require.NoError(t, err)
+		m := newPoolMatcher(cfg, pool, pf)
+		matched := 0
+		for _, ticket := range tickets {
+			if m.in(ticket) {
+				matched++
+			}
+		}
+		require.Equal(t, 1, matched)
+		m.recordDiagnostics(ctx)
+	}
+
+	// Pools sharing a filter name are recorded apart.
+	rows, err := view.RetrieveData(filterCandidatesView.Name)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		require.Len(t, row.Tags, 2)
+		require.Equal(t, int64(1), row.Data.(*view.DistributionData).Count)
+	}
+}
@@ -28,6 +28,7 @@ var (
 	iterationLatency        = stats.Float64("open-match.dev/synchronizer/iteration_latency", "Time elapsed of each synchronizer iteration", stats.UnitMilliseconds)
 	registrationWaitTime    = stats.Float64("open-match.dev/synchronizer/registration_wait_time", "Time elapsed of registration wait time", stats.UnitMilliseconds)
 	registrationMMFDoneTime = stats.Float64("open-match.dev/synchronizer/registration_mmf_done_time", "Time elapsed wasted in registration window with done MMFs", stats.UnitMilliseconds)
+	matchIDCollisions       = stats.Int64("open-match.dev/synchronizer/match_id_collisions", "Number of proposals dropped for reusing the match id of another proposal in the cycle", stats.UnitDimensionless)
 
 	iterationLatencyView = &view.View{
 		Measure:     iterationLatency,
@@ -47,6 +48,12 @@ var (
 		Description: "Time elapsed wasted in registration window with done MMFs",
 		Aggregation: telemetry.DefaultMillisecondsDistribution,
 	}
+	matchIDCollisionsView = &view.View{
+		Measure:     matchIDCollisions,
+		Name:        "open-match.dev/synchronizer/match_id_collisions",
+		Description: "Number of proposals dropped for reusing the match id of another proposal in the cycle",
+		Aggregation: view.Sum(),
+	}
 )
 
 // BindService creates the synchronizer service and binds it to the serving harness.
@@ -61,6 +68,7 @@ func BindService(p *appmain.Params, b *appmain.Bindings) error {
 		iterationLatencyView,
 		registrationWaitTimeView,
 		registrationMMFDoneTimeView,
+		matchIDCollisionsView,
 	)
 	return nil
 }
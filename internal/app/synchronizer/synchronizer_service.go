@@ -229,7 +229,7 @@ func (s *synchronizerService) runCycle() {
 	closedOnCycleEnd := make(chan struct{})
 
 	go func() {
-		fanInFanOut(m2c, m3c, m6c)
+		fanInFanOut(m2c, m3c, m6c, s.rejectMatchIDCollisions())
 		// Close response channels after all responses have been sent.
 		for _, r := range registrations {
 			close(r.m7c)
@@ -308,8 +308,10 @@ type mAndM6c struct {
 // Each incoming match is passed along with it's synchronize call's m7c channel.
 // This channel is remembered in a map, and the match is passed to be evaluated.
 // When a match returns from evaluation, it's ID is looked up in the map and the
-// match is returned on that channel.
-func fanInFanOut(m2c <-chan mAndM6c, m3c chan<- *pb.Match, m6c <-chan string) {
+// match is returned on that channel.  If rejectCollisions is set, a match
+// reusing the ID of an earlier match in the cycle is dropped instead of being
+// evaluated, as MMF replicas may generate the same IDs.
+func fanInFanOut(m2c <-chan mAndM6c, m3c chan<- *pb.Match, m6c <-chan string, rejectCollisions bool) {
 	m6cMap := make(map[string]chan<- string)
 
 	defer func(m2c <-chan mAndM6c) {
@@ -321,6 +323,13 @@ func fanInFanOut(m2c <-chan mAndM6c, m3c chan<- *pb.Match, m6c <-chan string) {
 		select {
 		case m2, ok := <-m2c:
 			if ok {
+				if _, collides := m6cMap[m2.m.GetMatchId()]; collides && rejectCollisions {
+					stats.Record(context.Background(), matchIDCollisions.M(1))
+					logger.WithFields(logrus.Fields{
+						"matchId": m2.m.GetMatchId(),
+					}).Warning("Dropping proposal which reuses the match id of another proposal in the cycle.")
+					continue
+				}
 				m6cMap[m2.m.GetMatchId()] = m2.m7c
 				m3c <- m2.m
 			} else {
@@ -437,11 +446,13 @@ func getTicketIds(tickets []*pb.Ticket) []string {
 // Calls statestore to add all of the tickets returned by the evaluator to the
 // pendingRelease list.  If it partially fails for whatever reason (not all tickets will
 // necessarily be in the same call), only the matches which can be safely
-// returned to the Synchronize calls are.
+// returned to the Synchronize calls are.  Once the evaluator is done, the
+// tickets of rejected proposals are held back for the rejectedTicketCooldown.
 func (s *synchronizerService) addMatchesToPendingRelease(ctx context.Context, m *sync.Map, cancel contextcause.CancelErrFunc, m5c <-chan []string, m6c chan<- string) {
 	totalMatches := 0
 	successfulMatches := 0
 	var lastErr error
+	accepted := map[string]struct{}{}
 	for mIDs := range m5c {
 		ids := []string{}
 		for _, mID := range mIDs {
@@ -463,10 +474,21 @@ func (s *synchronizerService) addMatchesToPendingRelease(ctx context.Context, m
 		}
 
 		for _, mID := range mIDs {
+			accepted[mID] = struct{}{}
 			m6c <- mID
 		}
 	}
 
+	if cooldown := s.rejectedTicketCooldown(); cooldown > 0 {
+		ids := rejectedTicketIds(m, accepted)
+		if err := s.store.AddTicketsToCooldown(ctx, ids, cooldown); err != nil {
+			logger.WithFields(logrus.Fields{
+				"error":   err.Error(),
+				"tickets": len(ids),
+			}).Error("failed to hold back the tickets of rejected proposals")
+		}
+	}
+
 	if lastErr != nil {
 		logger.WithFields(logrus.Fields{
 			"error":             lastErr.Error(),
@@ -481,6 +503,38 @@ func (s *synchronizerService) addMatchesToPendingRelease(ctx context.Context, m
 	close(m6c)
 }
 
+// rejectedTicketIds returns the tickets of the cached proposals which were not
+// accepted by the evaluator, and are not part of any accepted proposal.
+func rejectedTicketIds(m *sync.Map, accepted map[string]struct{}) []string {
+	inAccepted := map[string]struct{}{}
+	for mID := range accepted {
+		if tids, ok := m.Load(mID); ok {
+			for _, id := range tids.([]string) {
+				inAccepted[id] = struct{}{}
+			}
+		}
+	}
+
+	rejected := map[string]struct{}{}
+	m.Range(func(mID, tids interface{}) bool {
+		if _, ok := accepted[mID.(string)]; ok {
+			return true
+		}
+		for _, id := range tids.([]string) {
+			if _, ok := inAccepted[id]; !ok {
+				rejected[id] = struct{}{}
+			}
+		}
+		return true
+	})
+
+	ids := make([]string, 0, len(rejected))
+	for id := range rejected {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 ///////////////////////////////////////
 ///////////////////////////////////////
 
@@ -510,6 +564,31 @@ func (s *synchronizerService) proposalCollectionInterval() time.Duration {
 	return s.cfg.GetDuration(name)
 }
 
+// rejectedTicketCooldown is how long the tickets of proposals rejected by the
+// evaluator are hidden from queries.  Defaults to zero, releasing them at once.
+func (s *synchronizerService) rejectedTicketCooldown() time.Duration {
+	const name = "rejectedTicketCooldown"
+
+	if !s.cfg.IsSet(name) {
+		return 0
+	}
+
+	return s.cfg.GetDuration(name)
+}
+
+// rejectMatchIDCollisions is whether proposals which reuse the match id of
+// another proposal in the cycle are dropped.  By default such a collision fails
+// the whole cycle.
+func (s *synchronizerService) rejectMatchIDCollisions() bool {
+	const name = "rejectMatchIdCollisions"
+
+	if !s.cfg.IsSet(name) {
+		return false
+	}
+
+	return s.cfg.GetBool(name)
+}
+
 ///////////////////////////////////////
 ///////////////////////////////////////
 
@@ -22,6 +22,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"go.opencensus.io/stats/view"
 
@@ -96,6 +97,13 @@ func (b *Bindings) AddHealthCheckFunc(f func(context.Context) error) {
 	b.sp.AddHealthCheckFunc(f)
 }
 
+// SetAuthorizer checks every gRPC call to the application with a, such as to
+// only allow a specific backend to call AssignTickets.  All calls are allowed
+// by default.
+func (b *Bindings) SetAuthorizer(a rpc.Authorizer) {
+	b.sp.SetAuthorizer(a)
+}
+
 // RegisterViews begins collecting data for the given views.
 func (b *Bindings) RegisterViews(v ...*view.View) {
 	if err := view.Register(v...); err != nil {
@@ -104,6 +112,7 @@ func (b *Bindings) RegisterViews(v ...*view.View) {
 		}
 		return
 	}
+	b.a.views = append(b.a.views, v...)
 
 	b.AddCloser(func() {
 		view.Unregister(v...)
@@ -143,12 +152,20 @@ func (b *Bindings) AddCloserErr(c func() error) {
 
 // App is used internally, and public only for apptest.  Do not use, and use apptest instead.
 type App struct {
-	closers []func() error
+	closers      []func() error
+	serviceName  string
+	started      time.Time
+	views        []*view.View
+	flushMetrics func(views []*view.View, start time.Time)
 }
 
 // NewApplication is used internally, and public only for apptest.  Do not use, and use apptest instead.
 func NewApplication(serviceName string, bindService Bind, getCfg func() (config.View, error), listen func(network, address string) (net.Listener, error)) (*App, error) {
-	a := &App{}
+	a := &App{
+		serviceName:  serviceName,
+		started:      time.Now(),
+		flushMetrics: telemetry.FlushViews,
+	}
 
 	cfg, err := getCfg()
 	if err != nil {
@@ -191,6 +208,10 @@ func NewApplication(serviceName string, bindService Bind, getCfg func() (config.
 		_ = surpressedErr
 		return nil, b.firstErr
 	}
+	// Added after the service's closers so it runs before the views are
+	// unregistered, and before the server's closer so it runs after the server
+	// has stopped taking requests.
+	b.AddCloser(a.shutdown)
 
 	s := &rpc.Server{}
 	err = s.Start(sp)
@@ -204,6 +225,20 @@ func NewApplication(serviceName string, bindService Bind, getCfg func() (config.
 	return a, nil
 }
 
+// shutdown flushes the metrics recorded since the last report, and logs a
+// summary of the application's lifetime.
+func (a *App) shutdown() {
+	a.flushMetrics(a.views, a.started)
+
+	requests, errors := rpc.ServerRequestCounts()
+	logger.WithFields(logrus.Fields{
+		"service":  a.serviceName,
+		"uptime":   time.Since(a.started).String(),
+		"requests": requests,
+		"errors":   errors,
+	}).Info("Application shutting down.")
+}
+
 // Stop is used internally, and public only for apptest.  Do not use, and use apptest instead.
 func (a *App) Stop() error {
 	// Use closers in reverse order: Since dependencies are created before
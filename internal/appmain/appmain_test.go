@@ -0,0 +1,64 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appmain
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"open-match.dev/open-match/internal/config"
+)
+
+func TestStopFlushesMetrics(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("telemetry.reportingPeriod", "1m")
+
+	v := &view.View{
+		Name:        "open-match.dev/test/appmain",
+		Measure:     stats.Int64("open-match.dev/test/appmain", "Test measure", stats.UnitDimensionless),
+		Aggregation: view.Count(),
+	}
+	bind := func(p *Params, b *Bindings) error {
+		b.RegisterViews(v)
+		return nil
+	}
+	getCfg := func() (config.View, error) {
+		return cfg, nil
+	}
+	listen := func(network, address string) (net.Listener, error) {
+		return net.Listen(network, "localhost:0")
+	}
+
+	a, err := NewApplication("test", bind, getCfg, listen)
+	require.NoError(t, err)
+
+	var flushed []*view.View
+	var registered bool
+	a.flushMetrics = func(views []*view.View, start time.Time) {
+		flushed = views
+		// The views must still be registered for their data to be flushed.
+		registered = view.Find(v.Name) != nil
+	}
+
+	require.NoError(t, a.Stop())
+	require.Equal(t, []*view.View{v}, flushed)
+	require.True(t, registered)
+	require.Nil(t, view.Find(v.Name))
+}
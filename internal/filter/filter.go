@@ -79,83 +79,224 @@ type filteredEntity interface {
 	GetCreateTime() *timestamp.Timestamp
 }
 
-// In returns true if the Ticket meets all the criteria for this PoolFilter.
+// In returns true if the Ticket meets all the criteria for this PoolFilter,
+// apart from the optional filters.
 func (pf *PoolFilter) In(entity filteredEntity) bool {
+	return pf.passed(entity) == pf.filterCount()
+}
+
+// filterCount returns the number of individual filters which are evaluated by
+// passed, including the created before and after bounds when they are set.
+func (pf *PoolFilter) filterCount() int {
+	n := len(pf.DoubleRangeFilters) + len(pf.StringEqualsFilters) + len(pf.TagPresentFilters)
+	if !pf.CreatedAfter.IsZero() {
+		n++
+	}
+	if !pf.CreatedBefore.IsZero() {
+		n++
+	}
+	return n
+}
+
+// filterNames returns a name for each individual filter, in the same order as
+// they are evaluated by passed.
+func (pf *PoolFilter) filterNames() []string {
+	names := make([]string, 0, pf.filterCount())
+	if !pf.CreatedAfter.IsZero() {
+		names = append(names, "created_after")
+	}
+	if !pf.CreatedBefore.IsZero() {
+		names = append(names, "created_before")
+	}
+	for _, f := range pf.DoubleRangeFilters {
+		names = append(names, "double_range:"+f.DoubleArg)
+	}
+	for _, f := range pf.StringEqualsFilters {
+		names = append(names, "string_equals:"+f.StringArg)
+	}
+	for _, f := range pf.TagPresentFilters {
+		names = append(names, "tag_present:"+f.Tag)
+	}
+	return names
+}
+
+// passed returns how many of the filters, in evaluation order, the entity
+// meets before the first filter which excludes it.
+func (pf *PoolFilter) passed(entity filteredEntity) int {
 	s := entity.GetSearchFields()
 
 	if s == nil {
 		s = emptySearchFields
 	}
 
+	n := 0
+
 	if !pf.CreatedAfter.IsZero() || !pf.CreatedBefore.IsZero() {
 		// CreateTime is only populated by Open Match and hence expected to be valid.
 		if ct, err := ptypes.Timestamp(entity.GetCreateTime()); err == nil {
 			if !pf.CreatedAfter.IsZero() {
 				if !ct.After(pf.CreatedAfter) {
-					return false
+					return n
 				}
+				n++
 			}
 
 			if !pf.CreatedBefore.IsZero() {
 				if !ct.Before(pf.CreatedBefore) {
-					return false
+					return n
 				}
+				n++
 			}
 		} else {
 			logger.WithFields(logrus.Fields{
 				"error": err.Error(),
 				"id":    entity.GetId(),
 			}).Error("failed to get time from Timestamp proto")
+
+			if !pf.CreatedAfter.IsZero() {
+				n++
+			}
+			if !pf.CreatedBefore.IsZero() {
+				n++
+			}
 		}
 	}
 
+	// Optional filters never exclude the entity.
 	for _, f := range pf.DoubleRangeFilters {
-		v, ok := s.DoubleArgs[f.DoubleArg]
-		if !ok {
-			return false
+		if !f.Optional && !doubleRangeIn(f, s) {
+			return n
 		}
+		n++
+	}
 
-		switch f.Exclude {
-		case pb.DoubleRangeFilter_NONE:
-			// Not simplified so that NaN cases are handled correctly.
-			if !(v >= f.Min && v <= f.Max) {
-				return false
-			}
-		case pb.DoubleRangeFilter_MIN:
-			if !(v > f.Min && v <= f.Max) {
-				return false
-			}
-		case pb.DoubleRangeFilter_MAX:
-			if !(v >= f.Min && v < f.Max) {
-				return false
-			}
-		case pb.DoubleRangeFilter_BOTH:
-			if !(v > f.Min && v < f.Max) {
-				return false
-			}
+	for _, f := range pf.StringEqualsFilters {
+		if !f.Optional && !stringEqualsIn(f, s) {
+			return n
 		}
+		n++
+	}
 
+	for _, f := range pf.TagPresentFilters {
+		if !f.Optional && !tagPresentIn(f, s) {
+			return n
+		}
+		n++
 	}
 
-	for _, f := range pf.StringEqualsFilters {
-		v, ok := s.StringArgs[f.StringArg]
-		if !ok {
-			return false
+	return n
+}
+
+// Affinity returns how many of the optional filters the entity meets.
+func (pf *PoolFilter) Affinity(entity filteredEntity) int {
+	s := entity.GetSearchFields()
+
+	if s == nil {
+		s = emptySearchFields
+	}
+
+	n := 0
+	for _, f := range pf.DoubleRangeFilters {
+		if f.Optional && doubleRangeIn(f, s) {
+			n++
 		}
-		if f.Value != v {
-			return false
+	}
+	for _, f := range pf.StringEqualsFilters {
+		if f.Optional && stringEqualsIn(f, s) {
+			n++
 		}
 	}
-
-outer:
 	for _, f := range pf.TagPresentFilters {
-		for _, v := range s.Tags {
-			if v == f.Tag {
-				continue outer
-			}
+		if f.Optional && tagPresentIn(f, s) {
+			n++
 		}
+	}
+	return n
+}
+
+func doubleRangeIn(f *pb.DoubleRangeFilter, s *pb.SearchFields) bool {
+	v, ok := s.DoubleArgs[f.DoubleArg]
+	if !ok {
 		return false
 	}
 
+	switch f.Exclude {
+	case pb.DoubleRangeFilter_NONE:
+		// Not simplified so that NaN cases are handled correctly.
+		return v >= f.Min && v <= f.Max
+	case pb.DoubleRangeFilter_MIN:
+		return v > f.Min && v <= f.Max
+	case pb.DoubleRangeFilter_MAX:
+		return v >= f.Min && v < f.Max
+	case pb.DoubleRangeFilter_BOTH:
+		return v > f.Min && v < f.Max
+	}
 	return true
 }
+
+func stringEqualsIn(f *pb.StringEqualsFilter, s *pb.SearchFields) bool {
+	v, ok := s.StringArgs[f.StringArg]
+	return ok && f.Value == v
+}
+
+func tagPresentIn(f *pb.TagPresentFilter, s *pb.SearchFields) bool {
+	for _, v := range s.Tags {
+		if v == f.Tag {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterCount is the number of candidates remaining after a single filter of a
+// pool has been applied.
+type FilterCount struct {
+	Filter string
+	Count  int
+}
+
+// Diagnostics records how many candidates survive each filter of a
+// PoolFilter, in evaluation order.  It is used to find which filter of a pool
+// excludes all candidates.  Diagnostics is not safe for concurrent use.
+type Diagnostics struct {
+	pf     *PoolFilter
+	total  int
+	counts []int
+}
+
+// NewDiagnostics returns an empty Diagnostics for the PoolFilter.
+func (pf *PoolFilter) NewDiagnostics() *Diagnostics {
+	return &Diagnostics{
+		pf:     pf,
+		counts: make([]int, pf.filterCount()),
+	}
+}
+
+// In behaves as PoolFilter.In, additionally counting the entity against every
+// filter it passed.
+func (d *Diagnostics) In(entity filteredEntity) bool {
+	d.total++
+	n := d.pf.passed(entity)
+	for i := 0; i < n; i++ {
+		d.counts[i]++
+	}
+	return n == len(d.counts)
+}
+
+// Total returns the number of candidates considered before any filter.
+func (d *Diagnostics) Total() int {
+	return d.total
+}
+
+// Counts returns the number of candidates remaining after each filter.
+func (d *Diagnostics) Counts() []FilterCount {
+	names := d.pf.filterNames()
+	result := make([]FilterCount, len(names))
+	for i, name := range names {
+		result[i] = FilterCount{
+			Filter: name,
+			Count:  d.counts[i],
+		}
+	}
+	return result
+}
@@ -115,3 +115,111 @@ func TestValidPoolFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestDiagnostics(t *testing.T) {
+	pool := &pb.Pool{
+		DoubleRangeFilters: []*pb.DoubleRangeFilter{
+			{DoubleArg: "mmr", Min: 10, Max: 20},
+		},
+		StringEqualsFilters: []*pb.StringEqualsFilter{
+			{StringArg: "region", Value: "europe-west1"},
+		},
+		TagPresentFilters: []*pb.TagPresentFilter{
+			{Tag: "crossplay"},
+		},
+	}
+
+	tickets := []*pb.Ticket{
+		{
+			Id: "out-of-range",
+			SearchFields: &pb.SearchFields{
+				DoubleArgs: map[string]float64{"mmr": 5},
+				StringArgs: map[string]string{"region": "europe-west1"},
+				Tags:       []string{"crossplay"},
+			},
+		},
+		{
+			Id: "wrong-region",
+			SearchFields: &pb.SearchFields{
+				DoubleArgs: map[string]float64{"mmr": 15},
+				StringArgs: map[string]string{"region": "asia-east1"},
+				Tags:       []string{"crossplay"},
+			},
+		},
+		{
+			Id: "no-tag-1",
+			SearchFields: &pb.SearchFields{
+				DoubleArgs: map[string]float64{"mmr": 12},
+				StringArgs: map[string]string{"region": "europe-west1"},
+			},
+		},
+		{
+			Id: "no-tag-2",
+			SearchFields: &pb.SearchFields{
+				DoubleArgs: map[string]float64{"mmr": 18},
+				StringArgs: map[string]string{"region": "europe-west1"},
+				Tags:       []string{"console"},
+			},
+		},
+	}
+
+	pf, err := NewPoolFilter(pool)
+	require.NoError(t, err)
+
+	d := pf.NewDiagnostics()
+	for _, ticket := range tickets {
+		require.Equal(t, pf.In(ticket), d.In(ticket))
+	}
+
+	require.Equal(t, 4, d.Total())
+	require.Equal(t, []FilterCount{
+		{Filter: "double_range:mmr", Count: 3},
+		{Filter: "string_equals:region", Count: 2},
+		{Filter: "tag_present:crossplay", Count: 0},
+	}, d.Counts())
+}
+
+func TestOptionalFilters(t *testing.T) {
+	pool := &pb.Pool{
+		StringEqualsFilters: []*pb.StringEqualsFilter{
+			{StringArg: "region", Value: "europe-west1"},
+			{StringArg: "platform", Value: "pc", Optional: true},
+		},
+		TagPresentFilters: []*pb.TagPresentFilter{
+			{Tag: "crossplay", Optional: true},
+		},
+	}
+	pf, err := NewPoolFilter(pool)
+	require.NoError(t, err)
+
+	ticket := func(region, platform string, tags ...string) *pb.Ticket {
+		return &pb.Ticket{SearchFields: &pb.SearchFields{
+			StringArgs: map[string]string{"region": region, "platform": platform},
+			Tags:       tags,
+		}}
+	}
+
+	for _, tt := range []struct {
+		ticket   *pb.Ticket
+		in       bool
+		affinity int
+	}{
+		{ticket("europe-west1", "pc", "crossplay"), true, 2},
+		{ticket("europe-west1", "console", "crossplay"), true, 1},
+		{ticket("europe-west1", "console"), true, 0},
+		{ticket("asia-east1", "pc", "crossplay"), false, 2},
+	} {
+		require.Equal(t, tt.in, pf.In(tt.ticket))
+		require.Equal(t, tt.affinity, pf.Affinity(tt.ticket))
+	}
+
+	// Optional filters don't exclude any candidates.
+	d := pf.NewDiagnostics()
+	d.In(ticket("europe-west1", "console"))
+	d.In(ticket("asia-east1", "pc"))
+	require.Equal(t, []FilterCount{
+		{Filter: "string_equals:region", Count: 1},
+		{Filter: "string_equals:platform", Count: 1},
+		{Filter: "tag_present:crossplay", Count: 1},
+	}, d.Counts())
+}
@@ -150,6 +150,9 @@ func IncludedTestCases() []TestCase {
 			nil,
 			&pb.Pool{},
 		},
+
+		optionalFilters(true, true),
+		optionalFilters(true, false),
 	}
 }
 
@@ -373,6 +376,9 @@ func ExcludedTestCases() []TestCase {
 		multipleFilters(false, true, true),
 		multipleFilters(true, false, true),
 		multipleFilters(true, true, false),
+
+		optionalFilters(false, true),
+		optionalFilters(false, false),
 	}
 }
 
@@ -455,3 +461,44 @@ func timestamp(t time.Time) *tspb.Timestamp {
 
 	return tsp
 }
+
+// optionalFilters returns a pool requiring a region and playlist, and
+// optionally a platform, tag and skill, with search fields meeting the
+// required filters and the optional filters as specified.
+func optionalFilters(required, optional bool) TestCase {
+	sf := &pb.SearchFields{
+		StringArgs: map[string]string{
+			"region":   "europe",
+			"playlist": "ranked",
+			"platform": "pc",
+		},
+		DoubleArgs: map[string]float64{"skill": 5},
+		Tags:       []string{"voice"},
+	}
+	if !required {
+		sf.StringArgs["playlist"] = "casual"
+	}
+	if !optional {
+		sf.StringArgs["platform"] = "console"
+		sf.DoubleArgs = nil
+		sf.Tags = nil
+	}
+
+	return TestCase{
+		Name:         fmt.Sprintf("optionalFilters_%t_%t", required, optional),
+		SearchFields: sf,
+		Pool: &pb.Pool{
+			StringEqualsFilters: []*pb.StringEqualsFilter{
+				{StringArg: "region", Value: "europe"},
+				{StringArg: "playlist", Value: "ranked"},
+				{StringArg: "platform", Value: "pc", Optional: true},
+			},
+			DoubleRangeFilters: []*pb.DoubleRangeFilter{
+				{DoubleArg: "skill", Min: 0, Max: 10, Optional: true},
+			},
+			TagPresentFilters: []*pb.TagPresentFilter{
+				{Tag: "voice", Optional: true},
+			},
+		},
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Authorizer decides whether a gRPC call may proceed.  fullMethod is the full
+// gRPC method name, such as "/openmatch.FrontendService/DeleteTicket", and req
+// is the request message.  Streaming calls are authorized once for every
+// message received from the client.  Returning an error rejects the call;
+// errors without a gRPC status are returned as PermissionDenied.
+type Authorizer func(ctx context.Context, fullMethod string, req interface{}) error
+
+// SetAuthorizer configures the server to check every gRPC call with a.  All
+// calls are allowed when no Authorizer is set.
+func (p *ServerParams) SetAuthorizer(a Authorizer) *ServerParams {
+	p.authorizer = a
+	return p
+}
+
+func (a Authorizer) authorize(ctx context.Context, fullMethod string, req interface{}) error {
+	err := a(ctx, fullMethod, req)
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.PermissionDenied, err.Error())
+}
+
+func (a Authorizer) unaryServerInterceptor(ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.authorize(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a Authorizer) streamServerInterceptor(srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	return handler(srv, &authorizedServerStream{
+		ServerStream: stream,
+		authorizer:   a,
+		fullMethod:   info.FullMethod,
+	})
+}
+
+// authorizedServerStream authorizes each message as it is received.
+type authorizedServerStream struct {
+	grpc.ServerStream
+	authorizer Authorizer
+	fullMethod string
+}
+
+func (s *authorizedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.authorizer.authorize(s.Context(), s.fullMethod, m)
+}
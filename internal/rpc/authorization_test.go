@@ -0,0 +1,78 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	shellTesting "open-match.dev/open-match/internal/testing"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestAuthorizerDeniesMethod(t *testing.T) {
+	grpcL := MustListen()
+	httpL := MustListen()
+
+	params := NewServerParamsFromListeners(grpcL, httpL)
+	params.AddHandleFunc(func(s *grpc.Server) {
+		pb.RegisterFrontendServiceServer(s, &shellTesting.FakeFrontend{})
+	}, pb.RegisterFrontendServiceHandlerFromEndpoint)
+	params.SetAuthorizer(func(ctx context.Context, fullMethod string, req interface{}) error {
+		switch fullMethod {
+		case "/openmatch.FrontendService/DeleteTicket":
+			return errors.New("tickets may not be deleted")
+		case "/openmatch.FrontendService/WatchAssignments":
+			if req.(*pb.WatchAssignmentsRequest).TicketId == "other" {
+				return status.Error(codes.PermissionDenied, "not your ticket")
+			}
+		}
+		return nil
+	})
+	s := &Server{}
+	defer s.Stop()
+	require.NoError(t, s.Start(params))
+
+	conn, err := grpc.Dial(fmt.Sprintf(":%s", MustGetPortNumber(grpcL)), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	fe := pb.NewFrontendServiceClient(conn)
+	ctx := utilTesting.NewContext(t)
+
+	_, err = fe.CreateTicket(ctx, &pb.CreateTicketRequest{})
+	require.NoError(t, err)
+
+	_, err = fe.DeleteTicket(ctx, &pb.DeleteTicketRequest{TicketId: "1"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "tickets may not be deleted")
+
+	stream, err := fe.WatchAssignments(ctx, &pb.WatchAssignmentsRequest{TicketId: "other"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Allowed calls reach the handler, which the fake leaves unimplemented.
+	stream, err = fe.WatchAssignments(ctx, &pb.WatchAssignmentsRequest{TicketId: "mine"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -19,6 +19,7 @@ import (
 	"sync"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"open-match.dev/open-match/internal/config"
 )
 
@@ -37,22 +38,47 @@ type cachedHTTPClient struct {
 	baseURL string
 }
 
-// GetGRPC gets a GRPC client with the address.
+// GetGRPC gets a GRPC client with the address.  The client is shared by all
+// callers of the address, so calls reuse its connection.  A client which was
+// shut down or is failing to connect is replaced by a freshly dialed one.
 func (cc *ClientCache) GetGRPC(address string) (*grpc.ClientConn, error) {
 	val, exists := cc.cache.Load(address)
 	c, ok := val.(cachedGRPCClient)
-	if !ok || !exists {
-		conn, err := GRPCClientFromEndpoint(cc.cfg, address)
-		if err != nil {
-			return nil, err
-		}
-		c = cachedGRPCClient{
-			client: conn,
-		}
+	if ok && exists && healthy(c.client) {
+		return c.client, nil
+	}
+	if ok && exists {
+		cc.cache.Delete(address)
+		c.client.Close()
+	}
+
+	conn, err := GRPCClientFromEndpoint(cc.cfg, address)
+	if err != nil {
+		return nil, err
+	}
+	c = cachedGRPCClient{client: conn}
+	val, loaded := cc.cache.LoadOrStore(address, c)
+	if other, ok := val.(cachedGRPCClient); loaded && ok {
+		// Another caller dialed the address at the same time.
+		conn.Close()
+		return other.client, nil
+	}
+	if loaded {
 		cc.cache.Store(address, c)
 	}
 
-	return c.client, nil
+	return conn, nil
+}
+
+// healthy returns false if the connection of the client is shut down, or in
+// transient failure.
+func healthy(conn *grpc.ClientConn) bool {
+	switch conn.GetState() {
+	case connectivity.Shutdown, connectivity.TransientFailure:
+		return false
+	default:
+		return true
+	}
 }
 
 // GetHTTP gets a HTTP client with the address.
@@ -16,6 +16,7 @@ package rpc
 
 import (
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
@@ -40,6 +41,39 @@ func TestGetGRPC(t *testing.T) {
 	require.EqualValues(client, cachedClient)
 }
 
+func TestGetGRPCReplacesShutdownClient(t *testing.T) {
+	require := require.New(t)
+
+	cc := NewClientCache(viper.New())
+	client, err := cc.GetGRPC(fakeGRPCAddress)
+	require.Nil(err)
+	require.Nil(client.Close())
+
+	replaced, err := cc.GetGRPC(fakeGRPCAddress)
+	require.Nil(err)
+	require.NotEqual(client, replaced)
+
+	cachedClient, err := cc.GetGRPC(fakeGRPCAddress)
+	require.Nil(err)
+	require.EqualValues(replaced, cachedClient)
+}
+
+func TestKeepaliveFromConfig(t *testing.T) {
+	require := require.New(t)
+
+	cfg := viper.New()
+	require.Nil(keepaliveFromConfig(cfg))
+
+	cfg.Set(configNameClientKeepaliveTime, "5s")
+	ka := keepaliveFromConfig(cfg)
+	require.Equal(5*time.Second, ka.Time)
+	require.Equal(defaultClientKeepalive.Timeout, ka.Timeout)
+	require.True(ka.PermitWithoutStream)
+
+	cfg.Set(configNameClientKeepaliveTimeout, "2s")
+	require.Equal(2*time.Second, keepaliveFromConfig(cfg).Timeout)
+}
+
 func TestGetHTTP(t *testing.T) {
 	require := require.New(t)
 
@@ -49,8 +49,27 @@ const (
 	ConfigNameEnableRPCLogging = "logging.rpc"
 	// configNameClientTrustedCertificatePath is the same as the root CA cert that the server trusts.
 	configNameClientTrustedCertificatePath = configNameServerRootCertificatePath
+	// configNameClientCertificateFile is the certificate presented to servers which require mutual TLS.
+	// Components use the same certificate for serving and for authenticating as a client.
+	configNameClientCertificateFile = configNameServerPublicCertificateFile
+	// configNameClientPrivateKeyFile is the private key of the client certificate.
+	configNameClientPrivateKeyFile = configNameServerPrivateKeyFile
+	// configNameClientKeepaliveTime is how long a connection to a match function or evaluator
+	// endpoint is idle before the client pings it.
+	configNameClientKeepaliveTime = "api.client.keepaliveTime"
+	// configNameClientKeepaliveTimeout is how long the client waits for a ping to be
+	// acknowledged before closing the connection.
+	configNameClientKeepaliveTimeout = "api.client.keepaliveTimeout"
 )
 
+// defaultClientKeepalive keeps idle connections open across load balancers and NATs, and
+// notices dead ones.
+var defaultClientKeepalive = keepalive.ClientParameters{
+	Time:                20 * time.Second,
+	Timeout:             10 * time.Second,
+	PermitWithoutStream: true,
+}
+
 var (
 	clientLogger = logrus.WithFields(logrus.Fields{
 		"app":       "openmatch",
@@ -60,11 +79,20 @@ var (
 
 // ClientParams contains the connection parameters to connect to an Open Match service.
 type ClientParams struct {
-	Address                 string
-	TrustedCertificate      []byte
+	Address            string
+	TrustedCertificate []byte
+	// ClientCertificate and ClientPrivateKey are presented to the server when
+	// it requires mutual TLS.  Both are in PEM format and optional.
+	ClientCertificate       []byte
+	ClientPrivateKey        []byte
 	EnableRPCLogging        bool
 	EnableRPCPayloadLogging bool
 	EnableMetrics           bool
+	// Retry, when set, retries idempotent unary calls failing with transient
+	// errors.  See UnaryClientRetryInterceptor.
+	Retry *RetryParams
+	// Keepalive, when set, replaces the default keep-alive pings.
+	Keepalive *keepalive.ClientParameters
 }
 
 // nolint:gochecknoinits
@@ -80,77 +108,139 @@ func (p *ClientParams) usingTLS() bool {
 	return len(p.TrustedCertificate) > 0
 }
 
-// GRPCClientFromConfig creates a gRPC client connection from a configuration.
-func GRPCClientFromConfig(cfg config.View, prefix string) (*grpc.ClientConn, error) {
-	clientParams := &ClientParams{
-		Address:                 toAddress(cfg.GetString(prefix+".hostname"), cfg.GetInt(prefix+".grpcport")),
-		EnableRPCLogging:        cfg.GetBool(ConfigNameEnableRPCLogging),
-		EnableRPCPayloadLogging: logging.IsDebugEnabled(cfg),
-		EnableMetrics:           cfg.GetBool(telemetry.ConfigNameEnableMetrics),
+// readClientTLSFromConfig fills in the trusted certificate used to verify the
+// server, and the certificate presented for mutual TLS, if they are
+// configured.
+func readClientTLSFromConfig(cfg config.View, params *ClientParams) error {
+	trustedCertFile := cfg.GetString(configNameClientTrustedCertificatePath)
+	if trustedCertFile == "" {
+		return nil
+	}
+
+	_, err := os.Stat(trustedCertFile)
+	if err != nil {
+		clientLogger.WithError(err).Error("trusted certificate file may not exists.")
+		return err
+	}
+
+	params.TrustedCertificate, err = ioutil.ReadFile(trustedCertFile)
+	if err != nil {
+		clientLogger.WithError(err).Error("failed to read tls trusted certificate to establish a secure grpc client.")
+		return err
 	}
 
-	// If TLS support is enabled in the config, fill in the trusted certificates for decrpting server certificate.
-	if cfg.GetString(configNameClientTrustedCertificatePath) != "" {
-		_, err := os.Stat(cfg.GetString(configNameClientTrustedCertificatePath))
+	certFile := cfg.GetString(configNameClientCertificateFile)
+	privateKeyFile := cfg.GetString(configNameClientPrivateKeyFile)
+	if len(certFile) > 0 && len(privateKeyFile) > 0 {
+		params.ClientCertificate, err = ioutil.ReadFile(certFile)
 		if err != nil {
-			clientLogger.WithError(err).Error("trusted certificate file may not exists.")
-			return nil, err
+			clientLogger.WithError(err).Error("failed to read tls client certificate to establish a mutual tls grpc client.")
+			return err
 		}
 
-		clientParams.TrustedCertificate, err = ioutil.ReadFile(cfg.GetString(configNameClientTrustedCertificatePath))
+		params.ClientPrivateKey, err = ioutil.ReadFile(privateKeyFile)
 		if err != nil {
-			clientLogger.WithError(err).Error("failed to read tls trusted certificate to establish a secure grpc client.")
-			return nil, err
+			clientLogger.WithError(err).Error("failed to read tls client private key to establish a mutual tls grpc client.")
+			return err
 		}
 	}
 
-	return GRPCClientFromParams(clientParams)
+	return nil
 }
 
-// GRPCClientFromEndpoint creates a gRPC client connection from endpoint.
-func GRPCClientFromEndpoint(cfg config.View, address string) (*grpc.ClientConn, error) {
-	// TODO: investigate if it is possible to keep a cache of the certpool and transport credentials
-	grpcOptions := newGRPCDialOptions(cfg.GetBool(telemetry.ConfigNameEnableMetrics), cfg.GetBool(ConfigNameEnableRPCLogging), logging.IsDebugEnabled(cfg))
+// clientTLSConfig returns the TLS configuration which trusts the server
+// certificate, and presents the client certificate if one is set.
+func clientTLSConfig(params *ClientParams) (*tls.Config, error) {
+	pool, err := trustedCertificateFromFileData(params.TrustedCertificate)
+	if err != nil {
+		return nil, err
+	}
 
-	if cfg.GetString(configNameClientTrustedCertificatePath) != "" {
-		_, err := os.Stat(cfg.GetString(configNameClientTrustedCertificatePath))
-		if err != nil {
-			clientLogger.WithError(err).Error("trusted certificate file may not exists.")
-			return nil, err
-		}
+	tlsConfig := &tls.Config{
+		RootCAs: pool,
+	}
 
-		trustedCertificate, err := ioutil.ReadFile(cfg.GetString(configNameClientTrustedCertificatePath))
+	if len(params.ClientCertificate) > 0 {
+		cert, err := certificateFromFileData(params.ClientCertificate, params.ClientPrivateKey)
 		if err != nil {
-			clientLogger.WithError(err).Error("failed to read tls trusted certificate to establish a secure grpc client.")
 			return nil, err
 		}
+		tlsConfig.Certificates = []tls.Certificate{*cert}
+	}
 
-		pool, err := trustedCertificateFromFileData(trustedCertificate)
-		if err != nil {
-			clientLogger.WithError(err).Error("failed to get transport credentials from file.")
-			return nil, errors.WithStack(err)
-		}
-		tc := credentials.NewClientTLSFromCert(pool, "")
+	return tlsConfig, nil
+}
 
-		grpcOptions = append(grpcOptions, grpc.WithTransportCredentials(tc))
-	} else {
-		grpcOptions = append(grpcOptions, grpc.WithInsecure())
+// GRPCClientFromConfig creates a gRPC client connection from a configuration.
+func GRPCClientFromConfig(cfg config.View, prefix string) (*grpc.ClientConn, error) {
+	clientParams, err := clientParamsFromConfig(cfg, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return GRPCClientFromParams(clientParams)
+}
+
+func clientParamsFromConfig(cfg config.View, prefix string) (*ClientParams, error) {
+	clientParams := &ClientParams{
+		Address:                 toAddress(cfg.GetString(prefix+".hostname"), cfg.GetInt(prefix+".grpcport")),
+		EnableRPCLogging:        cfg.GetBool(ConfigNameEnableRPCLogging),
+		EnableRPCPayloadLogging: logging.IsDebugEnabled(cfg),
+		EnableMetrics:           cfg.GetBool(telemetry.ConfigNameEnableMetrics),
+	}
+
+	if err := readClientTLSFromConfig(cfg, clientParams); err != nil {
+		return nil, err
+	}
+
+	return clientParams, nil
+}
+
+// GRPCClientFromEndpoint creates a gRPC client connection from endpoint.
+func GRPCClientFromEndpoint(cfg config.View, address string) (*grpc.ClientConn, error) {
+	// TODO: investigate if it is possible to keep a cache of the certpool and transport credentials
+	clientParams := &ClientParams{
+		Address:                 address,
+		EnableRPCLogging:        cfg.GetBool(ConfigNameEnableRPCLogging),
+		EnableRPCPayloadLogging: logging.IsDebugEnabled(cfg),
+		EnableMetrics:           cfg.GetBool(telemetry.ConfigNameEnableMetrics),
+		Keepalive:               keepaliveFromConfig(cfg),
 	}
 
-	return grpc.Dial(address, grpcOptions...)
+	if err := readClientTLSFromConfig(cfg, clientParams); err != nil {
+		return nil, err
+	}
+
+	return GRPCClientFromParams(clientParams)
+}
+
+// keepaliveFromConfig returns the keep-alive pings configured for endpoint clients, or nil
+// if neither api.client.keepaliveTime nor api.client.keepaliveTimeout is set.
+func keepaliveFromConfig(cfg config.View) *keepalive.ClientParameters {
+	if !cfg.IsSet(configNameClientKeepaliveTime) && !cfg.IsSet(configNameClientKeepaliveTimeout) {
+		return nil
+	}
+	ka := defaultClientKeepalive
+	if cfg.IsSet(configNameClientKeepaliveTime) {
+		ka.Time = cfg.GetDuration(configNameClientKeepaliveTime)
+	}
+	if cfg.IsSet(configNameClientKeepaliveTimeout) {
+		ka.Timeout = cfg.GetDuration(configNameClientKeepaliveTimeout)
+	}
+	return &ka
 }
 
 // GRPCClientFromParams creates a gRPC client connection from the parameters.
 func GRPCClientFromParams(params *ClientParams) (*grpc.ClientConn, error) {
-	grpcOptions := newGRPCDialOptions(params.EnableMetrics, params.EnableRPCLogging, params.EnableRPCPayloadLogging)
+	grpcOptions := newGRPCDialOptions(params.EnableMetrics, params.EnableRPCLogging, params.EnableRPCPayloadLogging, params.Retry, params.Keepalive)
 
 	if params.usingTLS() {
-		trustedCertPool, err := trustedCertificateFromFileData(params.TrustedCertificate)
+		tlsConfig, err := clientTLSConfig(params)
 		if err != nil {
 			clientLogger.WithError(err).Error("failed to get transport credentials from file.")
 			return nil, errors.WithStack(err)
 		}
-		grpcOptions = append(grpcOptions, grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(trustedCertPool, "")))
+		grpcOptions = append(grpcOptions, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
 	} else {
 		grpcOptions = append(grpcOptions, grpc.WithInsecure())
 	}
@@ -167,19 +257,8 @@ func HTTPClientFromConfig(cfg config.View, prefix string) (*http.Client, string,
 		EnableMetrics:           cfg.GetBool(telemetry.ConfigNameEnableMetrics),
 	}
 
-	// If TLS support is enabled in the config, fill in the trusted certificates for decrpting server certificate.
-	if cfg.GetString(configNameClientTrustedCertificatePath) != "" {
-		_, err := os.Stat(cfg.GetString(configNameClientTrustedCertificatePath))
-		if err != nil {
-			clientLogger.WithError(err).Error("trusted certificate file may not exists.")
-			return nil, "", err
-		}
-
-		clientParams.TrustedCertificate, err = ioutil.ReadFile(cfg.GetString(configNameClientTrustedCertificatePath))
-		if err != nil {
-			clientLogger.WithError(err).Error("failed to read tls trusted certificate to establish a secure grpc client.")
-			return nil, "", err
-		}
+	if err := readClientTLSFromConfig(cfg, clientParams); err != nil {
+		return nil, "", err
 	}
 
 	return HTTPClientFromParams(clientParams)
@@ -211,19 +290,8 @@ func HTTPClientFromEndpoint(cfg config.View, address string) (*http.Client, stri
 		EnableRPCPayloadLogging: logging.IsDebugEnabled(cfg),
 		EnableMetrics:           cfg.GetBool(telemetry.ConfigNameEnableMetrics),
 	}
-	if cfg.GetString(configNameClientTrustedCertificatePath) != "" {
-		_, err := os.Stat(cfg.GetString(configNameClientTrustedCertificatePath))
-		if err != nil {
-			clientLogger.WithError(err).Error("trusted certificate file may not exists.")
-			return nil, "", err
-		}
-
-		trustedCertificate, err := ioutil.ReadFile(cfg.GetString(configNameClientTrustedCertificatePath))
-		if err != nil {
-			clientLogger.WithError(err).Error("failed to read tls trusted certificate to establish a secure grpc client.")
-			return nil, "", err
-		}
-		params.TrustedCertificate = trustedCertificate
+	if err := readClientTLSFromConfig(cfg, params); err != nil {
+		return nil, "", err
 	}
 	return HTTPClientFromParams(params)
 }
@@ -242,17 +310,15 @@ func HTTPClientFromParams(params *ClientParams) (*http.Client, string, error) {
 			return nil, "", err
 		}
 
-		pool, err := trustedCertificateFromFileData(params.TrustedCertificate)
+		tlsConfig, err := clientTLSConfig(params)
 		if err != nil {
 			clientLogger.WithError(err).Error("failed to get cert pool from file.")
 			return nil, "", err
 		}
+		tlsConfig.ServerName = params.Address
 
 		httpClient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-				ServerName: params.Address,
-				RootCAs:    pool,
-			},
+			TLSClientConfig: tlsConfig,
 		}
 	} else {
 		var err error
@@ -283,13 +349,20 @@ func HTTPClientFromParams(params *ClientParams) (*http.Client, string, error) {
 	return httpClient, baseURL, nil
 }
 
-func newGRPCDialOptions(enableMetrics bool, enableRPCLogging bool, enableRPCPayloadLogging bool) []grpc.DialOption {
+func newGRPCDialOptions(enableMetrics bool, enableRPCLogging bool, enableRPCPayloadLogging bool, retry *RetryParams, ka *keepalive.ClientParameters) []grpc.DialOption {
+	if ka == nil {
+		ka = &defaultClientKeepalive
+	}
 	si := []grpc.StreamClientInterceptor{
 		grpc_tracing.StreamClientInterceptor(),
 	}
 	ui := []grpc.UnaryClientInterceptor{
 		grpc_tracing.UnaryClientInterceptor(),
 	}
+	if retry != nil {
+		// Retry outside of logging so that every attempt is logged.
+		ui = append(ui, UnaryClientRetryInterceptor(retry))
+	}
 	if enableRPCLogging {
 		grpcLogger := logrus.WithFields(logrus.Fields{
 			"app":       "openmatch",
@@ -311,11 +384,7 @@ func newGRPCDialOptions(enableMetrics bool, enableRPCLogging bool, enableRPCPayl
 		grpc.WithStreamInterceptor(grpc_middleware.ChainStreamClient(si...)),
 		grpc.WithUnaryInterceptor(grpc_middleware.ChainUnaryClient(ui...)),
 		grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"round_robin"}`),
-		grpc.WithKeepaliveParams(keepalive.ClientParameters{
-			Time:                20 * time.Second,
-			Timeout:             10 * time.Second,
-			PermitWithoutStream: true,
-		}),
+		grpc.WithKeepaliveParams(*ka),
 	}
 	if enableMetrics {
 		opts = append(opts, grpc.WithStatsHandler(new(ocgrpc.ClientHandler)))
@@ -251,3 +251,61 @@ func removeTempFile(t *testing.T, paths ...string) {
 		}
 	}
 }
+
+func TestMutualTLSGRPCFromConfig(t *testing.T) {
+	require := require.New(t)
+
+	grpcL := MustListen()
+	httpL := MustListen()
+	rpcParams := NewServerParamsFromListeners(grpcL, httpL)
+
+	hostnames := []string{
+		fmt.Sprintf("localhost:%s", MustGetPortNumber(grpcL)),
+		fmt.Sprintf("localhost:%s", MustGetPortNumber(httpL)),
+	}
+	rootPub, rootPriv, err := certgenTesting.CreateRootCertificateAndPrivateKeyForTesting(hostnames)
+	require.Nil(err)
+	serverPub, serverPriv, err := certgenTesting.CreateDerivedCertificateAndPrivateKeyForTesting(rootPub, rootPriv, hostnames)
+	require.Nil(err)
+	clientPub, clientPriv, err := certgenTesting.CreateDerivedCertificateAndPrivateKeyForTesting(rootPub, rootPriv, []string{"client"})
+	require.Nil(err)
+
+	rpcParams.SetTLSConfiguration(rootPub, serverPub, serverPriv)
+	rpcParams.SetRequireClientCertificate(true)
+
+	rootFile := writeTempFile(t, require, rootPub)
+	clientPubFile := writeTempFile(t, require, clientPub)
+	clientPrivFile := writeTempFile(t, require, clientPriv)
+
+	cfg := viper.New()
+	cfg.Set("test.hostname", "localhost")
+	cfg.Set("test.grpcport", MustGetPortNumber(grpcL))
+	cfg.Set("test.httpport", MustGetPortNumber(httpL))
+	cfg.Set(configNameClientTrustedCertificatePath, rootFile)
+	cfg.Set(configNameClientCertificateFile, clientPubFile)
+	cfg.Set(configNameClientPrivateKeyFile, clientPrivFile)
+
+	runSuccessGrpcClientTests(t, require, cfg, rpcParams)
+
+	// A client which only trusts the server, without presenting a certificate,
+	// must be rejected.
+	noCertCfg := viper.New()
+	noCertCfg.Set(configNameClientTrustedCertificatePath, rootFile)
+	conn, err := GRPCClientFromEndpoint(noCertCfg, hostnames[0])
+	require.Nil(err)
+	defer conn.Close()
+
+	_, err = pb.NewFrontendServiceClient(conn).CreateTicket(utilTesting.NewContext(t), &pb.CreateTicketRequest{})
+	require.Equal(codes.Unavailable, status.Code(err))
+}
+
+func writeTempFile(t *testing.T, require *require.Assertions, data []byte) string {
+	f, err := ioutil.TempFile("", "tls*")
+	require.Nil(err)
+	require.Nil(f.Close())
+	t.Cleanup(func() { removeTempFile(t, f.Name()) })
+
+	err = ioutil.WriteFile(f.Name(), data, 0400)
+	require.Nil(err)
+	return f.Name()
+}
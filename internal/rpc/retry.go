@@ -0,0 +1,178 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+)
+
+// defaultRetryMaxElapsedTime caps the time spent retrying a call when
+// backoff.maxElapsedTime is not configured.
+const defaultRetryMaxElapsedTime = 30 * time.Second
+
+// idempotentMethods are the unary methods which UnaryClientRetryInterceptor
+// retries without the caller opting in, as attempting them again has no
+// further effect.
+var idempotentMethods = map[string]struct{}{
+	"/openmatch.FrontendService/GetTicket":              {},
+	"/openmatch.FrontendService/GetTicketsByPlayer":     {},
+	"/openmatch.FrontendService/GetBackfill":            {},
+	"/openmatch.FrontendService/DeleteTicket":           {},
+	"/openmatch.FrontendService/DeleteTickets":          {},
+	"/openmatch.FrontendService/DeleteBackfill":         {},
+	"/openmatch.BackendService/ReleaseTickets":          {},
+	"/openmatch.BackendService/ReleaseAllTickets":       {},
+	"/openmatch.BackendService/GetTicketPendingRelease": {},
+	"/openmatch.BackendService/PreviewMatches":          {},
+	"/openmatch.BackendService/Stats":                   {},
+}
+
+// RetryParams configures the exponential backoff used by retrying clients.
+type RetryParams struct {
+	InitialInterval     time.Duration
+	MaxInterval         time.Duration
+	Multiplier          float64
+	RandomizationFactor float64
+	// MaxElapsedTime caps the total time spent retrying a call.  It defaults
+	// to 30 seconds when not positive.
+	MaxElapsedTime time.Duration
+}
+
+// RetryParamsFromConfig reads the backoff settings shared by Open Match's
+// retryable calls.
+func RetryParamsFromConfig(cfg config.View) *RetryParams {
+	return &RetryParams{
+		InitialInterval:     cfg.GetDuration("backoff.initialInterval"),
+		MaxInterval:         cfg.GetDuration("backoff.maxInterval"),
+		Multiplier:          cfg.GetFloat64("backoff.multiplier"),
+		RandomizationFactor: cfg.GetFloat64("backoff.randFactor"),
+		MaxElapsedTime:      cfg.GetDuration("backoff.maxElapsedTime"),
+	}
+}
+
+// NewBackOff returns an exponential backoff with these parameters, which stops
+// once ctx is done.
+func (p *RetryParams) NewBackOff(ctx context.Context) backoff.BackOff {
+	return backoff.WithContext(p.newExponentialBackOff(), ctx)
+}
+
+func (p *RetryParams) newExponentialBackOff() *backoff.ExponentialBackOff {
+	b := backoff.NewExponentialBackOff()
+	if p.InitialInterval > 0 {
+		b.InitialInterval = p.InitialInterval
+	}
+	if p.MaxInterval > 0 {
+		b.MaxInterval = p.MaxInterval
+	}
+	if p.Multiplier > 0 {
+		b.Multiplier = p.Multiplier
+	}
+	b.RandomizationFactor = p.RandomizationFactor
+	b.MaxElapsedTime = p.MaxElapsedTime
+	if b.MaxElapsedTime <= 0 {
+		b.MaxElapsedTime = defaultRetryMaxElapsedTime
+	}
+	b.Reset()
+	return b
+}
+
+// isRetryable reports whether a failed call may succeed if attempted again.
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryCallOption marks a call as safe to retry.
+type retryCallOption struct {
+	grpc.EmptyCallOption
+}
+
+// WithRetry lets UnaryClientRetryInterceptor retry a call of a method which
+// isn't known to be idempotent, such as one whose request carries its own
+// idempotency key.
+func WithRetry() grpc.CallOption {
+	return retryCallOption{}
+}
+
+// isRetryAllowed reports whether the call may be attempted more than once.
+func isRetryAllowed(method string, opts []grpc.CallOption) bool {
+	if _, ok := idempotentMethods[method]; ok {
+		return true
+	}
+	for _, opt := range opts {
+		if _, ok := opt.(retryCallOption); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// UnaryClientRetryInterceptor retries unary calls failing with Unavailable or
+// DeadlineExceeded, backing off between attempts, until a call succeeds, fails
+// with another code, the backoff gives up, or the call's context is done.
+// Only the calls of idempotent Open Match methods, and those made WithRetry,
+// are retried, since a failed call may still have taken effect.  Streaming
+// calls are never retried.
+func UnaryClientRetryInterceptor(params *RetryParams) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !isRetryAllowed(method, opts) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		attempts := 0
+		operation := func() error {
+			attempts++
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err != nil && (!isRetryable(err) || ctx.Err() != nil) {
+				return backoff.Permanent(err)
+			}
+			return err
+		}
+
+		err := backoff.Retry(operation, params.NewBackOff(ctx))
+		if err != nil && attempts > 1 {
+			clientLogger.WithFields(logrus.Fields{
+				"method":   method,
+				"attempts": attempts,
+			}).WithError(err).Debug("call failed after retries")
+		}
+		return err
+	}
+}
+
+// GRPCRetryingClientFromConfig creates a gRPC client connection from a
+// configuration which retries its unary calls on transient failures, using
+// the configured backoff settings.  See UnaryClientRetryInterceptor.
+func GRPCRetryingClientFromConfig(cfg config.View, prefix string) (*grpc.ClientConn, error) {
+	clientParams, err := clientParamsFromConfig(cfg, prefix)
+	if err != nil {
+		return nil, err
+	}
+	clientParams.Retry = RetryParamsFromConfig(cfg)
+
+	return GRPCClientFromParams(clientParams)
+}
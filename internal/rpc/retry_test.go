@@ -0,0 +1,180 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+var testRetryParams = &RetryParams{
+	InitialInterval: time.Millisecond,
+	MaxInterval:     5 * time.Millisecond,
+	Multiplier:      2,
+	MaxElapsedTime:  100 * time.Millisecond,
+}
+
+// flakyFrontend fails GetTicket and CreateTicket with the configured code
+// until it has been called failures times.
+type flakyFrontend struct {
+	pb.UnimplementedFrontendServiceServer
+	code     codes.Code
+	failures int
+	calls    int
+}
+
+func (f *flakyFrontend) GetTicket(ctx context.Context, req *pb.GetTicketRequest) (*pb.Ticket, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, status.Error(f.code, "flaky")
+	}
+	return &pb.Ticket{Id: req.TicketId}, nil
+}
+
+func (f *flakyFrontend) CreateTicket(ctx context.Context, req *pb.CreateTicketRequest) (*pb.Ticket, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, status.Error(f.code, "flaky")
+	}
+	return &pb.Ticket{Id: "1"}, nil
+}
+
+// startFlakyFrontend serves fe, and returns a client of it retrying with
+// testRetryParams.
+func startFlakyFrontend(t *testing.T, fe *flakyFrontend) pb.FrontendServiceClient {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	pb.RegisterFrontendServiceServer(s, fe)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := GRPCClientFromParams(&ClientParams{
+		Address: lis.Addr().String(),
+		Retry:   testRetryParams,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewFrontendServiceClient(conn)
+}
+
+func TestRetryingClient(t *testing.T) {
+	tests := []struct {
+		description string
+		code        codes.Code
+		failures    int
+		wantCode    codes.Code
+		wantCalls   func(*testing.T, int)
+	}{
+		{
+			description: "retried until success",
+			code:        codes.Unavailable,
+			failures:    2,
+			wantCode:    codes.OK,
+			wantCalls: func(t *testing.T, calls int) {
+				require.Equal(t, 3, calls)
+			},
+		},
+		{
+			description: "deadline exceeded is retried",
+			code:        codes.DeadlineExceeded,
+			failures:    1,
+			wantCode:    codes.OK,
+			wantCalls: func(t *testing.T, calls int) {
+				require.Equal(t, 2, calls)
+			},
+		},
+		{
+			description: "gives up once the backoff elapses",
+			code:        codes.Unavailable,
+			failures:    1 << 30,
+			wantCode:    codes.Unavailable,
+			wantCalls: func(t *testing.T, calls int) {
+				require.True(t, calls > 1)
+			},
+		},
+		{
+			description: "other errors are not retried",
+			code:        codes.InvalidArgument,
+			failures:    1,
+			wantCode:    codes.InvalidArgument,
+			wantCalls: func(t *testing.T, calls int) {
+				require.Equal(t, 1, calls)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			fe := &flakyFrontend{code: tt.code, failures: tt.failures}
+			client := startFlakyFrontend(t, fe)
+
+			ticket, err := client.GetTicket(context.Background(), &pb.GetTicketRequest{TicketId: "1"})
+			require.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				require.Equal(t, "1", ticket.Id)
+			}
+			tt.wantCalls(t, fe.calls)
+		})
+	}
+}
+
+func TestRetryStopsWithContext(t *testing.T) {
+	calls := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		cancel()
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+
+	err := UnaryClientRetryInterceptor(&RetryParams{InitialInterval: time.Hour})(ctx, "method", nil, nil, nil, invoker, WithRetry())
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, 1, calls)
+}
+
+func TestRetryOnlyIdempotentMethods(t *testing.T) {
+	fe := &flakyFrontend{code: codes.Unavailable, failures: 1}
+	client := startFlakyFrontend(t, fe)
+
+	// CreateTicket may have created a ticket before failing, so it isn't retried.
+	_, err := client.CreateTicket(context.Background(), &pb.CreateTicketRequest{})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, 1, fe.calls)
+
+	// Unless the caller opts in.
+	fe.calls = 0
+	ticket, err := client.CreateTicket(context.Background(), &pb.CreateTicketRequest{}, WithRetry())
+	require.NoError(t, err)
+	require.Equal(t, "1", ticket.Id)
+	require.Equal(t, 2, fe.calls)
+}
+
+func TestRetryMaxElapsedTimeDefault(t *testing.T) {
+	require.Equal(t, defaultRetryMaxElapsedTime, (&RetryParams{}).newExponentialBackOff().MaxElapsedTime)
+	require.Equal(t, time.Second, (&RetryParams{MaxElapsedTime: time.Second}).newExponentialBackOff().MaxElapsedTime)
+}
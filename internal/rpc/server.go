@@ -21,6 +21,7 @@ import (
 	"net"
 	"net/http"
 	"net/http/httputil"
+	"sync/atomic"
 	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -45,6 +46,10 @@ const (
 	configNameServerPublicCertificateFile = "api.tls.certificateFile"
 	configNameServerPrivateKeyFile        = "api.tls.privateKey"
 	configNameServerRootCertificatePath   = "api.tls.rootCertificateFile"
+	// configNameServerRequireClientCertificate enables mutual TLS, rejecting
+	// gRPC and HTTPS clients which don't present a certificate signed by the
+	// root CA.
+	configNameServerRequireClientCertificate = "api.tls.requireClientCertificate"
 )
 
 var (
@@ -52,8 +57,19 @@ var (
 		"app":       "openmatch",
 		"component": "server",
 	})
+
+	// serverRequests and serverErrors count the gRPC calls handled by this
+	// process, for the summary logged at shutdown.
+	serverRequests int64
+	serverErrors   int64
 )
 
+// ServerRequestCounts returns the number of gRPC calls handled by servers in
+// this process, and how many of them returned an error.
+func ServerRequestCounts() (requests int64, errors int64) {
+	return atomic.LoadInt64(&serverRequests), atomic.LoadInt64(&serverErrors)
+}
+
 // GrpcHandler binds gRPC services.
 type GrpcHandler func(*grpc.Server)
 
@@ -71,6 +87,9 @@ type ServerParams struct {
 
 	grpcListener      net.Listener
 	grpcProxyListener net.Listener
+	// If set, health checks are also served over plain HTTP on this listener,
+	// for probes which can't present a client certificate.
+	healthListener net.Listener
 
 	// Root CA public certificate in PEM format.
 	rootCaPublicCertificateFileData []byte
@@ -79,10 +98,15 @@ type ServerParams struct {
 	publicCertificateFileData []byte
 	// Private key in PEM format.
 	privateKeyFileData []byte
+	// If true, gRPC clients must present a certificate signed by the root CA.
+	requireClientCertificate bool
 
 	enableRPCLogging        bool
 	enableRPCPayloadLogging bool
 	enableMetrics           bool
+
+	// If set, every gRPC call must be allowed by the authorizer.
+	authorizer Authorizer
 }
 
 // NewServerParamsFromConfig returns server Params initialized from the configuration file.
@@ -132,6 +156,15 @@ func NewServerParamsFromConfig(cfg config.View, prefix string, listen func(netwo
 			}
 		}
 		p.SetTLSConfiguration(rootPublicCertData, publicCertData, privateKeyData)
+		p.SetRequireClientCertificate(cfg.GetBool(configNameServerRequireClientCertificate))
+
+		if p.requireClientCertificate && cfg.GetInt(prefix+".healthport") > 0 {
+			p.healthListener, err = listen("tcp", fmt.Sprintf(":%d", cfg.GetInt(prefix+".healthport")))
+			if err != nil {
+				p.invalidate()
+				return nil, errors.Wrap(err, "can't start listener for health checks")
+			}
+		}
 	}
 
 	p.enableMetrics = cfg.GetBool(telemetry.ConfigNameEnableMetrics)
@@ -163,6 +196,14 @@ func (p *ServerParams) SetTLSConfiguration(rootCaPublicCertificateFileData []byt
 	return p
 }
 
+// SetRequireClientCertificate configures the TLS server to require and verify
+// client certificates on gRPC and HTTPS connections.  It has no effect unless
+// TLS is configured.
+func (p *ServerParams) SetRequireClientCertificate(required bool) *ServerParams {
+	p.requireClientCertificate = required
+	return p
+}
+
 // usingTLS returns true if a certificate is set.
 func (p *ServerParams) usingTLS() bool {
 	return len(p.publicCertificateFileData) > 0
@@ -193,6 +234,11 @@ func (p *ServerParams) invalidate() {
 	if err := p.grpcProxyListener.Close(); err != nil {
 		serverLogger.Errorf("error closing grpc-proxy handler, %s", err)
 	}
+	if p.healthListener != nil {
+		if err := p.healthListener.Close(); err != nil {
+			serverLogger.Errorf("error closing health check handler, %s", err)
+		}
+	}
 }
 
 // Server hosts a gRPC and HTTP server.
@@ -210,7 +256,7 @@ type grpcServerWithProxy interface {
 // Start the gRPC+HTTP(s) REST server.
 func (s *Server) Start(p *ServerParams) error {
 	if p.usingTLS() {
-		s.serverWithProxy = newTLSServer(p.grpcListener, p.grpcProxyListener)
+		s.serverWithProxy = newTLSServer(p.grpcListener, p.grpcProxyListener, p.healthListener)
 	} else {
 		s.serverWithProxy = newInsecureServer(p.grpcListener, p.grpcProxyListener)
 	}
@@ -290,6 +336,11 @@ func newGRPCServerOptions(params *ServerParams) []grpc.ServerOption {
 
 	ui = append(ui, serverUnaryInterceptor)
 	si = append(si, serverStreamInterceptor)
+	// Authorize last, so that rejected calls are still logged and counted.
+	if params.authorizer != nil {
+		si = append(si, params.authorizer.streamServerInterceptor)
+		ui = append(ui, params.authorizer.unaryServerInterceptor)
+	}
 
 	if params.enableMetrics {
 		opts = append(opts, grpc.StatsHandler(&ocgrpc.ServerHandler{}))
@@ -310,8 +361,10 @@ func serverStreamInterceptor(srv interface{},
 	stream grpc.ServerStream,
 	info *grpc.StreamServerInfo,
 	handler grpc.StreamHandler) error {
+	atomic.AddInt64(&serverRequests, 1)
 	err := handler(srv, stream)
 	if err != nil {
+		atomic.AddInt64(&serverErrors, 1)
 		serverLogger.Error(err)
 	}
 	return err
@@ -321,8 +374,10 @@ func serverUnaryInterceptor(ctx context.Context,
 	req interface{},
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler) (interface{}, error) {
+	atomic.AddInt64(&serverRequests, 1)
 	h, err := handler(ctx, req)
 	if err != nil {
+		atomic.AddInt64(&serverErrors, 1)
 		serverLogger.Error(err)
 	}
 	return h, err
@@ -41,6 +41,9 @@ type tlsServer struct {
 	httpMux      *http.ServeMux
 	proxyMux     *runtime.ServeMux
 	httpServer   *http.Server
+
+	healthListener net.Listener
+	healthServer   *http.Server
 }
 
 func (s *tlsServer) start(params *ServerParams) error {
@@ -66,7 +69,14 @@ func (s *tlsServer) start(params *ServerParams) error {
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	creds := credentials.NewServerTLSFromCert(grpcTLSCertificate)
+	grpcTLSConfig := &tls.Config{
+		Certificates: []tls.Certificate{*grpcTLSCertificate},
+	}
+	if params.requireClientCertificate {
+		grpcTLSConfig.ClientCAs = rootCaCert
+		grpcTLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	creds := credentials.NewTLS(grpcTLSConfig)
 	serverOpts := newGRPCServerOptions(params)
 	serverOpts = append(serverOpts, grpc.Creds(creds))
 	s.grpcServer = grpc.NewServer(serverOpts...)
@@ -88,8 +98,17 @@ func (s *tlsServer) start(params *ServerParams) error {
 	// Bind gRPC handlers
 	ctx, cancel := context.WithCancel(context.Background())
 
-	httpsToGrpcProxyOptions := newGRPCDialOptions(params.enableMetrics, params.enableRPCLogging, params.enableRPCPayloadLogging)
-	httpsToGrpcProxyOptions = append(httpsToGrpcProxyOptions, grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(certPoolForGrpcEndpoint, "")))
+	httpsToGrpcProxyOptions := newGRPCDialOptions(params.enableMetrics, params.enableRPCLogging, params.enableRPCPayloadLogging, nil, nil)
+	proxyTLSConfig := &tls.Config{
+		RootCAs: certPoolForGrpcEndpoint,
+	}
+	if params.requireClientCertificate {
+		// The proxy authenticates to the gRPC endpoint with the server's own
+		// certificate.  It is only reached by HTTPS clients whose certificate
+		// was verified, see the TLSConfig of the HTTPS server below.
+		proxyTLSConfig.Certificates = []tls.Certificate{*grpcTLSCertificate}
+	}
+	httpsToGrpcProxyOptions = append(httpsToGrpcProxyOptions, grpc.WithTransportCredentials(credentials.NewTLS(proxyTLSConfig)))
 
 	for _, handlerFunc := range params.handlersForGrpcProxy {
 		if err = handlerFunc(ctx, s.proxyMux, grpcAddress, httpsToGrpcProxyOptions); err != nil {
@@ -107,11 +126,15 @@ func (s *tlsServer) start(params *ServerParams) error {
 		TLSConfig: &tls.Config{
 			Certificates: []tls.Certificate{*grpcTLSCertificate},
 			ClientCAs:    rootCaCert,
-			// Commented as open-match does not support mutual authentication yet
-			// ClientAuth:   tls.RequireAndVerifyClientCert,
-			NextProtos: []string{http2WithTLSVersionID}, // https://github.com/grpc-ecosystem/grpc-gateway/issues/220
+			NextProtos:   []string{http2WithTLSVersionID}, // https://github.com/grpc-ecosystem/grpc-gateway/issues/220
 		},
 	}
+	if params.requireClientCertificate {
+		// HTTPS clients are held to the same certificates as gRPC clients, as
+		// the proxy calls the gRPC endpoint on their behalf.  Health checks
+		// without a certificate can be served by the health listener.
+		s.httpServer.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
 	go func() {
 		tlsListener := tls.NewListener(s.httpListener, s.httpServer.TLSConfig)
 		serverLogger.Infof("Serving HTTPS: %s", s.httpListener.Addr().String())
@@ -122,19 +145,41 @@ func (s *tlsServer) start(params *ServerParams) error {
 		}
 	}()
 
+	if s.healthListener != nil {
+		healthMux := http.NewServeMux()
+		healthMux.Handle(telemetry.HealthCheckEndpoint, telemetry.NewHealthCheck(params.handlersForHealthCheck))
+		s.healthServer = &http.Server{
+			Addr:    s.healthListener.Addr().String(),
+			Handler: healthMux,
+		}
+		go func() {
+			serverLogger.Infof("Serving HTTP health checks: %s", s.healthListener.Addr().String())
+			hErr := s.healthServer.Serve(s.healthListener)
+			if hErr != nil && hErr != http.ErrServerClosed {
+				serverLogger.Debugf("error serving HTTP health checks: %s", hErr)
+			}
+		}()
+	}
+
 	return nil
 }
 
 func (s *tlsServer) stop() error {
 	// the servers also close their respective listeners.
 	err := s.httpServer.Shutdown(context.Background())
+	if s.healthServer != nil {
+		if hErr := s.healthServer.Shutdown(context.Background()); hErr != nil && err == nil {
+			err = hErr
+		}
+	}
 	s.grpcServer.GracefulStop()
 	return err
 }
 
-func newTLSServer(grpcL, httpL net.Listener) *tlsServer {
+func newTLSServer(grpcL, httpL, healthL net.Listener) *tlsServer {
 	return &tlsServer{
-		grpcListener: grpcL,
-		httpListener: httpL,
+		grpcListener:   grpcL,
+		httpListener:   httpL,
+		healthListener: healthL,
 	}
 }
@@ -19,12 +19,14 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"open-match.dev/open-match/internal/telemetry"
 	shellTesting "open-match.dev/open-match/internal/testing"
 	"open-match.dev/open-match/pkg/pb"
 	certgenTesting "open-match.dev/open-match/tools/certgen/testing"
@@ -100,7 +102,7 @@ func runTestStartStopTLSServer(t *testing.T, tp *tlsServerTestParams) {
 	}, pb.RegisterFrontendServiceHandlerFromEndpoint)
 
 	serverParams.SetTLSConfiguration(tp.rootPublicCertificateFileData, tp.publicCertificateFileData, tp.privateKeyFileData)
-	s := newTLSServer(serverParams.grpcListener, serverParams.grpcProxyListener)
+	s := newTLSServer(serverParams.grpcListener, serverParams.grpcProxyListener, nil)
 	defer s.stop()
 
 	err := s.start(serverParams)
@@ -128,3 +130,66 @@ func runTestStartStopTLSServer(t *testing.T, tp *tlsServerTestParams) {
 	}
 	runGrpcWithProxyTests(t, require, s, conn, httpClient, httpsEndpoint)
 }
+
+// TestMutualTLSRejectsHTTPSWithoutCertificate verifies that the HTTPS proxy
+// doesn't let clients without a certificate reach the gRPC endpoint, and that
+// health checks are still served on the health listener.
+func TestMutualTLSRejectsHTTPSWithoutCertificate(t *testing.T) {
+	require := require.New(t)
+	grpcL := MustListen()
+	proxyL := MustListen()
+	healthL := MustListen()
+	grpcAddress := fmt.Sprintf("localhost:%s", MustGetPortNumber(grpcL))
+	proxyAddress := fmt.Sprintf("localhost:%s", MustGetPortNumber(proxyL))
+	allHostnames := []string{grpcAddress, proxyAddress}
+	rootPub, rootPriv, err := certgenTesting.CreateRootCertificateAndPrivateKeyForTesting(allHostnames)
+	require.Nil(err)
+	serverPub, serverPriv, err := certgenTesting.CreateDerivedCertificateAndPrivateKeyForTesting(rootPub, rootPriv, allHostnames)
+	require.Nil(err)
+	clientPub, clientPriv, err := certgenTesting.CreateDerivedCertificateAndPrivateKeyForTesting(rootPub, rootPriv, []string{"client"})
+	require.Nil(err)
+
+	serverParams := NewServerParamsFromListeners(grpcL, proxyL)
+	serverParams.AddHandleFunc(func(s *grpc.Server) {
+		pb.RegisterFrontendServiceServer(s, &shellTesting.FakeFrontend{})
+	}, pb.RegisterFrontendServiceHandlerFromEndpoint)
+	serverParams.SetTLSConfiguration(rootPub, serverPub, serverPriv)
+	serverParams.SetRequireClientCertificate(true)
+
+	s := newTLSServer(serverParams.grpcListener, serverParams.grpcProxyListener, healthL)
+	require.Nil(s.start(serverParams))
+	defer s.stop()
+
+	pool, err := trustedCertificateFromFileData(rootPub)
+	require.Nil(err)
+	clientCert, err := certificateFromFileData(clientPub, clientPriv)
+	require.Nil(err)
+
+	newClient := func(certificates []tls.Certificate) *http.Client {
+		return &http.Client{
+			Timeout: time.Second * 10,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					ServerName:   proxyAddress,
+					RootCAs:      pool,
+					Certificates: certificates,
+				},
+			},
+		}
+	}
+	apiURL := fmt.Sprintf("https://%s/v1/frontendservice/tickets", proxyAddress)
+
+	// Without a certificate, the TLS handshake fails before the proxy is reached.
+	_, err = newClient(nil).Post(apiURL, "application/json", strings.NewReader("{}"))
+	require.Error(err)
+
+	resp, err := newClient([]tls.Certificate{*clientCert}).Post(apiURL, "application/json", strings.NewReader("{}"))
+	require.Nil(err)
+	require.Nil(resp.Body.Close())
+	require.Equal(http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(fmt.Sprintf("http://localhost:%s%s", MustGetPortNumber(healthL), telemetry.HealthCheckEndpoint))
+	require.Nil(err)
+	require.Nil(resp.Body.Close())
+	require.Equal(http.StatusOK, resp.StatusCode)
+}
@@ -19,19 +19,27 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/golang/protobuf/proto"
 	"github.com/gomodule/redigo/redis"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/ipb"
+	"open-match.dev/open-match/pkg/backfill"
 	"open-match.dev/open-match/pkg/pb"
 )
 
 const (
 	backfillLastAckTime = "backfill_last_ack_time"
 	allBackfills        = "allBackfills"
+
+	// BackfillOpenSlotsKey is the Backfill extension holding the number of
+	// open slots, see backfill.OpenSlots.
+	BackfillOpenSlotsKey = backfill.OpenSlotsKey
+
+	// decrementOpenSlotsAttempts bounds the retries of a decrement which
+	// lost the race with another write to the backfill.
+	decrementOpenSlotsAttempts = 5
 )
 
 // CreateBackfill creates a new Backfill in the state storage if one doesn't exist. The xids algorithm used to create the ids ensures that they are unique with no system wide synchronization. Calling clients are forbidden from choosing an id during create. So no conflicts will occur.
@@ -47,23 +55,23 @@ func (rb *redisBackend) CreateBackfill(ctx context.Context, backfill *pb.Backfil
 		TicketIds: ticketIDs,
 	}
 
-	value, err := proto.Marshal(&bf)
+	value, err := rb.marshalValue(&bf)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to marshal the backfill proto, id: %s", backfill.GetId())
-		return status.Errorf(codes.Internal, "%v", err)
+		return internalErrorf("%v", err)
 	}
 
 	res, err := redisConn.Do("SETNX", backfill.GetId(), value)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to set the value for backfill, id: %s", backfill.GetId())
-		return status.Errorf(codes.Internal, "%v", err)
+		return internalErrorf("%v", err)
 	}
 
 	if res.(int64) == 0 {
 		return status.Errorf(codes.AlreadyExists, "backfill already exists, id: %s", backfill.GetId())
 	}
 
-	return acknowledgeBackfill(redisConn, backfill.GetId())
+	return acknowledgeBackfill(redisConn, backfill.GetId(), rb.clock.Now())
 }
 
 // GetBackfill gets the Backfill with the specified id from state storage. This method fails if the Backfill does not exist. Returns the Backfill and associated ticketIDs if they exist.
@@ -82,7 +90,7 @@ func (rb *redisBackend) GetBackfill(ctx context.Context, id string) (*pb.Backfil
 		}
 
 		err = errors.Wrapf(err, "failed to get the backfill from state storage, id: %s", id)
-		return nil, nil, status.Errorf(codes.Internal, "%v", err)
+		return nil, nil, internalErrorf("%v", err)
 	}
 
 	if value == nil {
@@ -90,10 +98,10 @@ func (rb *redisBackend) GetBackfill(ctx context.Context, id string) (*pb.Backfil
 	}
 
 	bi := &ipb.BackfillInternal{}
-	err = proto.Unmarshal(value, bi)
+	err = unmarshalValue(value, bi)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to unmarshal internal backfill, id: %s", id)
-		return nil, nil, status.Errorf(codes.Internal, "%v", err)
+		return nil, nil, internalErrorf("%v", err)
 	}
 
 	return bi.Backfill, bi.TicketIds, nil
@@ -119,17 +127,17 @@ func (rb *redisBackend) GetBackfills(ctx context.Context, ids []string) ([]*pb.B
 	slices, err := redis.ByteSlices(redisConn.Do("MGET", queryParams...))
 	if err != nil {
 		err = errors.Wrapf(err, "failed to lookup backfills: %v", ids)
-		return nil, status.Errorf(codes.Internal, "%v", err)
+		return nil, internalErrorf("%v", err)
 	}
 
 	m := make(map[string]*pb.Backfill, len(ids))
 	for i, s := range slices {
 		if s != nil {
 			b := &ipb.BackfillInternal{}
-			err = proto.Unmarshal(s, b)
+			err = unmarshalValue(s, b)
 			if err != nil {
 				err = errors.Wrapf(err, "failed to unmarshal backfill from redis, key: %s", ids[i])
-				return nil, status.Errorf(codes.Internal, "%v", err)
+				return nil, internalErrorf("%v", err)
 			}
 
 			if b.Backfill != nil {
@@ -166,13 +174,14 @@ func (rb *redisBackend) DeleteBackfill(ctx context.Context, id string) error {
 	_, err = redisConn.Do("DEL", id)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to delete the backfill from state storage, id: %s", id)
-		return status.Errorf(codes.Internal, "%v", err)
+		return internalErrorf("%v", err)
 	}
 
 	return rb.deleteExpiredBackfillID(redisConn, id)
 }
 
 // UpdateBackfill updates an existing Backfill with a new data. ticketIDs can be nil.
+// This method fails with NotFound if the Backfill does not exist.
 func (rb *redisBackend) UpdateBackfill(ctx context.Context, backfill *pb.Backfill, ticketIDs []string) error {
 	redisConn, err := rb.redisPool.GetContext(ctx)
 	if err != nil {
@@ -185,21 +194,127 @@ func (rb *redisBackend) UpdateBackfill(ctx context.Context, backfill *pb.Backfil
 		TicketIds: ticketIDs,
 	}
 
-	value, err := proto.Marshal(&bf)
+	value, err := rb.marshalValue(&bf)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to marshal the backfill proto, id: %s", backfill.GetId())
-		return status.Errorf(codes.Internal, "%v", err)
+		return internalErrorf("%v", err)
 	}
 
-	_, err = redisConn.Do("SET", backfill.GetId(), value)
+	// XX only overwrites an existing Backfill, so that one deleted since it was
+	// read is not recreated.
+	reply, err := redisConn.Do("SET", backfill.GetId(), value, "XX")
 	if err != nil {
 		err = errors.Wrapf(err, "failed to set the value for backfill, id: %s", backfill.GetId())
-		return status.Errorf(codes.Internal, "%v", err)
+		return internalErrorf("%v", err)
+	}
+	if reply == nil {
+		return status.Errorf(codes.NotFound, "Backfill id: %s not found", backfill.GetId())
 	}
 
 	return nil
 }
 
+// DecrementBackfillOpenSlots atomically takes n slots from the count in the Backfill's open-slots extension,
+// and returns the updated Backfill. The write is retried if the Backfill is changed while it is decremented.
+func (rb *redisBackend) DecrementBackfillOpenSlots(ctx context.Context, id string, n int32) (*pb.Backfill, error) {
+	return rb.decrementBackfillOpenSlots(ctx, "DecrementBackfillOpenSlots", id, n, nil)
+}
+
+// FillBackfill updates an existing Backfill like UpdateBackfill, and takes n slots from the count in the stored
+// Backfill's open-slots extension in the same transaction. The remaining count replaces the one of backfill.
+func (rb *redisBackend) FillBackfill(ctx context.Context, backfill *pb.Backfill, ticketIDs []string, n int32) error {
+	update := &ipb.BackfillInternal{
+		Backfill:  backfill,
+		TicketIds: ticketIDs,
+	}
+	_, err := rb.decrementBackfillOpenSlots(ctx, "FillBackfill", backfill.GetId(), n, update)
+	return err
+}
+
+func (rb *redisBackend) decrementBackfillOpenSlots(ctx context.Context, caller string, id string, n int32, update *ipb.BackfillInternal) (*pb.Backfill, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "%s, id: %s, failed to connect to redis: %v", caller, id, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	for i := 0; i < decrementOpenSlotsAttempts; i++ {
+		bf, done, err := rb.decrementOpenSlots(redisConn, id, n, update)
+		if err != nil || done {
+			return bf, err
+		}
+	}
+	return nil, status.Errorf(codes.Aborted, "backfill %s was updated concurrently while decrementing its open slots", id)
+}
+
+// decrementOpenSlots makes one attempt at DecrementBackfillOpenSlots. It reports false if the Backfill was
+// changed before the decrement was written. If update is not nil, it is written with the decremented count
+// instead of the stored Backfill.
+func (rb *redisBackend) decrementOpenSlots(redisConn redis.Conn, id string, n int32, update *ipb.BackfillInternal) (*pb.Backfill, bool, error) {
+	if _, err := redisConn.Do("WATCH", id); err != nil {
+		err = errors.Wrapf(err, "failed to watch the backfill, id: %s", id)
+		return nil, false, internalErrorf("%v", err)
+	}
+	// UNWATCH is a no-op once EXEC ran.
+	defer redisConn.Do("UNWATCH")
+
+	value, err := redis.Bytes(redisConn.Do("GET", id))
+	if err == redis.ErrNil {
+		return nil, false, status.Errorf(codes.NotFound, "Backfill id: %s not found", id)
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "failed to get the backfill from state storage, id: %s", id)
+		return nil, false, internalErrorf("%v", err)
+	}
+
+	bi := &ipb.BackfillInternal{}
+	if err = unmarshalValue(value, bi); err != nil {
+		err = errors.Wrapf(err, "failed to unmarshal internal backfill, id: %s", id)
+		return nil, false, internalErrorf("%v", err)
+	}
+
+	openSlots, err := backfill.OpenSlots(bi.Backfill)
+	if err == backfill.ErrNoOpenSlots {
+		return nil, false, status.Errorf(codes.FailedPrecondition, "backfill %s has no %s extension", id, BackfillOpenSlotsKey)
+	}
+	if err != nil {
+		return nil, false, status.Errorf(codes.FailedPrecondition, "backfill %s has a malformed %s extension: %v", id, BackfillOpenSlotsKey, err)
+	}
+	if openSlots < n {
+		return nil, false, status.Errorf(codes.FailedPrecondition, "backfill %s has %d open slots, fewer than the %d requested", id, openSlots, n)
+	}
+
+	if update != nil {
+		bi = update
+	}
+	if err = backfill.SetOpenSlots(bi.Backfill, openSlots-n); err != nil {
+		return nil, false, internalErrorf("%v", err)
+	}
+
+	value, err = rb.marshalValue(bi)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to marshal the backfill proto, id: %s", id)
+		return nil, false, internalErrorf("%v", err)
+	}
+
+	if err = redisConn.Send("MULTI"); err != nil {
+		return nil, false, internalErrorf("%v", errors.Wrap(err, "failed to pipeline commands for DecrementBackfillOpenSlots"))
+	}
+	if err = redisConn.Send("SET", id, value); err != nil {
+		return nil, false, internalErrorf("%v", errors.Wrap(err, "failed to pipeline commands for DecrementBackfillOpenSlots"))
+	}
+	reply, err := redisConn.Do("EXEC")
+	if err != nil {
+		err = errors.Wrapf(err, "failed to set the value for backfill, id: %s", id)
+		return nil, false, internalErrorf("%v", err)
+	}
+	// EXEC replies nil when the watched backfill changed.
+	if reply == nil {
+		return nil, false, nil
+	}
+	return bi.Backfill, true, nil
+}
+
 // AcknowledgeBackfill stores Backfill's last acknowledgement time.
 // Check on Backfill existence should be performed on Frontend side
 func (rb *redisBackend) AcknowledgeBackfill(ctx context.Context, id string) error {
@@ -208,15 +323,15 @@ func (rb *redisBackend) AcknowledgeBackfill(ctx context.Context, id string) erro
 		return status.Errorf(codes.Unavailable, "AcknowledgeBackfill, id: %s, failed to connect to redis: %v", id, err)
 	}
 	defer handleConnectionClose(&redisConn)
-	return acknowledgeBackfill(redisConn, id)
+	return acknowledgeBackfill(redisConn, id, rb.clock.Now())
 }
 
-func acknowledgeBackfill(conn redis.Conn, backfillID string) error {
-	currentTime := time.Now().UnixNano()
+func acknowledgeBackfill(conn redis.Conn, backfillID string, now time.Time) error {
+	currentTime := now.UnixNano()
 
 	_, err := conn.Do("ZADD", backfillLastAckTime, currentTime, backfillID)
 	if err != nil {
-		return status.Errorf(codes.Internal, "%v",
+		return internalErrorf("%v",
 			errors.Wrap(err, "failed to store backfill's last acknowledgement time"))
 	}
 
@@ -226,21 +341,23 @@ func acknowledgeBackfill(conn redis.Conn, backfillID string) error {
 
 // GetExpiredBackfillIDs gets all backfill IDs which are expired
 func (rb *redisBackend) GetExpiredBackfillIDs(ctx context.Context) ([]string, error) {
+	ttl := getBackfillReleaseTimeout(rb.cfg)
+	return rb.getBackfillIDsAcknowledgedBefore(ctx, "GetExpiredBackfillIDs", rb.clock.Now().Add(-ttl))
+}
+
+// getBackfillIDsAcknowledgedBefore returns the ids of the Backfills last
+// acknowledged before t.
+func (rb *redisBackend) getBackfillIDsAcknowledgedBefore(ctx context.Context, caller string, t time.Time) ([]string, error) {
 	redisConn, err := rb.redisPool.GetContext(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Unavailable, "GetExpiredBackfillIDs, failed to connect to redis: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "%s, failed to connect to redis: %v", caller, err)
 	}
 	defer handleConnectionClose(&redisConn)
 
-	ttl := getBackfillReleaseTimeout(rb.cfg)
-	curTime := time.Now()
-	endTimeInt := curTime.Add(-ttl).UnixNano()
-	startTimeInt := 0
-
 	// Filter out backfill IDs that are fetched but not assigned within TTL time (ms).
-	expiredBackfillIds, err := redis.Strings(redisConn.Do("ZRANGEBYSCORE", backfillLastAckTime, startTimeInt, endTimeInt))
+	expiredBackfillIds, err := redis.Strings(redisConn.Do("ZRANGEBYSCORE", backfillLastAckTime, 0, t.UnixNano()))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error getting expired backfills %v", err)
+		return nil, internalErrorf("error getting expired backfills %v", err)
 	}
 
 	return expiredBackfillIds, nil
@@ -251,8 +368,8 @@ func (rb *redisBackend) deleteExpiredBackfillID(conn redis.Conn, backfillID stri
 
 	_, err := conn.Do("ZREM", backfillLastAckTime, backfillID)
 	if err != nil {
-		return status.Errorf(codes.Internal, "failed to delete expired backfill ID %s from Sorted Set %s",
-			backfillID, err.Error())
+		return internalErrorf("failed to delete expired backfill ID %s from Sorted Set %s",
+			backfillID, err)
 	}
 	return nil
 }
@@ -268,7 +385,7 @@ func (rb *redisBackend) IndexBackfill(ctx context.Context, backfill *pb.Backfill
 	err = redisConn.Send("HSET", allBackfills, backfill.Id, backfill.Generation)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to add backfill to all backfills, id: %s", backfill.Id)
-		return status.Errorf(codes.Internal, "%v", err)
+		return internalErrorf("%v", err)
 	}
 
 	return nil
@@ -285,7 +402,7 @@ func (rb *redisBackend) DeindexBackfill(ctx context.Context, id string) error {
 	err = redisConn.Send("HDEL", allBackfills, id)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to remove ID from backfill index, id: %s", id)
-		return status.Errorf(codes.Internal, "%v", err)
+		return internalErrorf("%v", err)
 	}
 
 	return nil
@@ -300,19 +417,19 @@ func (rb *redisBackend) GetIndexedBackfills(ctx context.Context) (map[string]int
 	defer handleConnectionClose(&redisConn)
 
 	ttl := getBackfillReleaseTimeout(rb.cfg)
-	curTime := time.Now()
+	curTime := rb.clock.Now()
 	endTimeInt := curTime.Add(time.Hour).UnixNano()
 	startTimeInt := curTime.Add(-ttl).UnixNano()
 
 	// Exclude expired backfills
 	acknowledgedIds, err := redis.Strings(redisConn.Do("ZRANGEBYSCORE", backfillLastAckTime, startTimeInt, endTimeInt))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error getting acknowledged backfills %v", err)
+		return nil, internalErrorf("error getting acknowledged backfills %v", err)
 	}
 
 	index, err := redis.StringMap(redisConn.Do("HGETALL", allBackfills))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error getting all indexed backfill ids %v", err)
+		return nil, internalErrorf("error getting all indexed backfill ids %v", err)
 	}
 
 	r := make(map[string]int, len(acknowledgedIds))
@@ -320,7 +437,7 @@ func (rb *redisBackend) GetIndexedBackfills(ctx context.Context) (map[string]int
 		if generation, ok := index[id]; ok {
 			gen, err := strconv.Atoi(generation)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "error while parsing generation into number: %v", err)
+				return nil, internalErrorf("error while parsing generation into number: %v", err)
 			}
 			r[id] = gen
 		}
@@ -331,6 +448,12 @@ func (rb *redisBackend) GetIndexedBackfills(ctx context.Context) (map[string]int
 }
 
 func getBackfillReleaseTimeout(cfg config.View) time.Duration {
+	const name = "backfillAckTimeout"
+
+	if cfg.IsSet(name) && cfg.GetDuration(name) > 0 {
+		return cfg.GetDuration(name)
+	}
+
 	// Use a fraction 80% of pendingRelease Tickets TTL
 	ttl := cfg.GetDuration("pendingReleaseTimeout") / 5 * 4
 	return ttl
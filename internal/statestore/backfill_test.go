@@ -20,6 +20,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/gomodule/redigo/redis"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
@@ -126,6 +130,14 @@ func TestUpdateBackfill(t *testing.T) {
 		Id:         "1",
 		Generation: 1,
 	}
+	require.NoError(t, service.CreateBackfill(ctx, &bf, nil))
+
+	deleted := pb.Backfill{
+		Id:         "2",
+		Generation: 1,
+	}
+	require.NoError(t, service.CreateBackfill(ctx, &deleted, nil))
+	require.NoError(t, service.DeleteBackfill(ctx, deleted.Id))
 
 	var testCases = []struct {
 		description     string
@@ -142,12 +154,19 @@ func TestUpdateBackfill(t *testing.T) {
 			expectedMessage: "",
 		},
 		{
-			description:     "create existing backfill, no err expected",
+			description:     "update existing backfill, no err expected",
 			backfill:        &bf,
 			ticketIDs:       nil,
 			expectedCode:    codes.OK,
 			expectedMessage: "",
 		},
+		{
+			description:     "deleted backfill is not recreated",
+			backfill:        &deleted,
+			ticketIDs:       nil,
+			expectedCode:    codes.NotFound,
+			expectedMessage: "Backfill id: 2 not found",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -164,11 +183,14 @@ func TestUpdateBackfill(t *testing.T) {
 		})
 	}
 
+	_, _, err := service.GetBackfill(ctx, deleted.Id)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
 	// pass an expired context, err expected
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 	service = New(cfg)
-	err := service.UpdateBackfill(ctx, &pb.Backfill{
+	err = service.UpdateBackfill(ctx, &pb.Backfill{
 		Id: "222",
 	}, nil)
 	require.Error(t, err)
@@ -176,6 +198,101 @@ func TestUpdateBackfill(t *testing.T) {
 	require.Contains(t, status.Convert(err).Message(), "UpdateBackfill, id: 222, failed to connect to redis:")
 }
 
+func TestDecrementBackfillOpenSlots(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	openSlots := func(n int32) *any.Any {
+		a, err := ptypes.MarshalAny(&wrappers.Int32Value{Value: n})
+		require.NoError(t, err)
+		return a
+	}
+	require.NoError(t, service.CreateBackfill(ctx, &pb.Backfill{
+		Id:         "1",
+		Extensions: map[string]*any.Any{BackfillOpenSlotsKey: openSlots(3)},
+	}, nil))
+	require.NoError(t, service.CreateBackfill(ctx, &pb.Backfill{Id: "2"}, nil))
+
+	// Two concurrent fills of 2 slots each don't fit in the 3 open slots.
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := service.DecrementBackfillOpenSlots(ctx, "1", 2)
+			errs <- err
+		}()
+	}
+	var failed []error
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			failed = append(failed, err)
+		}
+	}
+	require.Len(t, failed, 1)
+	require.Equal(t, codes.FailedPrecondition, status.Code(failed[0]))
+
+	bf, _, err := service.GetBackfill(ctx, "1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(openSlots(1), bf.Extensions[BackfillOpenSlotsKey]))
+
+	// The last slot can still be taken.
+	bf, err = service.DecrementBackfillOpenSlots(ctx, "1", 1)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(openSlots(0), bf.Extensions[BackfillOpenSlotsKey]))
+
+	_, err = service.DecrementBackfillOpenSlots(ctx, "2", 1)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = service.DecrementBackfillOpenSlots(ctx, "3", 1)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestFillBackfill(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	openSlots := func(n int32) *any.Any {
+		a, err := ptypes.MarshalAny(&wrappers.Int32Value{Value: n})
+		require.NoError(t, err)
+		return a
+	}
+	require.NoError(t, service.CreateBackfill(ctx, &pb.Backfill{
+		Id:         "1",
+		Generation: 1,
+		Extensions: map[string]*any.Any{BackfillOpenSlotsKey: openSlots(3)},
+	}, nil))
+
+	// The stored count is decremented, whatever the count of the update.
+	update := &pb.Backfill{Id: "1", Generation: 2, Extensions: map[string]*any.Any{BackfillOpenSlotsKey: openSlots(3)}}
+	require.NoError(t, service.FillBackfill(ctx, update, []string{"t1", "t2"}, 2))
+
+	bf, ids, err := service.GetBackfill(ctx, "1")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), bf.Generation)
+	require.True(t, proto.Equal(openSlots(1), bf.Extensions[BackfillOpenSlotsKey]))
+	require.ElementsMatch(t, []string{"t1", "t2"}, ids)
+
+	// A fill which doesn't fit writes nothing, so no slot is lost.
+	update = &pb.Backfill{Id: "1", Generation: 3}
+	err = service.FillBackfill(ctx, update, []string{"t1", "t2", "t3", "t4"}, 2)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	bf, ids, err = service.GetBackfill(ctx, "1")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), bf.Generation)
+	require.True(t, proto.Equal(openSlots(1), bf.Extensions[BackfillOpenSlotsKey]))
+	require.ElementsMatch(t, []string{"t1", "t2"}, ids)
+
+	err = service.FillBackfill(ctx, &pb.Backfill{Id: "2"}, nil, 1)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
 func TestGetBackfill(t *testing.T) {
 	cfg, closer := createRedis(t, false, "")
 	defer closer()
@@ -604,3 +721,15 @@ func generateBackfills(ctx context.Context, t *testing.T, service Service, amoun
 
 	return backfills
 }
+
+func TestGetBackfillReleaseTimeout(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("pendingReleaseTimeout", 10*time.Second)
+	require.Equal(t, 8*time.Second, getBackfillReleaseTimeout(cfg))
+
+	cfg.Set("backfillAckTimeout", 0)
+	require.Equal(t, 8*time.Second, getBackfillReleaseTimeout(cfg))
+
+	cfg.Set("backfillAckTimeout", 30*time.Second)
+	require.Equal(t, 30*time.Second, getBackfillReleaseTimeout(cfg))
+}
@@ -0,0 +1,96 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// valueFormatJSON stores values as JSON when configured as redis.valueFormat,
+// which is easier to inspect than the default binary protobuf format.
+const valueFormatJSON = "json"
+
+var (
+	// gzipMagic starts every gzip stream.  A marshaled Ticket never starts with it,
+	// as 0x1f is field 3 with an invalid wire type, so stored values are read
+	// whether or not they were compressed when written.
+	gzipMagic = []byte{0x1f, 0x8b}
+
+	// jsonPrefix starts every JSON value.  The stored messages have no field 15,
+	// so a binary value never starts with it.
+	jsonPrefix = []byte("{")
+)
+
+// marshalTicket marshals the ticket for storage, compressing it if
+// redis.compressTickets is configured.
+func (rb *redisBackend) marshalTicket(ticket *pb.Ticket) ([]byte, error) {
+	value, err := rb.marshalValue(ticket)
+	if err != nil || !rb.cfg.GetBool("redis.compressTickets") {
+		return value, err
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err = w.Write(value); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalTicket unmarshals a stored ticket, decompressing it if needed.
+func unmarshalTicket(value []byte, ticket *pb.Ticket) error {
+	if bytes.HasPrefix(value, gzipMagic) {
+		r, err := gzip.NewReader(bytes.NewReader(value))
+		if err != nil {
+			return err
+		}
+		value, err = ioutil.ReadAll(r)
+		if err != nil {
+			return err
+		}
+	}
+	return unmarshalValue(value, ticket)
+}
+
+// marshalValue marshals the message in the configured redis.valueFormat.
+// Messages which can't be written as JSON, such as those with extensions of
+// types unknown to Open Match, are written as binary protobuf instead.
+func (rb *redisBackend) marshalValue(m proto.Message) ([]byte, error) {
+	if rb.cfg.GetString("redis.valueFormat") == valueFormatJSON {
+		var buf bytes.Buffer
+		if err := (&jsonpb.Marshaler{}).Marshal(&buf, m); err == nil {
+			return buf.Bytes(), nil
+		}
+	}
+	return proto.Marshal(m)
+}
+
+// unmarshalValue unmarshals a stored message, whatever its format.
+func unmarshalValue(value []byte, m proto.Message) error {
+	if bytes.HasPrefix(value, jsonPrefix) {
+		u := jsonpb.Unmarshaler{AllowUnknownFields: true}
+		return u.Unmarshal(bytes.NewReader(value), m)
+	}
+	return proto.Unmarshal(value, m)
+}
@@ -0,0 +1,169 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/gomodule/redigo/redis"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestCompressTickets(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	cfg.(*viper.Viper).Set("redis.compressTickets", true)
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+
+	ctx := utilTesting.NewContext(t)
+
+	ticket := &pb.Ticket{
+		Id: "compressed",
+		SearchFields: &pb.SearchFields{
+			DoubleArgs: map[string]float64{},
+			StringArgs: map[string]string{},
+		},
+	}
+	for i := 0; i < 100; i++ {
+		ticket.SearchFields.DoubleArgs[fmt.Sprintf("latency.region-%d", i)] = float64(i)
+		ticket.SearchFields.StringArgs[fmt.Sprintf("attribute-%d", i)] = "value"
+	}
+	require.NoError(t, service.CreateTicket(ctx, ticket))
+
+	c, err := redis.Dial("tcp", fmt.Sprintf("%s:%s", cfg.GetString("redis.hostname"), cfg.GetString("redis.port")))
+	require.NoError(t, err)
+	stored, err := redis.Bytes(c.Do("GET", ticket.Id))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(stored, gzipMagic))
+	require.Less(t, len(stored), proto.Size(ticket))
+
+	got, err := service.GetTicket(ctx, ticket.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(ticket, got))
+
+	// Compressed and uncompressed tickets are both read, whatever the configuration.
+	cfg.(*viper.Viper).Set("redis.compressTickets", false)
+	plain := &pb.Ticket{Id: "plain", SearchFields: &pb.SearchFields{Tags: []string{"mode.ranked"}}}
+	require.NoError(t, service.CreateTicket(ctx, plain))
+
+	stored, err = redis.Bytes(c.Do("GET", plain.Id))
+	require.NoError(t, err)
+	require.False(t, bytes.HasPrefix(stored, gzipMagic))
+
+	got, err = service.GetTicket(ctx, ticket.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(ticket, got))
+
+	tickets, err := service.GetTickets(ctx, []string{ticket.Id, plain.Id})
+	require.NoError(t, err)
+	require.Len(t, tickets, 2)
+	require.True(t, proto.Equal(ticket, tickets[0]))
+	require.True(t, proto.Equal(plain, tickets[1]))
+}
+
+func TestValueFormats(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+
+	ctx := utilTesting.NewContext(t)
+	c, err := redis.Dial("tcp", fmt.Sprintf("%s:%s", cfg.GetString("redis.hostname"), cfg.GetString("redis.port")))
+	require.NoError(t, err)
+
+	version, err := ptypes.MarshalAny(&wrappers.StringValue{Value: "1.2.3"})
+	require.NoError(t, err)
+
+	formats := []string{"", valueFormatJSON}
+	tickets := map[string]*pb.Ticket{}
+	backfills := map[string]*pb.Backfill{}
+	for _, format := range formats {
+		cfg.(*viper.Viper).Set("redis.valueFormat", format)
+
+		ticket := &pb.Ticket{
+			Id: "ticket-" + format,
+			SearchFields: &pb.SearchFields{
+				DoubleArgs: map[string]float64{"mmr": 1500},
+				StringArgs: map[string]string{"mode": "ranked"},
+				Tags:       []string{"beta"},
+			},
+			Extensions: map[string]*any.Any{"client_version": version},
+		}
+		require.NoError(t, service.CreateTicket(ctx, ticket))
+		tickets[ticket.Id] = ticket
+
+		backfill := &pb.Backfill{Id: "backfill-" + format, SearchFields: ticket.SearchFields}
+		require.NoError(t, service.CreateBackfill(ctx, backfill, []string{ticket.Id}))
+		backfills[backfill.Id] = backfill
+
+		for _, id := range []string{ticket.Id, backfill.Id} {
+			stored, err := redis.Bytes(c.Do("GET", id))
+			require.NoError(t, err)
+			require.Equal(t, format == valueFormatJSON, bytes.HasPrefix(stored, jsonPrefix), id)
+		}
+	}
+
+	// Values are read in whichever format they were written.
+	for _, format := range formats {
+		cfg.(*viper.Viper).Set("redis.valueFormat", format)
+
+		for id, ticket := range tickets {
+			got, err := service.GetTicket(ctx, id)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(ticket, got), id)
+		}
+
+		for id, backfill := range backfills {
+			got, ticketIDs, err := service.GetBackfill(ctx, id)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(backfill, got), id)
+			require.Len(t, ticketIDs, 1)
+		}
+	}
+}
+
+func TestJSONValueFormatFallsBackToProto(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	cfg.(*viper.Viper).Set("redis.valueFormat", valueFormatJSON)
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+
+	ctx := utilTesting.NewContext(t)
+
+	// The type of the extension isn't known, so the ticket can't be written as JSON.
+	ticket := &pb.Ticket{
+		Id:         "unknown-extension",
+		Extensions: map[string]*any.Any{"custom": {TypeUrl: "type.googleapis.com/example.Custom", Value: []byte{0x08, 0x01}}},
+	}
+	require.NoError(t, service.CreateTicket(ctx, ticket))
+
+	got, err := service.GetTicket(ctx, ticket.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(ticket, got))
+}
@@ -16,6 +16,7 @@ package statestore
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/trace"
 	"open-match.dev/open-match/pkg/pb"
@@ -41,6 +42,12 @@ func (is *instrumentedService) CreateTicket(ctx context.Context, ticket *pb.Tick
 	return is.s.CreateTicket(ctx, ticket)
 }
 
+func (is *instrumentedService) CreateTickets(ctx context.Context, tickets []*pb.Ticket) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.CreateTickets")
+	defer span.End()
+	return is.s.CreateTickets(ctx, tickets)
+}
+
 func (is *instrumentedService) GetTicket(ctx context.Context, id string) (*pb.Ticket, error) {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetTicket")
 	defer span.End()
@@ -53,6 +60,36 @@ func (is *instrumentedService) DeleteTicket(ctx context.Context, id string) erro
 	return is.s.DeleteTicket(ctx, id)
 }
 
+func (is *instrumentedService) DeleteTickets(ctx context.Context, ids []string) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.DeleteTickets")
+	defer span.End()
+	return is.s.DeleteTickets(ctx, ids)
+}
+
+func (is *instrumentedService) UpdateTicket(ctx context.Context, ticket *pb.Ticket) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.UpdateTicket")
+	defer span.End()
+	return is.s.UpdateTicket(ctx, ticket)
+}
+
+func (is *instrumentedService) GetTicketRevisions(ctx context.Context) (map[string]int, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetTicketRevisions")
+	defer span.End()
+	return is.s.GetTicketRevisions(ctx)
+}
+
+func (is *instrumentedService) GetPlayerTicketID(ctx context.Context, playerID string) (string, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetPlayerTicketID")
+	defer span.End()
+	return is.s.GetPlayerTicketID(ctx, playerID)
+}
+
+func (is *instrumentedService) SetPlayerTicketID(ctx context.Context, playerID string, ticketID string) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.SetPlayerTicketID")
+	defer span.End()
+	return is.s.SetPlayerTicketID(ctx, playerID, ticketID)
+}
+
 func (is *instrumentedService) IndexTicket(ctx context.Context, ticket *pb.Ticket) error {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.IndexTicket")
 	defer span.End()
@@ -71,6 +108,18 @@ func (is *instrumentedService) GetTickets(ctx context.Context, ids []string) ([]
 	return is.s.GetTickets(ctx, ids)
 }
 
+func (is *instrumentedService) GetIndexedPlayerTicketIDs(ctx context.Context, playerID string) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetIndexedPlayerTicketIDs")
+	defer span.End()
+	return is.s.GetIndexedPlayerTicketIDs(ctx, playerID)
+}
+
+func (is *instrumentedService) GetPoolTicketCount(ctx context.Context, pool string) (int64, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetPoolTicketCount")
+	defer span.End()
+	return is.s.GetPoolTicketCount(ctx, pool)
+}
+
 func (is *instrumentedService) GetIndexedIDSet(ctx context.Context) (map[string]struct{}, error) {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetIndexedIDSet")
 	defer span.End()
@@ -83,10 +132,22 @@ func (is *instrumentedService) UpdateAssignments(ctx context.Context, req *pb.As
 	return is.s.UpdateAssignments(ctx, req)
 }
 
-func (is *instrumentedService) GetAssignments(ctx context.Context, id string, callback func(*pb.Assignment) error) error {
+func (is *instrumentedService) GetTicketIDsCreatedBefore(ctx context.Context, t time.Time) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetTicketIDsCreatedBefore")
+	defer span.End()
+	return is.s.GetTicketIDsCreatedBefore(ctx, t)
+}
+
+func (is *instrumentedService) AssignUnassignedTickets(ctx context.Context, ids []string, assignment *pb.Assignment) ([]*pb.Ticket, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.AssignUnassignedTickets")
+	defer span.End()
+	return is.s.AssignUnassignedTickets(ctx, ids, assignment)
+}
+
+func (is *instrumentedService) GetAssignments(ctx context.Context, id string, pollInterval time.Duration, callback func(*pb.Assignment) error) error {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetAssignments")
 	defer span.End()
-	return is.s.GetAssignments(ctx, id, callback)
+	return is.s.GetAssignments(ctx, id, pollInterval, callback)
 }
 
 func (is *instrumentedService) AddTicketsToPendingRelease(ctx context.Context, ids []string) error {
@@ -95,18 +156,60 @@ func (is *instrumentedService) AddTicketsToPendingRelease(ctx context.Context, i
 	return is.s.AddTicketsToPendingRelease(ctx, ids)
 }
 
+func (is *instrumentedService) ProposeTickets(ctx context.Context, ids []string) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.ProposeTickets")
+	defer span.End()
+	return is.s.ProposeTickets(ctx, ids)
+}
+
+func (is *instrumentedService) AddTicketsToCooldown(ctx context.Context, ids []string, cooldown time.Duration) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.AddTicketsToCooldown")
+	defer span.End()
+	return is.s.AddTicketsToCooldown(ctx, ids, cooldown)
+}
+
+func (is *instrumentedService) ReserveTickets(ctx context.Context, ids []string, lease time.Duration) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.ReserveTickets")
+	defer span.End()
+	return is.s.ReserveTickets(ctx, ids, lease)
+}
+
 func (is *instrumentedService) DeleteTicketsFromPendingRelease(ctx context.Context, ids []string) error {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.DeleteTicketsFromPendingRelease")
 	defer span.End()
 	return is.s.DeleteTicketsFromPendingRelease(ctx, ids)
 }
 
+func (is *instrumentedService) RecordMatchesMade(ctx context.Context, count int) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.RecordMatchesMade")
+	defer span.End()
+	return is.s.RecordMatchesMade(ctx, count)
+}
+
+func (is *instrumentedService) RecordTicketsAssigned(ctx context.Context, count int) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.RecordTicketsAssigned")
+	defer span.End()
+	return is.s.RecordTicketsAssigned(ctx, count)
+}
+
+func (is *instrumentedService) GetStats(ctx context.Context) (*Stats, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetStats")
+	defer span.End()
+	return is.s.GetStats(ctx)
+}
+
 func (is *instrumentedService) ReleaseAllTickets(ctx context.Context) error {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.ReleaseAllTickets")
 	defer span.End()
 	return is.s.ReleaseAllTickets(ctx)
 }
 
+func (is *instrumentedService) GetPendingReleaseTime(ctx context.Context, id string) (time.Time, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetPendingReleaseTime")
+	defer span.End()
+	return is.s.GetPendingReleaseTime(ctx, id)
+}
+
 // CreateBackfill creates a new Backfill in the state storage if one doesn't exist. The xids algorithm used to create the ids ensures that they are unique with no system wide synchronization. Calling clients are forbidden from choosing an id during create. So no conflicts will occur.
 func (is *instrumentedService) CreateBackfill(ctx context.Context, backfill *pb.Backfill, ticketIDs []string) error {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.CreateBackfill")
@@ -142,6 +245,20 @@ func (is *instrumentedService) UpdateBackfill(ctx context.Context, backfill *pb.
 	return is.s.UpdateBackfill(ctx, backfill, ticketIDs)
 }
 
+// DecrementBackfillOpenSlots atomically takes n slots from the count in the Backfill's open-slots extension.
+func (is *instrumentedService) DecrementBackfillOpenSlots(ctx context.Context, id string, n int32) (*pb.Backfill, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.DecrementBackfillOpenSlots")
+	defer span.End()
+	return is.s.DecrementBackfillOpenSlots(ctx, id, n)
+}
+
+// FillBackfill updates a Backfill and takes n slots from its stored open-slots count at once.
+func (is *instrumentedService) FillBackfill(ctx context.Context, backfill *pb.Backfill, ticketIDs []string, n int32) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.FillBackfill")
+	defer span.End()
+	return is.s.FillBackfill(ctx, backfill, ticketIDs, n)
+}
+
 // NewMutex returns a new distributed mutex with given name
 func (is *instrumentedService) NewMutex(key string) RedisLocker {
 	_, span := trace.StartSpan(context.Background(), "statestore/instrumented.NewMutex")
@@ -149,6 +266,13 @@ func (is *instrumentedService) NewMutex(key string) RedisLocker {
 	return is.s.NewMutex(key)
 }
 
+// RunLocked runs fn if no other replica holds the lock with the given name
+func (is *instrumentedService) RunLocked(ctx context.Context, key string, fn func(context.Context) error) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.RunLocked")
+	defer span.End()
+	return is.s.RunLocked(ctx, key, fn)
+}
+
 // AcknowledgeBackfill stores Backfill's last acknowledged time
 func (is *instrumentedService) AcknowledgeBackfill(ctx context.Context, id string) error {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.AcknowledgeBackfill")
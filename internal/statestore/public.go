@@ -16,9 +16,11 @@ package statestore
 
 import (
 	"context"
+	"time"
 
 	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/internal/util"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -35,20 +37,53 @@ type Service interface {
 	// CreateTicket creates a new Ticket in the state storage. If the id already exists, it will be overwritten.
 	CreateTicket(ctx context.Context, ticket *pb.Ticket) error
 
+	// CreateTickets creates and indexes new Tickets in a single round trip to state storage.
+	// The Tickets must not have been created or indexed before.
+	CreateTickets(ctx context.Context, tickets []*pb.Ticket) error
+
 	// GetTicket gets the Ticket with the specified id from state storage.
 	// This method fails if the Ticket does not exist.
 	GetTicket(ctx context.Context, id string) (*pb.Ticket, error)
 
-	// DeleteTicket removes the Ticket with the specified id from state storage.
+	// DeleteTicket removes the Ticket with the specified id from state storage, along with its indexing.
 	// This method succeeds if the Ticket does not exist.
 	DeleteTicket(ctx context.Context, id string) error
 
+	// DeleteTickets removes the Tickets with the specified ids from state storage, along with
+	// their indexing, in a single round trip. Returns the ids of the Tickets which existed.
+	DeleteTickets(ctx context.Context, ids []string) ([]string, error)
+
+	// UpdateTicket overwrites an indexed Ticket and increments its revision, so that
+	// cached copies of the Ticket can be refreshed, and moves it to the index of its
+	// saturation pool. This method fails with NotFound if the Ticket does not exist,
+	// is assigned or is no longer indexed, such as once it is deleted, and with
+	// FailedPrecondition if the Ticket is changed, such as assigned, while it is updated.
+	UpdateTicket(ctx context.Context, ticket *pb.Ticket) error
+
+	// GetTicketRevisions returns the revision of every Ticket which was updated since
+	// its creation, by Ticket id. Tickets never updated are not included.
+	GetTicketRevisions(ctx context.Context) (map[string]int, error)
+
+	// GetPlayerTicketID returns the id of the Ticket last recorded for the player.
+	// Returns an empty string if no Ticket was recorded. The Ticket may no longer exist.
+	GetPlayerTicketID(ctx context.Context, playerID string) (string, error)
+
+	// SetPlayerTicketID records the Ticket id as the Ticket of the player.
+	SetPlayerTicketID(ctx context.Context, playerID string, ticketID string) error
+
 	// IndexTicket adds the ticket to the index.
 	IndexTicket(ctx context.Context, ticket *pb.Ticket) error
 
 	// DeindexTicket removes specified ticket from the index. The Ticket continues to exist.
 	DeindexTicket(ctx context.Context, id string) error
 
+	// GetIndexedPlayerTicketIDs returns the ids of the indexed tickets with the player id.
+	GetIndexedPlayerTicketIDs(ctx context.Context, playerID string) ([]string, error)
+
+	// GetPoolTicketCount returns the number of indexed tickets in the saturation pool,
+	// as chosen by SaturationPool. Tickets are only counted while poolSaturation.tags is set.
+	GetPoolTicketCount(ctx context.Context, pool string) (int64, error)
+
 	// GetIndexedIDSet returns the ids of all tickets currently indexed.
 	GetIndexedIDSet(ctx context.Context) (map[string]struct{}, error)
 
@@ -59,18 +94,63 @@ type Service interface {
 	// UpdateAssignments update using the request's specified tickets with assignments.
 	UpdateAssignments(ctx context.Context, req *pb.AssignTicketsRequest) (*pb.AssignTicketsResponse, []*pb.Ticket, error)
 
+	// GetTicketIDsCreatedBefore returns the ids of the tickets created before t which are not
+	// known to be assigned. Creation times are only recorded while fallbackAssignment is configured.
+	GetTicketIDsCreatedBefore(ctx context.Context, t time.Time) ([]string, error)
+
+	// AssignUnassignedTickets assigns the tickets which are indexed, unassigned and not pending
+	// release, and returns them. Tickets changed since they were read, such as assigned by a
+	// backend, are left as they are.
+	AssignUnassignedTickets(ctx context.Context, ids []string, assignment *pb.Assignment) ([]*pb.Ticket, error)
+
 	// GetAssignments returns the assignment associated with the input ticket id.
-	GetAssignments(ctx context.Context, id string, callback func(*pb.Assignment) error) error
+	// The assignment is polled every pollInterval, or every backoff.initialInterval
+	// if pollInterval is not positive, until the callback fails.  Once an unassigned ticket
+	// expires after its ticketTTL, it fails with DeadlineExceeded rather than NotFound.
+	GetAssignments(ctx context.Context, id string, pollInterval time.Duration, callback func(*pb.Assignment) error) error
 
 	// AddTicketsToPendingRelease appends new proposed tickets to the proposed sorted set with current timestamp.
+	// Tickets are added to the configured pendingReleaseScope, and are only hidden from
+	// GetIndexedIDSet of the same scope. Tickets proposed without a scope are hidden from all scopes.
+	// The scope is set per process, so it separates deployments sharing a Redis, not backends.
 	AddTicketsToPendingRelease(ctx context.Context, ids []string) error
 
+	// ProposeTickets adds the tickets to pending release like AddTicketsToPendingRelease, but
+	// atomically and only if none of them is pending release already. It fails with Aborted,
+	// adding no ticket, otherwise.
+	ProposeTickets(ctx context.Context, ids []string) error
+
+	// AddTicketsToCooldown hides tickets from GetIndexedIDSet of the configured scope until the
+	// cooldown elapses. Tickets which are already pending release until later keep their release time.
+	AddTicketsToCooldown(ctx context.Context, ids []string, cooldown time.Duration) error
+
+	// ReserveTickets hides tickets from GetIndexedIDSet of the configured scope until the lease
+	// elapses. Unlike AddTicketsToCooldown, tickets which are already pending release have their
+	// release time replaced by the end of the lease.
+	ReserveTickets(ctx context.Context, ids []string, lease time.Duration) error
+
 	// DeleteTicketsFromPendingRelease deletes tickets from the proposed sorted set.
 	DeleteTicketsFromPendingRelease(ctx context.Context, ids []string) error
 
-	// ReleaseAllTickets releases all pending tickets back to active.
+	// ReleaseAllTickets releases all pending tickets of the configured scope back to active.
+	// Tickets pending in other scopes, including the global one, are not released.
 	ReleaseAllTickets(ctx context.Context) error
 
+	// GetPendingReleaseTime returns when the pending release of the ticket times out.
+	// Returns false if the ticket is not pending release in the global or configured scope.
+	GetPendingReleaseTime(ctx context.Context, id string) (time.Time, bool, error)
+
+	// Stats
+
+	// RecordMatchesMade adds count to the number of recently made matches.
+	RecordMatchesMade(ctx context.Context, count int) error
+
+	// RecordTicketsAssigned adds count to the number of recently assigned tickets.
+	RecordTicketsAssigned(ctx context.Context, count int) error
+
+	// GetStats returns aggregate matchmaking numbers from maintained counters.
+	GetStats(ctx context.Context) (*Stats, error)
+
 	// Backfill
 
 	// CreateBackfill creates a new Backfill in the state storage if one doesn't exist.
@@ -91,11 +171,28 @@ type Service interface {
 	DeleteBackfill(ctx context.Context, id string) error
 
 	// UpdateBackfill updates an existing Backfill with a new data. ticketIDs can be nil.
+	// This method fails with NotFound if the Backfill does not exist.
 	UpdateBackfill(ctx context.Context, backfill *pb.Backfill, ticketIDs []string) error
 
+	// DecrementBackfillOpenSlots atomically takes n slots from the count in the
+	// Backfill's open-slots extension, and returns the updated Backfill.
+	// It fails with FailedPrecondition if fewer than n slots are open.
+	DecrementBackfillOpenSlots(ctx context.Context, id string, n int32) (*pb.Backfill, error)
+
+	// FillBackfill updates an existing Backfill like UpdateBackfill, and in the same
+	// transaction takes n slots from the stored Backfill's open-slots extension, which
+	// then replaces the one of backfill. It fails with FailedPrecondition if fewer than
+	// n slots are open, writing nothing.
+	FillBackfill(ctx context.Context, backfill *pb.Backfill, ticketIDs []string, n int32) error
+
 	// NewMutex returns an interface of a new distributed mutex with given name
 	NewMutex(key string) RedisLocker
 
+	// RunLocked runs fn if no other replica holds the lock with the given name, and returns
+	// nil without running it otherwise. The lock is held while fn runs, and fn's context is
+	// canceled should the lock be lost.
+	RunLocked(ctx context.Context, key string, fn func(context.Context) error) error
+
 	// AcknowledgeBackfill stores Backfill's last acknowledged time
 	AcknowledgeBackfill(ctx context.Context, id string) error
 
@@ -115,7 +212,13 @@ type Service interface {
 
 // New creates a Service based on the configuration.
 func New(cfg config.View) Service {
-	s := newRedis(cfg)
+	return NewWithClock(cfg, util.RealClock())
+}
+
+// NewWithClock creates a Service based on the configuration, which reads the
+// current time from clock when expiring pending releases and recording stats.
+func NewWithClock(cfg config.View, clock util.Clock) Service {
+	s := newRedis(cfg, clock)
 	if cfg.GetBool(telemetry.ConfigNameEnableMetrics) {
 		return &instrumentedService{
 			s: s,
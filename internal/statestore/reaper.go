@@ -0,0 +1,209 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"context"
+	"time"
+
+	rs "github.com/go-redsync/redsync/v4"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+)
+
+const backfillReaperLock = "lock/backfillReaper"
+
+var (
+	backfillsReaped = stats.Int64("open-match.dev/statestore/backfills_reaped", "Number of backfills deleted for not being acknowledged within backfillTTL", stats.UnitDimensionless)
+
+	backfillsReapedView = &view.View{
+		Measure:     backfillsReaped,
+		Name:        "open-match.dev/statestore/backfills_reaped",
+		Description: "Number of backfills deleted for not being acknowledged within backfillTTL",
+		Aggregation: view.Sum(),
+	}
+)
+
+// backfillReaper deletes the Backfills which were neither acknowledged nor
+// created within backfillTTL, such as those of a game server which died, so
+// that they don't pile up in Redis.  Every Open Match component may run one,
+// a Redis lock lets a single replica reap at a time.
+type backfillReaper struct {
+	rb       *redisBackend
+	lock     *rs.Mutex
+	ttl      time.Duration
+	interval time.Duration
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// newBackfillReaper starts the reaper, or returns nil if no backfillTTL is
+// configured.  Expired Backfills are looked for every backfillReapInterval,
+// which defaults to a tenth of the TTL.
+func newBackfillReaper(cfg config.View, rb *redisBackend, mutexes *rs.Redsync) *backfillReaper {
+	ttl := cfg.GetDuration("backfillTTL")
+	if !cfg.IsSet("backfillTTL") || ttl <= 0 {
+		return nil
+	}
+
+	interval := cfg.GetDuration("backfillReapInterval")
+	if interval <= 0 {
+		interval = ttl / 10
+	}
+
+	if err := view.Register(backfillsReapedView); err != nil {
+		redisLogger.WithError(err).Infof("cannot register view for metric: %s, it will not be reported", backfillsReapedView.Name)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &backfillReaper{
+		rb: rb,
+		// A single try, replicas which don't get the lock skip this round.
+		lock:     mutexes.NewMutex(backfillReaperLock, rs.WithTries(1), rs.WithExpiry(roundLockExpiry)),
+		ttl:      ttl,
+		interval: interval,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go r.run(ctx)
+	return r
+}
+
+func (r *backfillReaper) run(ctx context.Context) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.reapLocked(ctx); err != nil && ctx.Err() == nil {
+				redisLogger.WithError(err).Warning("failed to reap expired backfills")
+			}
+		}
+	}
+}
+
+// reapLocked reaps the expired Backfills if no other replica is doing so.
+func (r *backfillReaper) reapLocked(ctx context.Context) error {
+	return runLocked(ctx, backfillReaperLock, r.lock, func(ctx context.Context) error {
+		_, err := r.reap(ctx)
+		return err
+	})
+}
+
+// reap deletes the Backfills last acknowledged, or created, more than the TTL
+// ago, and returns their ids.  The Tickets associated with them return to the
+// matchmaking pool.
+func (r *backfillReaper) reap(ctx context.Context) ([]string, error) {
+	ids, err := r.rb.getBackfillIDsAcknowledgedBefore(ctx, "backfillReaper", r.rb.clock.Now().Add(-r.ttl))
+	if err != nil {
+		return nil, err
+	}
+
+	reaped := []string{}
+	for _, id := range ids {
+		ok, err := r.reapBackfill(ctx, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return reaped, err
+			}
+			redisLogger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"id":    id,
+			}).Error("failed to reap the backfill")
+			continue
+		}
+		if ok {
+			reaped = append(reaped, id)
+		}
+	}
+
+	stats.Record(ctx, backfillsReaped.M(int64(len(reaped))))
+	return reaped, nil
+}
+
+// reapBackfill deletes the Backfill, unless it was acknowledged since it was
+// found expired.  The Backfill's lock is held as the frontend does, so that
+// it isn't reaped while it is being acknowledged or updated.
+func (r *backfillReaper) reapBackfill(ctx context.Context, id string) (bool, error) {
+	m := r.rb.NewMutex(id)
+	if err := m.Lock(ctx); err != nil {
+		return false, err
+	}
+	defer func() {
+		if _, err := m.Unlock(ctx); err != nil {
+			redisLogger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"id":    id,
+			}).Error("error on mutex unlock")
+		}
+	}()
+
+	expired, err := r.rb.isBackfillAcknowledgedBefore(ctx, id, r.rb.clock.Now().Add(-r.ttl))
+	if err != nil || !expired {
+		return false, err
+	}
+
+	_, associatedTickets, err := r.rb.GetBackfill(ctx, id)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return false, err
+	}
+	if err = r.rb.DeleteTicketsFromPendingRelease(ctx, associatedTickets); err != nil {
+		return false, err
+	}
+	if err = r.rb.DeleteBackfill(ctx, id); err != nil {
+		return false, err
+	}
+	if err = r.rb.DeindexBackfill(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// close stops the reaper, waiting for a running round to finish.
+func (r *backfillReaper) close() {
+	r.cancel()
+	<-r.done
+}
+
+// isBackfillAcknowledgedBefore reports whether the Backfill was last
+// acknowledged before t.  Backfills without an acknowledgement time are not.
+func (rb *redisBackend) isBackfillAcknowledgedBefore(ctx context.Context, id string, t time.Time) (bool, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return false, status.Errorf(codes.Unavailable, "isBackfillAcknowledgedBefore, id: %s, failed to connect to redis: %v", id, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	// Scores are doubles, which Redis may reply in exponent form.
+	ackTime, err := redis.Float64(redisConn.Do("ZSCORE", backfillLastAckTime, id))
+	if err == redis.ErrNil {
+		return false, nil
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "failed to get the backfill's last acknowledgement time, id: %s", id)
+		return false, internalErrorf("%v", err)
+	}
+	return ackTime <= float64(t.UnixNano()), nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	rs "github.com/go-redsync/redsync/v4"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestBackfillReaper(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	cfg.(*viper.Viper).Set("backfillTTL", time.Minute)
+	// The test reaps by hand.
+	cfg.(*viper.Viper).Set("backfillReapInterval", time.Hour)
+	clock := utilTesting.NewFakeClock(time.Now())
+	rb := newRedis(cfg, clock).(*redisBackend)
+	defer rb.Close()
+	ctx := utilTesting.NewContext(t)
+	require.NotNil(t, rb.reaper)
+
+	stale := &pb.Backfill{Id: "stale"}
+	require.NoError(t, rb.CreateBackfill(ctx, stale, []string{"t1"}))
+	require.NoError(t, rb.IndexBackfill(ctx, stale))
+	require.NoError(t, rb.AddTicketsToPendingRelease(ctx, []string{"t1"}))
+
+	clock.Advance(time.Minute / 2)
+	acked := &pb.Backfill{Id: "acked"}
+	require.NoError(t, rb.CreateBackfill(ctx, acked, nil))
+	require.NoError(t, rb.IndexBackfill(ctx, acked))
+
+	clock.Advance(time.Minute / 2)
+	require.NoError(t, rb.AcknowledgeBackfill(ctx, acked.Id))
+	clock.Advance(time.Millisecond)
+
+	reaped, err := rb.reaper.reap(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{stale.Id}, reaped)
+
+	_, _, err = rb.GetBackfill(ctx, stale.Id)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, _, err = rb.GetBackfill(ctx, acked.Id)
+	require.NoError(t, err)
+
+	indexed, err := rb.GetIndexedBackfills(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{acked.Id: 0}, indexed)
+
+	// The stale Backfill's Ticket returns to the pool.
+	_, pending, err := rb.GetPendingReleaseTime(ctx, "t1")
+	require.NoError(t, err)
+	require.False(t, pending)
+
+	reaped, err = rb.reaper.reap(ctx)
+	require.NoError(t, err)
+	require.Empty(t, reaped)
+}
+
+func TestBackfillReaperOptIn(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	rb := newRedis(cfg, utilTesting.NewFakeClock(time.Now())).(*redisBackend)
+	defer rb.Close()
+	require.Nil(t, rb.reaper)
+
+	cfg.(*viper.Viper).Set("backfillTTL", time.Minute)
+	reaper := newBackfillReaper(cfg, rb, redsync)
+	require.NotNil(t, reaper)
+	defer reaper.close()
+	require.Equal(t, 6*time.Second, reaper.interval)
+}
+
+func TestBackfillReaperSkipsLockedRound(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	cfg.(*viper.Viper).Set("backfillTTL", time.Minute)
+	cfg.(*viper.Viper).Set("backfillReapInterval", time.Hour)
+	clock := utilTesting.NewFakeClock(time.Now())
+	rb := newRedis(cfg, clock).(*redisBackend)
+	defer rb.Close()
+	ctx := utilTesting.NewContext(t)
+
+	require.NoError(t, rb.CreateBackfill(ctx, &pb.Backfill{Id: "stale"}, nil))
+	clock.Advance(2 * time.Minute)
+
+	// Another replica holds the lock.
+	other := newBackfillReaper(cfg, rb, redsync)
+	defer other.close()
+	require.NoError(t, other.lock.LockContext(ctx))
+
+	require.NoError(t, rb.reaper.reapLocked(ctx))
+	_, _, err := rb.GetBackfill(ctx, "stale")
+	require.NoError(t, err)
+
+	_, err = other.lock.UnlockContext(ctx)
+	require.NoError(t, err)
+	require.NoError(t, rb.reaper.reapLocked(ctx))
+	_, _, err = rb.GetBackfill(ctx, "stale")
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestBackfillReaperExtendsLock(t *testing.T) {
+	defer func(expiry time.Duration) { roundLockExpiry = expiry }(roundLockExpiry)
+	roundLockExpiry = 300 * time.Millisecond
+
+	// Keys only expire as miniredis is fast forwarded.
+	mredis, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mredis.Close()
+
+	cfg := viper.New()
+	cfg.Set("redis.hostname", mredis.Host())
+	cfg.Set("redis.port", mredis.Port())
+	cfg.Set("backfillTTL", time.Minute)
+	cfg.Set("backfillReapInterval", time.Hour)
+	clock := utilTesting.NewFakeClock(time.Now())
+	rb := newRedis(cfg, clock).(*redisBackend)
+	defer rb.Close()
+	ctx := utilTesting.NewContext(t)
+
+	require.NoError(t, rb.CreateBackfill(ctx, &pb.Backfill{Id: "stale"}, nil))
+	clock.Advance(2 * time.Minute)
+
+	// The round waits for the Backfill, which is locked as by the frontend.
+	m := rb.NewMutex("stale")
+	require.NoError(t, m.Lock(ctx))
+	errs := make(chan error, 1)
+	go func() {
+		errs <- rb.reaper.reapLocked(ctx)
+	}()
+
+	// The round outlives the lock's expiry, yet no other replica joins it.
+	for i := 0; i < 2; i++ {
+		time.Sleep(roundLockExpiry / 2)
+		mredis.FastForward(roundLockExpiry * 2 / 3)
+	}
+	other := newBackfillReaper(cfg, rb, redsync)
+	defer other.close()
+	require.Equal(t, rs.ErrFailed, other.lock.LockContext(ctx))
+
+	_, err = m.Unlock(ctx)
+	require.NoError(t, err)
+	require.NoError(t, <-errs)
+	_, _, err = rb.GetBackfill(ctx, "stale")
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,97 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/sirupsen/logrus"
+)
+
+// maxRedirects bounds the number of MOVED or ASK redirects followed for a
+// single command.
+const maxRedirects = 3
+
+// redirectConn is a redis.Conn which follows MOVED and ASK redirects returned
+// by Do, which Redis may return while its topology is changing.  The command is
+// sent again to the node named by the redirect, on a connection dialed for
+// that command only.  Pipelined commands (Send, Flush, Receive) are not
+// redirected.
+type redirectConn struct {
+	redis.Conn
+	dial func(addr string) (redis.Conn, error)
+}
+
+func (c *redirectConn) Do(commandName string, args ...interface{}) (interface{}, error) {
+	reply, err := c.Conn.Do(commandName, args...)
+
+	for i := 0; i < maxRedirects; i++ {
+		addr, asking, ok := parseRedirect(err)
+		if !ok {
+			return reply, err
+		}
+
+		redisLogger.WithFields(logrus.Fields{
+			"command": commandName,
+			"address": addr,
+			"asking":  asking,
+		}).Debug("following redis redirect")
+		reply, err = c.doAt(addr, asking, commandName, args...)
+	}
+
+	return reply, err
+}
+
+// doAt sends the command to the node at addr, preceded by ASKING if the
+// redirect was an ASK redirect.
+func (c *redirectConn) doAt(addr string, asking bool, commandName string, args ...interface{}) (interface{}, error) {
+	conn, err := c.dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	defer handleConnectionClose(&conn)
+
+	if asking {
+		if _, err = conn.Do("ASKING"); err != nil {
+			return nil, err
+		}
+	}
+
+	return conn.Do(commandName, args...)
+}
+
+// parseRedirect returns the address of the node named by a MOVED or ASK error,
+// of the form "MOVED <slot> <host>:<port>".
+func parseRedirect(err error) (addr string, asking bool, ok bool) {
+	redisErr, isRedisErr := err.(redis.Error)
+	if !isRedisErr {
+		return "", false, false
+	}
+
+	fields := strings.Fields(string(redisErr))
+	if len(fields) != 3 {
+		return "", false, false
+	}
+
+	switch fields[0] {
+	case "MOVED":
+		return fields[2], false, true
+	case "ASK":
+		return fields[2], true, true
+	default:
+		return "", false, false
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/require"
+)
+
+// stubConn replies to Do with the error of the given node, or "OK" once the
+// node has no error left, and records the commands it received.
+type stubConn struct {
+	redis.Conn
+	errs     []error
+	commands []string
+	closed   bool
+}
+
+func (c *stubConn) Do(commandName string, args ...interface{}) (interface{}, error) {
+	c.commands = append(c.commands, commandName)
+	if commandName == "ASKING" {
+		return "OK", nil
+	}
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		return nil, err
+	}
+	return "OK", nil
+}
+
+func (c *stubConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestRedirectConn(t *testing.T) {
+	tests := []struct {
+		description  string
+		errs         []error
+		nodes        map[string]*stubConn
+		wantErr      bool
+		wantCommands map[string][]string
+	}{
+		{
+			description: "no redirect",
+			nodes:       map[string]*stubConn{},
+		},
+		{
+			description: "moved once",
+			errs:        []error{redis.Error("MOVED 3999 127.0.0.1:6381")},
+			nodes: map[string]*stubConn{
+				"127.0.0.1:6381": {},
+			},
+			wantCommands: map[string][]string{
+				"127.0.0.1:6381": {"SET"},
+			},
+		},
+		{
+			description: "ask sends asking first",
+			errs:        []error{redis.Error("ASK 3999 127.0.0.1:6381")},
+			nodes: map[string]*stubConn{
+				"127.0.0.1:6381": {},
+			},
+			wantCommands: map[string][]string{
+				"127.0.0.1:6381": {"ASKING", "SET"},
+			},
+		},
+		{
+			description: "redirects are bounded",
+			errs:        []error{redis.Error("MOVED 3999 127.0.0.1:6381")},
+			nodes: map[string]*stubConn{
+				"127.0.0.1:6381": {errs: []error{
+					redis.Error("MOVED 3999 127.0.0.1:6381"),
+					redis.Error("MOVED 3999 127.0.0.1:6381"),
+					redis.Error("MOVED 3999 127.0.0.1:6381"),
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			description: "other errors are returned",
+			errs:        []error{redis.Error("ERR wrong number of arguments")},
+			nodes:       map[string]*stubConn{},
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			conn := &redirectConn{
+				Conn: &stubConn{errs: test.errs},
+				dial: func(addr string) (redis.Conn, error) {
+					node, ok := test.nodes[addr]
+					if !ok {
+						return nil, fmt.Errorf("unexpected dial to %s", addr)
+					}
+					return node, nil
+				},
+			}
+
+			reply, err := conn.Do("SET", "key", "value")
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "OK", reply)
+
+			for addr, commands := range test.wantCommands {
+				require.Equal(t, commands, test.nodes[addr].commands)
+				require.True(t, test.nodes[addr].closed)
+			}
+		})
+	}
+}
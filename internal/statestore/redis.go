@@ -16,6 +16,8 @@ package statestore
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"time"
@@ -27,6 +29,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/util"
 )
 
 var (
@@ -56,27 +59,102 @@ func (rb redisBackend) Unlock(ctx context.Context) (bool, error) {
 	return rb.mutex.UnlockContext(ctx)
 }
 
+// roundLockExpiry is how long the lock of a round, such as a backfill reaper
+// round, outlives a replica which died while holding it.  The lock is extended
+// while a round runs, so that rounds longer than this aren't joined by another
+// replica.
+var roundLockExpiry = 30 * time.Second
+
+// RunLocked runs fn if no other replica holds the lock with the given name,
+// and returns nil without running it otherwise.
+func (rb *redisBackend) RunLocked(ctx context.Context, key string, fn func(context.Context) error) error {
+	// A single try, replicas which don't get the lock skip this round.
+	lock := redsync.NewMutex(fmt.Sprintf("lock/%s", key), rs.WithTries(1), rs.WithExpiry(roundLockExpiry))
+	return runLocked(ctx, key, lock, fn)
+}
+
+// runLocked runs fn holding lock, unless another replica holds it.  Should the
+// lock be lost, fn's context is canceled, as another replica may have started
+// a round.
+func runLocked(ctx context.Context, name string, lock *rs.Mutex, fn func(context.Context) error) error {
+	if err := lock.LockContext(ctx); err != nil {
+		if err == rs.ErrFailed {
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		if _, err := lock.UnlockContext(ctx); err != nil && ctx.Err() == nil {
+			redisLogger.WithError(err).Errorf("error on %s unlock", name)
+		}
+	}()
+
+	lockedCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer keepLocked(lockedCtx, cancel, name, lock)()
+
+	return fn(lockedCtx)
+}
+
+// keepLocked extends the lock every third of its expiry until the returned
+// function is called, canceling the round if the lock is lost.
+func keepLocked(ctx context.Context, cancel context.CancelFunc, name string, lock *rs.Mutex) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(roundLockExpiry / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if ok, err := lock.ExtendContext(ctx); !ok {
+					redisLogger.WithError(err).Warningf("lost the %s lock, stopping the round", name)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
 type redisBackend struct {
 	healthCheckPool *redis.Pool
 	redisPool       *redis.Pool
 	cfg             config.View
 	mutex           *rs.Mutex
+	clock           util.Clock
+	reaper          *backfillReaper
 }
 
 // Close the connection to the database.
 func (rb *redisBackend) Close() error {
+	if rb.reaper != nil {
+		rb.reaper.close()
+	}
 	return rb.redisPool.Close()
 }
 
 // newRedis creates a statestore.Service backed by Redis database.
-func newRedis(cfg config.View) Service {
+func newRedis(cfg config.View, clock util.Clock) Service {
 	pool := GetRedisPool(cfg)
 	redsync = rs.New(rsredigo.NewPool(pool))
-	return &redisBackend{
+	rb := &redisBackend{
 		healthCheckPool: getHealthCheckPool(cfg),
 		redisPool:       pool,
 		cfg:             cfg,
+		clock:           clock,
 	}
+	rb.reaper = newBackfillReaper(cfg, rb, redsync)
+	return rb
 }
 
 func getHealthCheckPool(cfg config.View) *redis.Pool {
@@ -92,6 +170,7 @@ func getHealthCheckPool(cfg config.View) *redis.Pool {
 		masterAddr := getMasterAddr(cfg)
 		healthCheckURL = redisURLFromAddr(masterAddr, cfg, cfg.GetBool("redis.usePassword"))
 	}
+	dialOptions := redisDialOptions(cfg, healthCheckTimeout)
 
 	return &redis.Pool{
 		MaxIdle:      maxIdle,
@@ -103,17 +182,21 @@ func getHealthCheckPool(cfg config.View) *redis.Pool {
 			if ctx != nil && ctx.Err() != nil {
 				return nil, ctx.Err()
 			}
-			return redis.DialURL(healthCheckURL, redis.DialConnectTimeout(healthCheckTimeout), redis.DialReadTimeout(healthCheckTimeout))
+			return redis.DialURL(healthCheckURL, dialOptions...)
 		},
 	}
 }
 
 // GetRedisPool configures a new pool to connect to redis given the config.
+// Commands on the pool's connections fail with DeadlineExceeded once they run
+// longer than redis.commandTimeout, if it is set.
 func GetRedisPool(cfg config.View) *redis.Pool {
 	var dialFunc func(context.Context) (redis.Conn, error)
 	maxIdle := cfg.GetInt("redis.pool.maxIdle")
 	maxActive := cfg.GetInt("redis.pool.maxActive")
 	idleTimeout := cfg.GetDuration("redis.pool.idleTimeout")
+	commandTimeout := cfg.GetDuration("redis.commandTimeout")
+	dialOptions := redisDialOptions(cfg, idleTimeout)
 
 	if cfg.IsSet("redis.sentinelHostname") {
 		sentinelPool := getSentinelPool(cfg)
@@ -139,7 +222,7 @@ func GetRedisPool(cfg config.View) *redis.Pool {
 			}
 
 			masterURL := redisURLFromAddr(fmt.Sprintf("%s:%s", masterInfo[0], masterInfo[1]), cfg, cfg.GetBool("redis.usePassword"))
-			return redis.DialURL(masterURL, redis.DialConnectTimeout(idleTimeout), redis.DialReadTimeout(idleTimeout))
+			return redis.DialURL(masterURL, dialOptions...)
 		}
 	} else {
 		masterAddr := getMasterAddr(cfg)
@@ -148,17 +231,32 @@ func GetRedisPool(cfg config.View) *redis.Pool {
 			if ctx != nil && ctx.Err() != nil {
 				return nil, ctx.Err()
 			}
-			return redis.DialURL(masterURL, redis.DialConnectTimeout(idleTimeout), redis.DialReadTimeout(idleTimeout))
+			return redis.DialURL(masterURL, dialOptions...)
 		}
 	}
 
+	redirectDial := func(addr string) (redis.Conn, error) {
+		redirectURL := redisURLFromAddr(addr, cfg, cfg.GetBool("redis.usePassword"))
+		conn, err := redis.DialURL(redirectURL, dialOptions...)
+		if err != nil {
+			return nil, err
+		}
+		return withCommandTimeout(conn, commandTimeout), nil
+	}
+
 	return &redis.Pool{
 		MaxIdle:      maxIdle,
 		MaxActive:    maxActive,
 		IdleTimeout:  idleTimeout,
 		Wait:         true,
 		TestOnBorrow: testOnBorrow,
-		DialContext:  dialFunc,
+		DialContext: func(ctx context.Context) (redis.Conn, error) {
+			conn, err := dialFunc(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return &redirectConn{Conn: withCommandTimeout(conn, commandTimeout), dial: redirectDial}, nil
+		},
 	}
 }
 
@@ -169,6 +267,7 @@ func getSentinelPool(cfg config.View) *redis.Pool {
 
 	sentinelAddr := getSentinelAddr(cfg)
 	sentinelURL := redisURLFromAddr(sentinelAddr, cfg, cfg.GetBool("redis.sentinelUsePassword"))
+	dialOptions := redisDialOptions(cfg, idleTimeout)
 	return &redis.Pool{
 		MaxIdle:      maxIdle,
 		MaxActive:    maxActive,
@@ -180,7 +279,7 @@ func getSentinelPool(cfg config.View) *redis.Pool {
 				return nil, ctx.Err()
 			}
 			redisLogger.WithField("sentinelAddr", sentinelAddr).Debug("Attempting to connect to Redis Sentinel")
-			return redis.DialURL(sentinelURL, redis.DialConnectTimeout(idleTimeout), redis.DialReadTimeout(idleTimeout))
+			return redis.DialURL(sentinelURL, dialOptions...)
 		},
 	}
 }
@@ -225,6 +324,9 @@ func redisURLFromAddr(addr string, cfg config.View, usePassword bool) string {
 
 	// Add redis user and password to connection url if they exist
 	redisURL := "redis://"
+	if cfg.GetBool("redis.tls.enabled") {
+		redisURL = "rediss://"
+	}
 
 	if usePassword {
 		passwordFile := cfg.GetString("redis.passwordPath")
@@ -239,6 +341,54 @@ func redisURLFromAddr(addr string, cfg config.View, usePassword bool) string {
 	return redisURL + addr
 }
 
+// redisDialOptions returns the options of dialing redis with the timeout.
+// With redis.tls.enabled, connections are made over TLS, see redisTLSConfig.
+func redisDialOptions(cfg config.View, timeout time.Duration) []redis.DialOption {
+	options := []redis.DialOption{redis.DialConnectTimeout(timeout), redis.DialReadTimeout(timeout)}
+	if !cfg.GetBool("redis.tls.enabled") {
+		return options
+	}
+
+	tlsConfig, err := redisTLSConfig(cfg)
+	if err != nil {
+		redisLogger.Fatalf("cannot load the Redis TLS configuration, desc: %s", err.Error())
+	}
+	return append(options, redis.DialTLSConfig(tlsConfig))
+}
+
+// redisTLSConfig returns the TLS configuration of redis connections.  The
+// server certificate is verified against the CA certificate of
+// redis.tls.caCert, or the system roots if it is not set, unless
+// redis.tls.insecureSkipVerify is set.  The client certificate of
+// redis.tls.clientCert and redis.tls.clientKey is presented if they are set.
+func redisTLSConfig(cfg config.View) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.GetBool("redis.tls.insecureSkipVerify"),
+	}
+
+	if caCertFile := cfg.GetString("redis.tls.caCert"); caCertFile != "" {
+		caCertData, err := ioutil.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the CA certificate from file %s: %w", caCertFile, err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCertData) {
+			return nil, fmt.Errorf("no CA certificate found in file %s", caCertFile)
+		}
+	}
+
+	clientCertFile, clientKeyFile := cfg.GetString("redis.tls.clientCert"), cfg.GetString("redis.tls.clientKey")
+	if clientCertFile != "" || clientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
 func handleConnectionClose(conn *redis.Conn) {
 	err := (*conn).Close()
 	if err != nil {
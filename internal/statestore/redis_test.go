@@ -1,11 +1,22 @@
 package statestore
 
 import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis/v2"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	utilTesting "open-match.dev/open-match/internal/util/testing"
 	"open-match.dev/open-match/pkg/pb"
+	certgenTesting "open-match.dev/open-match/tools/certgen/testing"
 )
 
 func TestNewMutex(t *testing.T) {
@@ -31,3 +42,142 @@ func TestNewMutex(t *testing.T) {
 	require.True(t, b)
 
 }
+
+func TestRunLocked(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	service := New(cfg)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	ran := 0
+	err := service.RunLocked(ctx, "round", func(ctx context.Context) error {
+		ran++
+		// Another replica skips the round while this one runs it.
+		return service.RunLocked(ctx, "round", func(context.Context) error {
+			ran++
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, ran)
+
+	// The lock is released after the round, and fn's error is returned.
+	err = service.RunLocked(ctx, "round", func(context.Context) error {
+		ran++
+		return status.Error(codes.Internal, "failed round")
+	})
+	require.Equal(t, codes.Internal, status.Code(err))
+	require.Equal(t, 2, ran)
+}
+
+func TestRedisTLS(t *testing.T) {
+	pubData, privData, err := certgenTesting.CreateCertificateAndPrivateKeyForTesting([]string{"127.0.0.1", "localhost"})
+	require.NoError(t, err)
+	cert, err := tls.X509KeyPair(pubData, privData)
+	require.NoError(t, err)
+
+	mredis, err := miniredis.RunTLS(&tls.Config{Certificates: []tls.Certificate{cert}})
+	require.NoError(t, err)
+	defer mredis.Close()
+
+	dir, err := ioutil.TempDir("", "redis-tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	caCertFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, ioutil.WriteFile(caCertFile, pubData, 0600))
+
+	createTLSRedisConfig := func() *viper.Viper {
+		cfg := viper.New()
+		cfg.Set("redis.hostname", mredis.Host())
+		cfg.Set("redis.port", mredis.Port())
+		cfg.Set("redis.pool.maxIdle", 5)
+		cfg.Set("redis.pool.idleTimeout", time.Second)
+		cfg.Set("redis.pool.healthCheckTimeout", 100*time.Millisecond)
+		cfg.Set("redis.pool.maxActive", 5)
+		cfg.Set("redis.tls.enabled", true)
+		return cfg
+	}
+
+	testCases := []struct {
+		description string
+		configure   func(cfg *viper.Viper)
+		expectCode  codes.Code
+	}{
+		{
+			description: "server certificate verified against the CA certificate",
+			configure: func(cfg *viper.Viper) {
+				cfg.Set("redis.tls.caCert", caCertFile)
+			},
+			expectCode: codes.OK,
+		},
+		{
+			description: "server certificate not verified with insecureSkipVerify",
+			configure: func(cfg *viper.Viper) {
+				cfg.Set("redis.tls.insecureSkipVerify", true)
+			},
+			expectCode: codes.OK,
+		},
+		{
+			description: "unknown server certificate",
+			configure:   func(cfg *viper.Viper) {},
+			expectCode:  codes.Unavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := createTLSRedisConfig()
+			tc.configure(cfg)
+			service := New(cfg)
+			defer service.Close()
+			ctx := utilTesting.NewContext(t)
+
+			err := service.CreateTicket(ctx, &pb.Ticket{Id: "tls-ticket"})
+			require.Equal(t, tc.expectCode.String(), status.Convert(err).Code().String())
+			if tc.expectCode != codes.OK {
+				return
+			}
+
+			ticket, err := service.GetTicket(ctx, "tls-ticket")
+			require.NoError(t, err)
+			require.Equal(t, "tls-ticket", ticket.Id)
+		})
+	}
+}
+
+func TestRedisTLSConfig(t *testing.T) {
+	require := require.New(t)
+
+	pubData, privData, err := certgenTesting.CreateCertificateAndPrivateKeyForTesting([]string{"localhost"})
+	require.NoError(err)
+
+	dir, err := ioutil.TempDir("", "redis-tls")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	require.NoError(ioutil.WriteFile(certFile, pubData, 0600))
+	require.NoError(ioutil.WriteFile(keyFile, privData, 0600))
+
+	cfg := viper.New()
+	cfg.Set("redis.tls.caCert", certFile)
+	cfg.Set("redis.tls.clientCert", certFile)
+	cfg.Set("redis.tls.clientKey", keyFile)
+	tlsConfig, err := redisTLSConfig(cfg)
+	require.NoError(err)
+	require.NotNil(tlsConfig.RootCAs)
+	require.Len(tlsConfig.Certificates, 1)
+	require.False(tlsConfig.InsecureSkipVerify)
+
+	cfg = viper.New()
+	cfg.Set("redis.tls.caCert", keyFile)
+	_, err = redisTLSConfig(cfg)
+	require.Error(err)
+
+	cfg = viper.New()
+	cfg.Set("redis.tls.clientCert", certFile)
+	_, err = redisTLSConfig(cfg)
+	require.Error(err)
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	matchesMadeStat     = "stats/matches"
+	ticketsAssignedStat = "stats/ticketsAssigned"
+
+	// statsWindow is the period over which recent activity is reported.
+	// Activity is counted in one key per second, which expires once it is
+	// outside of the window.
+	statsWindow = time.Minute
+)
+
+// Stats contains aggregate matchmaking numbers.
+type Stats struct {
+	ActiveTickets             int64
+	Backfills                 int64
+	MatchesLastMinute         int64
+	TicketsAssignedLastMinute int64
+}
+
+// RecordMatchesMade adds count to the number of matches made in the current second.
+func (rb *redisBackend) RecordMatchesMade(ctx context.Context, count int) error {
+	return rb.incrementStat(ctx, matchesMadeStat, count)
+}
+
+// RecordTicketsAssigned adds count to the number of tickets assigned in the current second.
+func (rb *redisBackend) RecordTicketsAssigned(ctx context.Context, count int) error {
+	return rb.incrementStat(ctx, ticketsAssignedStat, count)
+}
+
+func (rb *redisBackend) incrementStat(ctx context.Context, name string, count int) error {
+	if count == 0 {
+		return nil
+	}
+
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "incrementStat, name: %s, failed to connect to redis: %v", name, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	key := statKey(name, rb.clock.Now())
+	err = redisConn.Send("MULTI")
+	if err == nil {
+		err = redisConn.Send("INCRBY", key, count)
+	}
+	if err == nil {
+		err = redisConn.Send("EXPIRE", key, int64(2*statsWindow/time.Second))
+	}
+	if err == nil {
+		_, err = redisConn.Do("EXEC")
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "failed to increment stat %s", name)
+		return internalErrorf("%v", err)
+	}
+
+	return nil
+}
+
+// GetStats returns the aggregate matchmaking numbers. It only reads counters
+// and set sizes, and doesn't scan Tickets or Backfills.
+func (rb *redisBackend) GetStats(ctx context.Context) (*Stats, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "GetStats, failed to connect to redis: %v", err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	s := &Stats{}
+
+	s.ActiveTickets, err = redis.Int64(redisConn.Do("SCARD", allTickets))
+	if err != nil {
+		return nil, internalErrorf("error counting indexed tickets %v", err)
+	}
+
+	s.Backfills, err = redis.Int64(redisConn.Do("HLEN", allBackfills))
+	if err != nil {
+		return nil, internalErrorf("error counting indexed backfills %v", err)
+	}
+
+	now := rb.clock.Now()
+	s.MatchesLastMinute, err = sumRecentStat(redisConn, matchesMadeStat, now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.TicketsAssignedLastMinute, err = sumRecentStat(redisConn, ticketsAssignedStat, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func sumRecentStat(redisConn redis.Conn, name string, now time.Time) (int64, error) {
+	seconds := int(statsWindow / time.Second)
+	keys := make([]interface{}, 0, seconds)
+	for i := 0; i < seconds; i++ {
+		keys = append(keys, statKey(name, now.Add(-time.Duration(i)*time.Second)))
+	}
+
+	values, err := redis.Int64s(redisConn.Do("MGET", keys...))
+	if err != nil {
+		return 0, internalErrorf("error getting stat %s %v", name, err)
+	}
+
+	var sum int64
+	for _, v := range values {
+		sum += v
+	}
+	return sum, nil
+}
+
+// statKey returns the key counting the stat in the second of t.  The name is a
+// hash tag, so that the keys of one stat are in the same Redis Cluster slot and
+// can be read with a single MGET.
+func statKey(name string, t time.Time) string {
+	return fmt.Sprintf("{%s}/%d", name, t.Unix())
+}
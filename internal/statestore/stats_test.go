@@ -0,0 +1,43 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatKeysShareHashTag(t *testing.T) {
+	// Redis Cluster only hashes the part of the key between the first { and
+	// the following }, so every second of a stat maps to the same slot and
+	// sumRecentStat's MGET doesn't fail with CROSSSLOT.
+	hashTag := func(key string) string {
+		start := strings.Index(key, "{")
+		require.NotEqual(t, -1, start, key)
+		end := strings.Index(key[start+1:], "}")
+		require.Greater(t, end, 0, key)
+		return key[start+1 : start+1+end]
+	}
+
+	now := time.Now()
+	for i := 0; i < int(statsWindow/time.Second); i++ {
+		key := statKey(matchesMadeStat, now.Add(-time.Duration(i)*time.Second))
+		require.Equal(t, matchesMadeStat, hashTag(key))
+	}
+	require.NotEqual(t, statKey(matchesMadeStat, now), statKey(matchesMadeStat, now.Add(-time.Second)))
+}
@@ -24,7 +24,7 @@ import (
 )
 
 // New creates a new in memory Redis instance with Sentinel for testing.
-func New(t *testing.T, cfg config.Mutable) func() {
+func New(t testing.TB, cfg config.Mutable) func() {
 	mredis := miniredis.NewMiniRedis()
 	err := mredis.StartAddr("localhost:0")
 	if err != nil {
@@ -60,7 +60,7 @@ func New(t *testing.T, cfg config.Mutable) func() {
 }
 
 // NewStoreServiceForTesting creates a new statestore service for testing
-func NewStoreServiceForTesting(t *testing.T, cfg config.Mutable) (statestore.Service, func()) {
+func NewStoreServiceForTesting(t testing.TB, cfg config.Mutable) (statestore.Service, func()) {
 	closer := New(t, cfg)
 	s := statestore.New(cfg)
 
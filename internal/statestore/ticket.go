@@ -20,20 +20,181 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff"
-	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/gomodule/redigo/redis"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/pkg/pb"
 )
 
 const (
 	allTickets        = "allTickets"
 	proposedTicketIDs = "proposed_ticket_ids"
+	playerTickets     = "playerTickets"
+	// ticketPlayers maps the ids of indexed tickets with a player id to the player id.
+	ticketPlayers = "ticketPlayers"
+	// ticketPools maps the ids of indexed tickets in a saturation pool to the pool.
+	ticketPools = "ticketPools"
+	// ticketRevisions maps the ids of updated tickets to their number of updates.
+	ticketRevisions = "ticketRevisions"
+	// ticketExpirations is the sorted set of unassigned tickets created with a
+	// ticketTTL, scored by the time they expire.
+	ticketExpirations = "ticketExpirations"
+	// ticketCreateTimes is the sorted set of tickets not known to be assigned,
+	// scored by their creation time.  It is only kept while a fallback
+	// assignment is configured.
+	ticketCreateTimes = "ticketCreateTimes"
+	// pendingReleaseScopes is the set of all scopes which have been used for
+	// pending release, so that tickets can be removed from every scope.
+	pendingReleaseScopes = "pending_release_scopes"
 )
 
+// pendingReleaseScope returns the configured scope of the tickets this
+// instance puts in pending release. Tickets proposed in a scope are only
+// hidden from queries of the same scope. The empty, global, scope hides
+// tickets from every query.
+//
+// The scope is process wide: tickets are proposed by the synchronizer and
+// hidden by the query service's cache, which every backend of a deployment
+// shares. It therefore isolates deployments sharing a Redis, each with all of
+// its components configured with the same scope, and not the backends of a
+// single deployment.
+func (rb *redisBackend) pendingReleaseScope() string {
+	return rb.cfg.GetString("pendingReleaseScope")
+}
+
+// pendingReleaseKey returns the key of the sorted set of proposed tickets for the scope.
+func pendingReleaseKey(scope string) string {
+	if scope == "" {
+		return proposedTicketIDs
+	}
+	return proposedTicketIDs + "/" + scope
+}
+
+// playerIndexKey returns the key of the set of indexed tickets of the player.
+func playerIndexKey(playerID string) string {
+	return "playerIndex/" + playerID
+}
+
+// poolIndexKey returns the key of the set of indexed tickets of the saturation pool.
+func poolIndexKey(pool string) string {
+	return "poolIndex/" + pool
+}
+
+// SaturationPool returns the pool whose population is tracked for the ticket,
+// which is the first tag of poolSaturation.tags the ticket has.  It returns an
+// empty string if the ticket has none of them.
+func SaturationPool(cfg config.View, ticket *pb.Ticket) string {
+	pools := cfg.GetStringSlice("poolSaturation.tags")
+	if len(pools) == 0 {
+		return ""
+	}
+
+	tags := make(map[string]struct{}, len(ticket.GetSearchFields().GetTags()))
+	for _, tag := range ticket.GetSearchFields().GetTags() {
+		tags[tag] = struct{}{}
+	}
+	for _, pool := range pools {
+		if _, ok := tags[pool]; ok {
+			return pool
+		}
+	}
+	return ""
+}
+
+// ticketTTL returns how long tickets live after their creation.  Zero keeps
+// tickets until they are deleted.
+func (rb *redisBackend) ticketTTL() time.Duration {
+	return rb.cfg.GetDuration("ticketTTL")
+}
+
+// trackPools returns whether tickets are indexed by saturation pool.
+func (rb *redisBackend) trackPools() bool {
+	return len(rb.cfg.GetStringSlice("poolSaturation.tags")) > 0
+}
+
+// trackCreateTimes returns whether the creation times of tickets are recorded
+// for the frontend's fallback assignment.
+func (rb *redisBackend) trackCreateTimes() bool {
+	return rb.cfg.GetString("fallbackAssignment.connection") != "" && rb.cfg.GetDuration("fallbackAssignment.timeout") > 0
+}
+
+// deletePlayerTicketScript removes the ticket recorded for a player, only if it
+// is still the given ticket, since the player may have created a newer one.
+var deletePlayerTicketScript = redis.NewScript(1, `
+if redis.call("HGET", KEYS[1], ARGV[1]) == ARGV[2] then
+	return redis.call("HDEL", KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+// updateTicketScript overwrites an indexed ticket, increments its revision and
+// moves it to the index of its new saturation pool, all at once so that a
+// ticket deindexed, deleted or assigned meanwhile is never overwritten nor
+// indexed again.  It returns 0 if the ticket is not indexed or does not exist,
+// and -1 if the ticket or its pool changed since they were read.
+//
+// KEYS: the ticket, allTickets, ticketRevisions, ticketPools, the index of the
+// ticket's previous pool, the index of its new pool.
+// ARGV: the ticket value, "1" to keep the ticket's TTL, "1" to track the
+// saturation pool, the previous and new pools of the ticket or "" if it has
+// none, the ticket value read.
+var updateTicketScript = redis.NewScript(6, `
+if redis.call("SISMEMBER", KEYS[2], KEYS[1]) == 0 then
+	return 0
+end
+
+local current = redis.call("GET", KEYS[1])
+if not current then
+	return 0
+end
+if current ~= ARGV[6] then
+	return -1
+end
+if ARGV[3] == "1" and (redis.call("HGET", KEYS[4], KEYS[1]) or "") ~= ARGV[4] then
+	return -1
+end
+
+if ARGV[2] == "1" then
+	redis.call("SET", KEYS[1], ARGV[1], "KEEPTTL")
+else
+	redis.call("SET", KEYS[1], ARGV[1])
+end
+redis.call("HINCRBY", KEYS[3], KEYS[1], 1)
+
+if ARGV[3] == "1" then
+	if ARGV[4] ~= "" and ARGV[4] ~= ARGV[5] then
+		redis.call("SREM", KEYS[5], KEYS[1])
+	end
+	if ARGV[5] == "" then
+		redis.call("HDEL", KEYS[4], KEYS[1])
+	else
+		redis.call("SADD", KEYS[6], KEYS[1])
+		redis.call("HSET", KEYS[4], KEYS[1], ARGV[5])
+	end
+end
+return 1
+`)
+
+// allPendingReleaseKeys returns the keys of the proposed ticket sets of every known scope, including the global scope.
+func allPendingReleaseKeys(redisConn redis.Conn) ([]string, error) {
+	scopes, err := redis.Strings(redisConn.Do("SMEMBERS", pendingReleaseScopes))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{proposedTicketIDs}
+	for _, scope := range scopes {
+		keys = append(keys, pendingReleaseKey(scope))
+	}
+	return keys, nil
+}
+
 // CreateTicket creates a new Ticket in the state storage. If the id already exists, it will be overwritten.
+// With a ticketTTL, the Ticket expires once it lives that long without being assigned.
 func (rb *redisBackend) CreateTicket(ctx context.Context, ticket *pb.Ticket) error {
 	redisConn, err := rb.redisPool.GetContext(ctx)
 	if err != nil {
@@ -41,16 +202,108 @@ func (rb *redisBackend) CreateTicket(ctx context.Context, ticket *pb.Ticket) err
 	}
 	defer handleConnectionClose(&redisConn)
 
-	value, err := proto.Marshal(ticket)
+	value, err := rb.marshalTicket(ticket)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to marshal the ticket proto, id: %s", ticket.GetId())
-		return status.Errorf(codes.Internal, "%v", err)
+		return internalErrorf("%v", err)
 	}
 
-	_, err = redisConn.Do("SET", ticket.GetId(), value)
-	if err != nil {
+	for _, cmd := range rb.createTicketCommands(ticket, value) {
+		if err = redisConn.Send(cmd[0].(string), cmd[1:]...); err != nil {
+			err = errors.Wrapf(err, "failed to set the value for ticket, id: %s", ticket.GetId())
+			return internalErrorf("%v", err)
+		}
+	}
+	if _, err = redisConn.Do(""); err != nil {
 		err = errors.Wrapf(err, "failed to set the value for ticket, id: %s", ticket.GetId())
-		return status.Errorf(codes.Internal, "%v", err)
+		return internalErrorf("%v", err)
+	}
+
+	return nil
+}
+
+// createTicketCommands returns the commands storing a new Ticket, along with
+// its expiration if a ticketTTL is configured, and its creation time if a
+// fallback assignment is.
+func (rb *redisBackend) createTicketCommands(ticket *pb.Ticket, value []byte) [][]interface{} {
+	cmds := [][]interface{}{{"SET", ticket.GetId(), value}}
+	if ttl := rb.ticketTTL(); ttl > 0 {
+		cmds = [][]interface{}{
+			{"SET", ticket.GetId(), value, "PX", ttl.Milliseconds()},
+			{"ZADD", ticketExpirations, rb.clock.Now().Add(ttl).UnixNano(), ticket.GetId()},
+		}
+	}
+	if rb.trackCreateTimes() {
+		created, err := ptypes.Timestamp(ticket.GetCreateTime())
+		if err != nil {
+			created = rb.clock.Now()
+		}
+		cmds = append(cmds, []interface{}{"ZADD", ticketCreateTimes, created.UnixNano(), ticket.GetId()})
+	}
+	return cmds
+}
+
+// CreateTickets creates and indexes new Tickets, pipelining the commands of all Tickets.
+// Since the Tickets are new, they have no previous player or pool index to leave.
+func (rb *redisBackend) CreateTickets(ctx context.Context, tickets []*pb.Ticket) error {
+	if len(tickets) == 0 {
+		return nil
+	}
+
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "CreateTickets, failed to connect to redis: %v", err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	// sentFor holds the id of the ticket each pipelined command is for.
+	var sentFor []string
+	for _, ticket := range tickets {
+		value, err := rb.marshalTicket(ticket)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to marshal the ticket proto, id: %s", ticket.GetId())
+			return internalErrorf("%v", err)
+		}
+
+		cmds := append(rb.createTicketCommands(ticket, value),
+			[]interface{}{"SADD", allTickets, ticket.GetId()},
+		)
+		if ticket.PlayerId != "" {
+			cmds = append(cmds,
+				[]interface{}{"SADD", playerIndexKey(ticket.PlayerId), ticket.GetId()},
+				[]interface{}{"HSET", ticketPlayers, ticket.GetId(), ticket.PlayerId},
+			)
+		}
+		if rb.trackPools() {
+			if pool := SaturationPool(rb.cfg, ticket); pool != "" {
+				cmds = append(cmds,
+					[]interface{}{"SADD", poolIndexKey(pool), ticket.GetId()},
+					[]interface{}{"HSET", ticketPools, ticket.GetId(), pool},
+				)
+			}
+		}
+
+		for _, cmd := range cmds {
+			if err = redisConn.Send(cmd[0].(string), cmd[1:]...); err != nil {
+				err = errors.Wrapf(err, "failed to send the commands creating ticket, id: %s", ticket.GetId())
+				return internalErrorf("%v", err)
+			}
+			sentFor = append(sentFor, ticket.GetId())
+		}
+	}
+
+	// An empty command flushes the pipeline and reads all of its replies.
+	replies, err := redis.Values(redisConn.Do(""))
+	if err != nil {
+		err = errors.Wrap(err, "failed to create tickets")
+		return internalErrorf("%v", err)
+	}
+	// A failing command only shows in its own reply.
+	for i, reply := range replies {
+		if replyErr, ok := reply.(redis.Error); ok {
+			err = errors.Wrapf(replyErr, "failed to create ticket, id: %s", sentFor[i])
+			return internalErrorf("%v", err)
+		}
 	}
 
 	return nil
@@ -73,7 +326,7 @@ func (rb *redisBackend) GetTicket(ctx context.Context, id string) (*pb.Ticket, e
 		}
 
 		err = errors.Wrapf(err, "failed to get the ticket from state storage, id: %s", id)
-		return nil, status.Errorf(codes.Internal, "%v", err)
+		return nil, internalErrorf("%v", err)
 	}
 
 	if value == nil {
@@ -82,16 +335,20 @@ func (rb *redisBackend) GetTicket(ctx context.Context, id string) (*pb.Ticket, e
 	}
 
 	ticket := &pb.Ticket{}
-	err = proto.Unmarshal(value, ticket)
+	err = unmarshalTicket(value, ticket)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to unmarshal the ticket proto, id: %s", id)
-		return nil, status.Errorf(codes.Internal, "%v", err)
+		return nil, internalErrorf("%v", err)
 	}
 
 	return ticket, nil
 }
 
-// DeleteTicket removes the Ticket with the specified id from state storage.
+// DeleteTicket removes the Ticket with the specified id from state storage,
+// along with its indexing.  The references derived from its player id are
+// found from both the index and the stored Ticket, so none are left behind
+// even if the Ticket was not deindexed, or was indexed under a player id it
+// no longer has.
 func (rb *redisBackend) DeleteTicket(ctx context.Context, id string) error {
 	redisConn, err := rb.redisPool.GetContext(ctx)
 	if err != nil {
@@ -99,10 +356,329 @@ func (rb *redisBackend) DeleteTicket(ctx context.Context, id string) error {
 	}
 	defer handleConnectionClose(&redisConn)
 
-	_, err = redisConn.Do("DEL", id)
+	return deleteTicket(redisConn, id)
+}
+
+// deleteTicket deletes the Ticket as DeleteTicket does, on the given connection.
+func deleteTicket(redisConn redis.Conn, id string) error {
+	// The Ticket and its indexed player id and pool are read as the Ticket is
+	// deleted.
+	err := redisConn.Send("MULTI")
+	if err != nil {
+		return internalErrorf("%v", errors.Wrap(err, "error starting redis multi"))
+	}
+	for _, cmd := range [][]interface{}{
+		{"HGET", ticketPlayers, id},
+		{"GET", id},
+		{"HGET", ticketPools, id},
+		{"DEL", id},
+		{"HDEL", ticketRevisions, id},
+		{"HDEL", ticketPlayers, id},
+		{"HDEL", ticketPools, id},
+		{"SREM", allTickets, id},
+		{"ZREM", ticketExpirations, id},
+		{"ZREM", ticketCreateTimes, id},
+	} {
+		err = redisConn.Send(cmd[0].(string), cmd[1:]...)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to delete the ticket from state storage, id: %s", id)
+			return internalErrorf("%v", err)
+		}
+	}
+	replies, err := redis.Values(redisConn.Do("EXEC"))
 	if err != nil {
 		err = errors.Wrapf(err, "failed to delete the ticket from state storage, id: %s", id)
-		return status.Errorf(codes.Internal, "%v", err)
+		return internalErrorf("%v", err)
+	}
+
+	indexed, err := redis.String(replies[0], nil)
+	if err != nil && err != redis.ErrNil {
+		err = errors.Wrapf(err, "failed to get the player of ticket, id: %s", id)
+		return internalErrorf("%v", err)
+	}
+	value, err := redis.Bytes(replies[1], nil)
+	if err != nil && err != redis.ErrNil {
+		err = errors.Wrapf(err, "failed to get the ticket from state storage, id: %s", id)
+		return internalErrorf("%v", err)
+	}
+	pool, err := redis.String(replies[2], nil)
+	if err != nil && err != redis.ErrNil {
+		err = errors.Wrapf(err, "failed to get the pool of ticket, id: %s", id)
+		return internalErrorf("%v", err)
+	}
+
+	if pool != "" {
+		_, err = redisConn.Do("SREM", poolIndexKey(pool), id)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to remove ticket from the tickets of pool, id: %s", id)
+			return internalErrorf("%v", err)
+		}
+	}
+
+	for _, playerID := range ticketPlayerIDs(id, indexed, value) {
+		_, err = redisConn.Do("SREM", playerIndexKey(playerID), id)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to remove ticket from the tickets of player, id: %s", id)
+			return internalErrorf("%v", err)
+		}
+
+		_, err = deletePlayerTicketScript.Do(redisConn, playerTickets, playerID, id)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to delete the ticket of player, id: %s", id)
+			return internalErrorf("%v", err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteTickets removes the Tickets with the specified ids from state storage,
+// along with their indexing, as DeleteTicket does.  The Tickets are deleted
+// together in one transaction, and the references derived from their player
+// ids and pools are removed in a single pipeline after it.
+func (rb *redisBackend) DeleteTickets(ctx context.Context, ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "DeleteTickets, failed to connect to redis: %v", err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	idsI := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		idsI = append(idsI, id)
+	}
+	withKey := func(key string) []interface{} {
+		return append([]interface{}{key}, idsI...)
+	}
+
+	// The Tickets and their indexed player ids and pools are read as the
+	// Tickets are deleted.
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return nil, internalErrorf("%v", errors.Wrap(err, "error starting redis multi"))
+	}
+	for _, cmd := range [][]interface{}{
+		append([]interface{}{"HMGET"}, withKey(ticketPlayers)...),
+		append([]interface{}{"MGET"}, idsI...),
+		append([]interface{}{"HMGET"}, withKey(ticketPools)...),
+		append([]interface{}{"DEL"}, idsI...),
+		append([]interface{}{"HDEL"}, withKey(ticketRevisions)...),
+		append([]interface{}{"HDEL"}, withKey(ticketPlayers)...),
+		append([]interface{}{"HDEL"}, withKey(ticketPools)...),
+		append([]interface{}{"SREM"}, withKey(allTickets)...),
+		append([]interface{}{"ZREM"}, withKey(ticketExpirations)...),
+		append([]interface{}{"ZREM"}, withKey(ticketCreateTimes)...),
+	} {
+		err = redisConn.Send(cmd[0].(string), cmd[1:]...)
+		if err != nil {
+			err = errors.Wrap(err, "failed to delete the tickets from state storage")
+			return nil, internalErrorf("%v", err)
+		}
+	}
+	replies, err := redis.Values(redisConn.Do("EXEC"))
+	if err != nil {
+		err = errors.Wrap(err, "failed to delete the tickets from state storage")
+		return nil, internalErrorf("%v", err)
+	}
+
+	indexed, err := redis.Strings(replies[0], nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to get the players of tickets")
+		return nil, internalErrorf("%v", err)
+	}
+	values, err := redis.ByteSlices(replies[1], nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to get the tickets from state storage")
+		return nil, internalErrorf("%v", err)
+	}
+	pools, err := redis.Strings(replies[2], nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to get the pools of tickets")
+		return nil, internalErrorf("%v", err)
+	}
+
+	var deleted []string
+	for i, id := range ids {
+		if values[i] != nil {
+			deleted = append(deleted, id)
+		}
+
+		if pools[i] != "" {
+			err = redisConn.Send("SREM", poolIndexKey(pools[i]), id)
+			if err != nil {
+				err = errors.Wrapf(err, "failed to remove ticket from the tickets of pool, id: %s", id)
+				return nil, internalErrorf("%v", err)
+			}
+		}
+
+		for _, playerID := range ticketPlayerIDs(id, indexed[i], values[i]) {
+			err = redisConn.Send("SREM", playerIndexKey(playerID), id)
+			if err != nil {
+				err = errors.Wrapf(err, "failed to remove ticket from the tickets of player, id: %s", id)
+				return nil, internalErrorf("%v", err)
+			}
+
+			err = deletePlayerTicketScript.Send(redisConn, playerTickets, playerID, id)
+			if err != nil {
+				err = errors.Wrapf(err, "failed to delete the ticket of player, id: %s", id)
+				return nil, internalErrorf("%v", err)
+			}
+		}
+	}
+
+	_, err = redisConn.Do("")
+	if err != nil {
+		err = errors.Wrap(err, "failed to remove the tickets from the indexes")
+		return nil, internalErrorf("%v", err)
+	}
+
+	return deleted, nil
+}
+
+// ticketPlayerIDs returns the player ids a deleted Ticket may be referenced
+// under: the one it was indexed with and the one of the stored Ticket.
+func ticketPlayerIDs(id string, indexed string, value []byte) []string {
+	var ids []string
+	if indexed != "" {
+		ids = append(ids, indexed)
+	}
+	if value == nil {
+		return ids
+	}
+
+	ticket := &pb.Ticket{}
+	if err := unmarshalTicket(value, ticket); err != nil {
+		// A corrupt Ticket is deleted all the same.
+		redisLogger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"id":    id,
+		}).Warning("failed to unmarshal the deleted ticket")
+		return ids
+	}
+	if ticket.PlayerId != "" && ticket.PlayerId != indexed {
+		ids = append(ids, ticket.PlayerId)
+	}
+	return ids
+}
+
+// UpdateTicket overwrites an indexed Ticket in the state storage, increments its revision and moves it to the index of its
+// saturation pool, atomically. This method fails with NotFound if the Ticket does not exist or is no longer indexed.
+// With a ticketTTL, the Ticket keeps its expiration, which requires Redis 6.0 or later.
+func (rb *redisBackend) UpdateTicket(ctx context.Context, ticket *pb.Ticket) error {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "UpdateTicket, id: %s, failed to connect to redis: %v", ticket.GetId(), err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	// The stored ticket is read so that the update is only written if it is
+	// still the same, and in particular still unassigned.
+	current, err := redis.Bytes(redisConn.Do("GET", ticket.GetId()))
+	if err == redis.ErrNil {
+		return status.Errorf(codes.NotFound, "Ticket id: %s not found", ticket.GetId())
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "failed to get the ticket from state storage, id: %s", ticket.GetId())
+		return internalErrorf("%v", err)
+	}
+	stored := &pb.Ticket{}
+	if err = unmarshalTicket(current, stored); err != nil {
+		err = errors.Wrapf(err, "failed to unmarshal the ticket proto, id: %s", ticket.GetId())
+		return internalErrorf("%v", err)
+	}
+	if stored.GetAssignment() != nil {
+		return status.Errorf(codes.NotFound, "Ticket id: %s is assigned", ticket.GetId())
+	}
+
+	value, err := rb.marshalTicket(ticket)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to marshal the ticket proto, id: %s", ticket.GetId())
+		return internalErrorf("%v", err)
+	}
+
+	keepTTL, trackPools, previous := "0", "0", ""
+	if rb.ticketTTL() > 0 {
+		keepTTL = "1"
+	}
+	if rb.trackPools() {
+		trackPools = "1"
+		previous, err = redis.String(redisConn.Do("HGET", ticketPools, ticket.GetId()))
+		if err != nil && err != redis.ErrNil {
+			err = errors.Wrapf(err, "failed to get the pool of ticket, id: %s", ticket.GetId())
+			return internalErrorf("%v", err)
+		}
+	}
+	pool := SaturationPool(rb.cfg, ticket)
+
+	updated, err := redis.Int(updateTicketScript.Do(redisConn,
+		ticket.GetId(), allTickets, ticketRevisions, ticketPools, poolIndexKey(previous), poolIndexKey(pool),
+		value, keepTTL, trackPools, previous, pool, current))
+	if err != nil {
+		err = errors.Wrapf(err, "failed to update the ticket, id: %s", ticket.GetId())
+		return internalErrorf("%v", err)
+	}
+	if updated == 0 {
+		return status.Errorf(codes.NotFound, "Ticket id: %s not found", ticket.GetId())
+	}
+	if updated < 0 {
+		return status.Errorf(codes.FailedPrecondition, "Ticket id: %s was changed while it was updated", ticket.GetId())
+	}
+
+	return nil
+}
+
+// GetTicketRevisions returns the number of updates of every updated Ticket, by Ticket id.
+func (rb *redisBackend) GetTicketRevisions(ctx context.Context) (map[string]int, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "GetTicketRevisions, failed to connect to redis: %v", err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	revisions, err := redis.IntMap(redisConn.Do("HGETALL", ticketRevisions))
+	if err != nil {
+		return nil, internalErrorf("error getting ticket revisions %v", err)
+	}
+
+	return revisions, nil
+}
+
+// GetPlayerTicketID returns the id of the Ticket last recorded for the player, or an empty string if there is none.
+func (rb *redisBackend) GetPlayerTicketID(ctx context.Context, playerID string) (string, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return "", status.Errorf(codes.Unavailable, "GetPlayerTicketID, player id: %s, failed to connect to redis: %v", playerID, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	id, err := redis.String(redisConn.Do("HGET", playerTickets, playerID))
+	if err != nil {
+		if err == redis.ErrNil {
+			return "", nil
+		}
+		err = errors.Wrapf(err, "failed to get the ticket of player, player id: %s", playerID)
+		return "", internalErrorf("%v", err)
+	}
+
+	return id, nil
+}
+
+// SetPlayerTicketID records the Ticket id as the Ticket of the player, replacing any previous record.
+func (rb *redisBackend) SetPlayerTicketID(ctx context.Context, playerID string, ticketID string) error {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "SetPlayerTicketID, player id: %s, failed to connect to redis: %v", playerID, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	_, err = redisConn.Do("HSET", playerTickets, playerID, ticketID)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to set the ticket of player, player id: %s", playerID)
+		return internalErrorf("%v", err)
 	}
 
 	return nil
@@ -119,7 +695,89 @@ func (rb *redisBackend) IndexTicket(ctx context.Context, ticket *pb.Ticket) erro
 	err = redisConn.Send("SADD", allTickets, ticket.Id)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to add ticket to all tickets, id: %s", ticket.Id)
-		return status.Errorf(codes.Internal, "%v", err)
+		return internalErrorf("%v", err)
+	}
+
+	// A Ticket indexed again under another player id leaves the index of the
+	// previous one.
+	previous, err := redis.String(redisConn.Do("HGET", ticketPlayers, ticket.Id))
+	if err != nil && err != redis.ErrNil {
+		err = errors.Wrapf(err, "failed to get the player of ticket, id: %s", ticket.Id)
+		return internalErrorf("%v", err)
+	}
+	if previous != "" && previous != ticket.PlayerId {
+		err = redisConn.Send("SREM", playerIndexKey(previous), ticket.Id)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to remove ticket from the tickets of player, id: %s", ticket.Id)
+			return internalErrorf("%v", err)
+		}
+		if ticket.PlayerId == "" {
+			err = redisConn.Send("HDEL", ticketPlayers, ticket.Id)
+			if err != nil {
+				err = errors.Wrapf(err, "failed to delete the player of ticket, id: %s", ticket.Id)
+				return internalErrorf("%v", err)
+			}
+		}
+	}
+
+	if ticket.PlayerId != "" {
+		err = redisConn.Send("SADD", playerIndexKey(ticket.PlayerId), ticket.Id)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to add ticket to the tickets of player, id: %s", ticket.Id)
+			return internalErrorf("%v", err)
+		}
+
+		err = redisConn.Send("HSET", ticketPlayers, ticket.Id, ticket.PlayerId)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to record the player of ticket, id: %s", ticket.Id)
+			return internalErrorf("%v", err)
+		}
+	}
+
+	if rb.trackPools() {
+		return rb.indexTicketPool(redisConn, ticket)
+	}
+
+	return nil
+}
+
+// indexTicketPool adds the Ticket to the index of its saturation pool,
+// removing it from the one it was indexed in before.
+func (rb *redisBackend) indexTicketPool(redisConn redis.Conn, ticket *pb.Ticket) error {
+	pool := SaturationPool(rb.cfg, ticket)
+
+	previous, err := redis.String(redisConn.Do("HGET", ticketPools, ticket.Id))
+	if err != nil && err != redis.ErrNil {
+		err = errors.Wrapf(err, "failed to get the pool of ticket, id: %s", ticket.Id)
+		return internalErrorf("%v", err)
+	}
+	if previous != "" && previous != pool {
+		err = redisConn.Send("SREM", poolIndexKey(previous), ticket.Id)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to remove ticket from the tickets of pool, id: %s", ticket.Id)
+			return internalErrorf("%v", err)
+		}
+		if pool == "" {
+			err = redisConn.Send("HDEL", ticketPools, ticket.Id)
+			if err != nil {
+				err = errors.Wrapf(err, "failed to delete the pool of ticket, id: %s", ticket.Id)
+				return internalErrorf("%v", err)
+			}
+		}
+	}
+
+	if pool != "" {
+		err = redisConn.Send("SADD", poolIndexKey(pool), ticket.Id)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to add ticket to the tickets of pool, id: %s", ticket.Id)
+			return internalErrorf("%v", err)
+		}
+
+		err = redisConn.Send("HSET", ticketPools, ticket.Id, pool)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to record the pool of ticket, id: %s", ticket.Id)
+			return internalErrorf("%v", err)
+		}
 	}
 
 	return nil
@@ -133,16 +791,91 @@ func (rb *redisBackend) DeindexTicket(ctx context.Context, id string) error {
 	}
 	defer handleConnectionClose(&redisConn)
 
+	playerID, err := redis.String(redisConn.Do("HGET", ticketPlayers, id))
+	if err != nil && err != redis.ErrNil {
+		err = errors.Wrapf(err, "failed to get the player of ticket, id: %s", id)
+		return internalErrorf("%v", err)
+	}
+	if playerID != "" {
+		err = redisConn.Send("SREM", playerIndexKey(playerID), id)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to remove ticket from the tickets of player, id: %s", id)
+			return internalErrorf("%v", err)
+		}
+
+		err = redisConn.Send("HDEL", ticketPlayers, id)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to delete the player of ticket, id: %s", id)
+			return internalErrorf("%v", err)
+		}
+	}
+
+	if rb.trackPools() {
+		pool, err := redis.String(redisConn.Do("HGET", ticketPools, id))
+		if err != nil && err != redis.ErrNil {
+			err = errors.Wrapf(err, "failed to get the pool of ticket, id: %s", id)
+			return internalErrorf("%v", err)
+		}
+		if pool != "" {
+			err = redisConn.Send("SREM", poolIndexKey(pool), id)
+			if err != nil {
+				err = errors.Wrapf(err, "failed to remove ticket from the tickets of pool, id: %s", id)
+				return internalErrorf("%v", err)
+			}
+
+			err = redisConn.Send("HDEL", ticketPools, id)
+			if err != nil {
+				err = errors.Wrapf(err, "failed to delete the pool of ticket, id: %s", id)
+				return internalErrorf("%v", err)
+			}
+		}
+	}
+
 	err = redisConn.Send("SREM", allTickets, id)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to remove ticket from all tickets, id: %s", id)
-		return status.Errorf(codes.Internal, "%v", err)
+		return internalErrorf("%v", err)
 	}
 
 	return nil
 }
 
-// GetIndexedIds returns the ids of all tickets currently indexed.
+// GetIndexedPlayerTicketIDs returns the ids of the indexed tickets of the player.
+func (rb *redisBackend) GetIndexedPlayerTicketIDs(ctx context.Context, playerID string) ([]string, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "GetIndexedPlayerTicketIDs, player id: %s, failed to connect to redis: %v", playerID, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	ids, err := redis.Strings(redisConn.Do("SMEMBERS", playerIndexKey(playerID)))
+	if err != nil {
+		err = errors.Wrapf(err, "failed to get the tickets of player, player id: %s", playerID)
+		return nil, internalErrorf("%v", err)
+	}
+
+	return ids, nil
+}
+
+// GetPoolTicketCount returns the number of indexed tickets in the saturation pool.
+func (rb *redisBackend) GetPoolTicketCount(ctx context.Context, pool string) (int64, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return 0, status.Errorf(codes.Unavailable, "GetPoolTicketCount, pool: %s, failed to connect to redis: %v", pool, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	count, err := redis.Int64(redisConn.Do("SCARD", poolIndexKey(pool)))
+	if err != nil {
+		err = errors.Wrapf(err, "failed to count the tickets of pool, pool: %s", pool)
+		return 0, internalErrorf("%v", err)
+	}
+
+	return count, nil
+}
+
+// GetIndexedIds returns the ids of all tickets currently indexed.  The index is read with SSCAN so that
+// Redis isn't blocked by one large reply, but the ids are still returned in a single set.
 func (rb *redisBackend) GetIndexedIDSet(ctx context.Context) (map[string]struct{}, error) {
 	redisConn, err := rb.redisPool.GetContext(ctx)
 	if err != nil {
@@ -151,32 +884,106 @@ func (rb *redisBackend) GetIndexedIDSet(ctx context.Context) (map[string]struct{
 	defer handleConnectionClose(&redisConn)
 
 	ttl := rb.cfg.GetDuration("pendingReleaseTimeout")
-	curTime := time.Now()
+	curTime := rb.clock.Now()
 	endTimeInt := curTime.Add(time.Hour).UnixNano()
 	startTimeInt := curTime.Add(-ttl).UnixNano()
 
 	// Filter out tickets that are fetched but not assigned within ttl time (ms).
+	// Tickets proposed in the global scope, or the scope of this instance, are pending.
 	idsInPendingReleases, err := redis.Strings(redisConn.Do("ZRANGEBYSCORE", proposedTicketIDs, startTimeInt, endTimeInt))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error getting pending release %v", err)
+		return nil, internalErrorf("error getting pending release %v", err)
 	}
 
-	idsIndexed, err := redis.Strings(redisConn.Do("SMEMBERS", allTickets))
+	if scope := rb.pendingReleaseScope(); scope != "" {
+		idsInScope, err := redis.Strings(redisConn.Do("ZRANGEBYSCORE", pendingReleaseKey(scope), startTimeInt, endTimeInt))
+		if err != nil {
+			return nil, internalErrorf("error getting pending release of scope %s %v", scope, err)
+		}
+		idsInPendingReleases = append(idsInPendingReleases, idsInScope...)
+	}
+
+	idsExpired, err := redis.Strings(redisConn.Do("ZRANGEBYSCORE", ticketExpirations, "-inf", curTime.UnixNano()))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error getting all indexed ticket ids %v", err)
+		return nil, internalErrorf("error getting expired tickets %v", err)
 	}
 
-	r := make(map[string]struct{}, len(idsIndexed))
-	for _, id := range idsIndexed {
-		r[id] = struct{}{}
+	r := make(map[string]struct{})
+	err = scanSet(redisConn, allTickets, getScanCount(rb.cfg), func(ids []string) {
+		for _, id := range ids {
+			r[id] = struct{}{}
+		}
+	})
+	if err != nil {
+		return nil, internalErrorf("error getting all indexed ticket ids %v", err)
 	}
 	for _, id := range idsInPendingReleases {
 		delete(r, id)
 	}
+	for _, id := range idsExpired {
+		delete(r, id)
+	}
+	deleteExpiredTickets(redisConn, idsExpired)
 
 	return r, nil
 }
 
+// scanSet calls fn with the members of the set, about count at a time, iterating with SSCAN so that large sets
+// aren't read in a single reply which blocks Redis.  It doesn't bound the memory of the caller, which still
+// decides what to keep of each page.  Members may be passed more than once.
+func scanSet(redisConn redis.Conn, key string, count int, fn func([]string)) error {
+	cursor := "0"
+	for {
+		reply, err := redis.Values(redisConn.Do("SSCAN", key, cursor, "COUNT", count))
+		if err != nil {
+			return err
+		}
+		if len(reply) != 2 {
+			return errors.Errorf("unexpected SSCAN reply of length %d", len(reply))
+		}
+
+		cursor, err = redis.String(reply[0], nil)
+		if err != nil {
+			return err
+		}
+		members, err := redis.Strings(reply[1], nil)
+		if err != nil {
+			return err
+		}
+		fn(members)
+
+		if cursor == "0" {
+			return nil
+		}
+	}
+}
+
+// getScanCount returns the number of members redis.scanCount asks Redis to return per SSCAN call.
+func getScanCount(cfg config.View) int {
+	const (
+		name             = "redis.scanCount"
+		defaultScanCount = 1000
+	)
+
+	if !cfg.IsSet(name) || cfg.GetInt(name) <= 0 {
+		return defaultScanCount
+	}
+	return cfg.GetInt(name)
+}
+
+// deleteExpiredTickets removes what is left of the expired tickets, such as
+// their indexing.  Failures are only logged, as the next call retries them.
+func deleteExpiredTickets(redisConn redis.Conn, ids []string) {
+	for _, id := range ids {
+		if err := deleteTicket(redisConn, id); err != nil {
+			redisLogger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"id":    id,
+			}).Warning("failed to delete the expired ticket")
+		}
+	}
+}
+
 // GetTickets returns multiple tickets from storage.  Missing tickets are
 // silently ignored.
 func (rb *redisBackend) GetTickets(ctx context.Context, ids []string) ([]*pb.Ticket, error) {
@@ -198,7 +1005,7 @@ func (rb *redisBackend) GetTickets(ctx context.Context, ids []string) ([]*pb.Tic
 	ticketBytes, err := redis.ByteSlices(redisConn.Do("MGET", queryParams...))
 	if err != nil {
 		err = errors.Wrapf(err, "failed to lookup tickets %v", ids)
-		return nil, status.Errorf(codes.Internal, "%v", err)
+		return nil, internalErrorf("%v", err)
 	}
 
 	r := make([]*pb.Ticket, 0, len(ids))
@@ -207,10 +1014,10 @@ func (rb *redisBackend) GetTickets(ctx context.Context, ids []string) ([]*pb.Tic
 		// Tickets may be deleted by the time we read it from redis.
 		if b != nil {
 			t := &pb.Ticket{}
-			err = proto.Unmarshal(b, t)
+			err = unmarshalTicket(b, t)
 			if err != nil {
 				err = errors.Wrapf(err, "failed to unmarshal ticket from redis, key %s", ids[i])
-				return nil, status.Errorf(codes.Internal, "%v", err)
+				return nil, internalErrorf("%v", err)
 			}
 			r = append(r, t)
 		}
@@ -266,10 +1073,10 @@ func (rb *redisBackend) UpdateAssignments(ctx context.Context, req *pb.AssignTic
 			})
 		} else {
 			t := &pb.Ticket{}
-			err = proto.Unmarshal(ticketByte, t)
+			err = unmarshalTicket(ticketByte, t)
 			if err != nil {
 				err = errors.Wrapf(err, "failed to unmarshal ticket from redis %s", ids[i])
-				return nil, nil, status.Errorf(codes.Internal, "%v", err)
+				return nil, nil, internalErrorf("%v", err)
 			}
 			tickets = append(tickets, t)
 		}
@@ -284,7 +1091,7 @@ func (rb *redisBackend) UpdateAssignments(ctx context.Context, req *pb.AssignTic
 		ticket.Assignment = idToA[ticket.Id]
 
 		var ticketByte []byte
-		ticketByte, err = proto.Marshal(ticket)
+		ticketByte, err = rb.marshalTicket(ticket)
 		if err != nil {
 			return nil, nil, status.Errorf(codes.Internal, "failed to marshal ticket %s", ticket.GetId())
 		}
@@ -323,24 +1130,211 @@ func (rb *redisBackend) UpdateAssignments(ctx context.Context, req *pb.AssignTic
 		assignedTickets = append(assignedTickets, ticket)
 	}
 
+	// Assigned tickets expire after the assignedDeleteTimeout instead of the ticketTTL.
+	if rb.ticketTTL() > 0 && len(assignedTickets) > 0 {
+		args := []interface{}{ticketExpirations}
+		for _, ticket := range assignedTickets {
+			args = append(args, ticket.Id)
+		}
+		if _, err = redisConn.Do("ZREM", args...); err != nil {
+			err = errors.Wrap(err, "failed to remove the expirations of assigned tickets")
+			return nil, nil, internalErrorf("%v", err)
+		}
+	}
+	// Assigned tickets no longer need a fallback assignment.
+	if rb.trackCreateTimes() && len(assignedTickets) > 0 {
+		args := []interface{}{ticketCreateTimes}
+		for _, ticket := range assignedTickets {
+			args = append(args, ticket.Id)
+		}
+		if _, err = redisConn.Do("ZREM", args...); err != nil {
+			err = errors.Wrap(err, "failed to remove the creation times of assigned tickets")
+			return nil, nil, internalErrorf("%v", err)
+		}
+	}
+
 	return resp, assignedTickets, nil
 }
 
-// GetAssignments returns the assignment associated with the input ticket id
-func (rb *redisBackend) GetAssignments(ctx context.Context, id string, callback func(*pb.Assignment) error) error {
+// GetTicketIDsCreatedBefore returns the ids of the tickets created before t
+// which are not known to be assigned.  Creation times are only recorded while
+// a fallback assignment is configured.
+func (rb *redisBackend) GetTicketIDsCreatedBefore(ctx context.Context, t time.Time) ([]string, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "GetTicketIDsCreatedBefore, failed to connect to redis: %v", err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	ids, err := redis.Strings(redisConn.Do("ZRANGEBYSCORE", ticketCreateTimes, "-inf", t.UnixNano()))
+	if err != nil {
+		err = errors.Wrap(err, "failed to get the tickets created before the time")
+		return nil, internalErrorf("%v", err)
+	}
+	return ids, nil
+}
+
+// assignUnassignedTicketsScript assigns the tickets which are indexed, not
+// pending release, and still hold the value they were read with, and returns
+// their ids.  The others are left untouched.
+//
+// KEYS: allTickets, ticketCreateTimes, ticketExpirations, followed by the
+// pending release keys the tickets must not be pending in.
+// ARGV: the score from which tickets are still pending, the milliseconds the
+// assigned tickets live, or 0 to keep them, then the id, read value and
+// assigned value of every ticket.
+var assignUnassignedTicketsScript = redis.NewScript(-1, `
+local assigned = {}
+for i = 3, #ARGV, 3 do
+	local id = ARGV[i]
+	local pending = false
+	for k = 4, #KEYS do
+		local score = redis.call("ZSCORE", KEYS[k], id)
+		if score and tonumber(score) >= tonumber(ARGV[1]) then
+			pending = true
+		end
+	end
+	if not pending and redis.call("SISMEMBER", KEYS[1], id) == 1 and redis.call("GET", id) == ARGV[i + 1] then
+		if tonumber(ARGV[2]) > 0 then
+			redis.call("SET", id, ARGV[i + 2], "PX", ARGV[2])
+		else
+			redis.call("SET", id, ARGV[i + 2])
+		end
+		redis.call("ZREM", KEYS[2], id)
+		redis.call("ZREM", KEYS[3], id)
+		table.insert(assigned, id)
+	end
+end
+return assigned
+`)
+
+// AssignUnassignedTickets assigns the assignment to the tickets which are
+// indexed, unassigned and not pending release in the global or configured
+// scope, and returns the assigned tickets.  A ticket changed after it is read,
+// such as assigned by a backend, is left as it is.
+func (rb *redisBackend) AssignUnassignedTickets(ctx context.Context, ids []string, assignment *pb.Assignment) ([]*pb.Ticket, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "AssignUnassignedTickets, failed to connect to redis: %v", err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	idsI := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		idsI = append(idsI, id)
+	}
+	values, err := redis.ByteSlices(redisConn.Do("MGET", idsI...))
+	if err != nil {
+		err = errors.Wrap(err, "failed to get the tickets to assign")
+		return nil, internalErrorf("%v", err)
+	}
+
+	keys := []interface{}{allTickets, ticketCreateTimes, ticketExpirations, proposedTicketIDs}
+	if scope := rb.pendingReleaseScope(); scope != "" {
+		keys = append(keys, pendingReleaseKey(scope))
+	}
+	now := rb.clock.Now()
+	assignedTTL := rb.cfg.GetDuration("assignedDeleteTimeout").Milliseconds()
+	if assignedTTL < 0 {
+		assignedTTL = 0
+	}
+	args := make([]interface{}, 0, 1+len(keys)+2+3*len(ids))
+	args = append(args, len(keys))
+	args = append(args, keys...)
+	args = append(args, now.Add(-rb.cfg.GetDuration("pendingReleaseTimeout")).UnixNano(), assignedTTL)
+
+	// Tickets which are gone or assigned no longer need a fallback assignment.
+	stale := []interface{}{ticketCreateTimes}
+	tickets := make(map[string]*pb.Ticket)
+	for i, value := range values {
+		if value == nil {
+			stale = append(stale, ids[i])
+			continue
+		}
+		t := &pb.Ticket{}
+		if err = unmarshalTicket(value, t); err != nil {
+			err = errors.Wrapf(err, "failed to unmarshal ticket from redis %s", ids[i])
+			return nil, internalErrorf("%v", err)
+		}
+		if t.GetAssignment() != nil {
+			stale = append(stale, ids[i])
+			continue
+		}
+
+		t.Assignment = assignment
+		assignedValue, err := rb.marshalTicket(t)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to marshal the ticket proto, id: %s", ids[i])
+			return nil, internalErrorf("%v", err)
+		}
+		args = append(args, ids[i], value, assignedValue)
+		tickets[ids[i]] = t
+	}
+
+	if len(stale) > 1 {
+		if _, err = redisConn.Do("ZREM", stale...); err != nil {
+			err = errors.Wrap(err, "failed to remove the creation times of assigned tickets")
+			return nil, internalErrorf("%v", err)
+		}
+	}
+	if len(tickets) == 0 {
+		return nil, nil
+	}
+
+	assignedIDs, err := redis.Strings(assignUnassignedTicketsScript.Do(redisConn, args...))
+	if err != nil {
+		err = errors.Wrap(err, "failed to assign the tickets")
+		return nil, internalErrorf("%v", err)
+	}
+	assigned := make([]*pb.Ticket, 0, len(assignedIDs))
+	for _, id := range assignedIDs {
+		assigned = append(assigned, tickets[id])
+	}
+	return assigned, nil
+}
+
+// GetAssignments returns the assignment associated with the input ticket id.
+// With a ticketTTL, an unassigned ticket which expires fails the polling with
+// DeadlineExceeded, so that watchers can tell it from a deleted ticket.
+func (rb *redisBackend) GetAssignments(ctx context.Context, id string, pollInterval time.Duration, callback func(*pb.Assignment) error) error {
 	redisConn, err := rb.redisPool.GetContext(ctx)
 	if err != nil {
 		return status.Errorf(codes.Unavailable, "GetAssignments, id: %s, failed to connect to redis: %v", id, err)
 	}
 	defer handleConnectionClose(&redisConn)
 
+	// expiresAt is the ticketExpirations score of the unassigned ticket, or
+	// zero if it doesn't expire.  It is kept from the last poll which found
+	// the ticket, as expired tickets may be cleaned up before the next one.
+	// Scores are doubles, so the clock is compared as one too.
+	var expiresAt float64
 	backoffOperation := func() error {
 		var ticket *pb.Ticket
 		ticket, err = rb.GetTicket(ctx, id)
 		if err != nil {
+			if status.Code(err) == codes.NotFound && expiresAt > 0 && float64(rb.clock.Now().UnixNano()) >= expiresAt {
+				return backoff.Permanent(status.Errorf(codes.DeadlineExceeded, "ticket expired, id: %s", id))
+			}
 			return backoff.Permanent(err)
 		}
 
+		if ticket.GetAssignment() != nil {
+			expiresAt = 0
+		} else if rb.ticketTTL() > 0 {
+			score, err := redis.Float64(redisConn.Do("ZSCORE", ticketExpirations, id))
+			if err != nil && err != redis.ErrNil {
+				err = errors.Wrapf(err, "failed to get the expiration of ticket, id: %s", id)
+				return backoff.Permanent(internalErrorf("%v", err))
+			}
+			if err == nil {
+				expiresAt = score
+			}
+		}
+
 		err = callback(ticket.GetAssignment())
 		if err != nil {
 			return backoff.Permanent(err)
@@ -349,42 +1343,169 @@ func (rb *redisBackend) GetAssignments(ctx context.Context, id string, callback
 		return status.Error(codes.Unavailable, "listening on assignment updates, waiting for the next backoff")
 	}
 
-	err = backoff.Retry(backoffOperation, rb.newConstantBackoffStrategy())
+	err = backoff.Retry(backoffOperation, rb.newConstantBackoffStrategy(pollInterval))
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-// AddTicketsToPendingRelease appends new proposed tickets to the proposed sorted set with current timestamp
+// AddTicketsToPendingRelease appends new proposed tickets to the proposed sorted set of the configured scope with current timestamp
 func (rb *redisBackend) AddTicketsToPendingRelease(ctx context.Context, ids []string) error {
+	return rb.addTicketsToPendingRelease(ctx, "AddTicketsToPendingRelease", ids, rb.clock.Now(), false)
+}
+
+// proposeTicketsScript adds tickets to pending release unless any of them is
+// already pending, in which case it returns the id of that ticket and adds
+// none.
+//
+// KEYS: the pending release key the tickets are added to, followed by the other
+// pending release keys they must not be pending in.
+// ARGV: the score from which tickets are still pending, the score of the
+// proposal, the ids of the tickets.
+var proposeTicketsScript = redis.NewScript(-1, `
+for _, key in ipairs(KEYS) do
+	for i = 3, #ARGV do
+		local score = redis.call("ZSCORE", key, ARGV[i])
+		if score and tonumber(score) >= tonumber(ARGV[1]) then
+			return ARGV[i]
+		end
+	end
+end
+for i = 3, #ARGV do
+	redis.call("ZADD", KEYS[1], ARGV[2], ARGV[i])
+end
+return ""
+`)
+
+// ProposeTickets adds the tickets to pending release of the configured scope,
+// all at once and only if none of them is pending release in the global or
+// configured scope already.
+func (rb *redisBackend) ProposeTickets(ctx context.Context, ids []string) error {
 	if len(ids) == 0 {
 		return nil
 	}
 
 	redisConn, err := rb.redisPool.GetContext(ctx)
 	if err != nil {
-		return status.Errorf(codes.Unavailable, "AddTicketsToPendingRelease, failed to connect to redis: %v", err)
+		return status.Errorf(codes.Unavailable, "ProposeTickets, failed to connect to redis: %v", err)
 	}
 	defer handleConnectionClose(&redisConn)
 
-	currentTime := time.Now().UnixNano()
-	cmds := make([]interface{}, 0, 2*len(ids)+1)
-	cmds = append(cmds, proposedTicketIDs)
+	scope := rb.pendingReleaseScope()
+	keys := []interface{}{pendingReleaseKey(scope)}
+	if scope != "" {
+		_, err = redisConn.Do("SADD", pendingReleaseScopes, scope)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to record pending release scope %s", scope)
+			return internalErrorf("%v", err)
+		}
+		keys = append(keys, proposedTicketIDs)
+	}
+
+	now := rb.clock.Now()
+	args := make([]interface{}, 0, len(keys)+len(ids)+3)
+	args = append(args, len(keys))
+	args = append(args, keys...)
+	args = append(args, now.Add(-rb.cfg.GetDuration("pendingReleaseTimeout")).UnixNano(), now.UnixNano())
 	for _, id := range ids {
-		cmds = append(cmds, currentTime, id)
+		args = append(args, id)
+	}
+
+	pending, err := redis.String(proposeTicketsScript.Do(redisConn, args...))
+	if err != nil {
+		err = errors.Wrap(err, "failed to propose tickets")
+		return internalErrorf("%v", err)
 	}
+	if pending != "" {
+		return status.Errorf(codes.Aborted, "ticket %s is already proposed", pending)
+	}
+
+	return nil
+}
+
+// cooldownTicketsScript sets the pending release score of the tickets, except
+// for those already pending release until later.  Stale scores, of tickets no
+// longer pending, are always replaced.
+//
+// KEYS: the pending release key.
+// ARGV: the score from which tickets are still pending, the score of the
+// cooldown, the ids of the tickets.
+var cooldownTicketsScript = redis.NewScript(1, `
+for i = 3, #ARGV do
+	local score = redis.call("ZSCORE", KEYS[1], ARGV[i])
+	if not score or tonumber(score) < tonumber(ARGV[1]) or tonumber(score) < tonumber(ARGV[2]) then
+		redis.call("ZADD", KEYS[1], ARGV[2], ARGV[i])
+	end
+end
+return 0
+`)
+
+// AddTicketsToCooldown hides tickets from GetIndexedIDSet of the configured scope for the cooldown.
+// The tickets are added to the pending release as if they were proposed cooldown before the
+// pendingReleaseTimeout, so tickets which are already pending release until later keep their
+// release time.
+func (rb *redisBackend) AddTicketsToCooldown(ctx context.Context, ids []string, cooldown time.Duration) error {
+	proposed := rb.clock.Now().Add(cooldown - rb.cfg.GetDuration("pendingReleaseTimeout"))
+	return rb.addTicketsToPendingRelease(ctx, "AddTicketsToCooldown", ids, proposed, true)
+}
+
+// ReserveTickets hides tickets from GetIndexedIDSet of the configured scope for the lease.
+// The tickets are added to the pending release as if they were proposed lease before the
+// pendingReleaseTimeout, overwriting the release time of tickets already pending release.
+func (rb *redisBackend) ReserveTickets(ctx context.Context, ids []string, lease time.Duration) error {
+	proposed := rb.clock.Now().Add(lease - rb.cfg.GetDuration("pendingReleaseTimeout"))
+	return rb.addTicketsToPendingRelease(ctx, "ReserveTickets", ids, proposed, false)
+}
 
-	_, err = redisConn.Do("ZADD", cmds...)
+// addTicketsToPendingRelease adds the tickets to pending release of the
+// configured scope as if they were proposed at proposed.  With onlyLater, a
+// ticket already pending release until later keeps its release time.
+func (rb *redisBackend) addTicketsToPendingRelease(ctx context.Context, caller string, ids []string, proposed time.Time, onlyLater bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "%s, failed to connect to redis: %v", caller, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	scope := rb.pendingReleaseScope()
+	if scope != "" {
+		_, err = redisConn.Do("SADD", pendingReleaseScopes, scope)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to record pending release scope %s", scope)
+			return internalErrorf("%v", err)
+		}
+	}
+
+	score := proposed.UnixNano()
+	if onlyLater {
+		args := make([]interface{}, 0, len(ids)+3)
+		args = append(args, pendingReleaseKey(scope), rb.clock.Now().Add(-rb.cfg.GetDuration("pendingReleaseTimeout")).UnixNano(), score)
+		for _, id := range ids {
+			args = append(args, id)
+		}
+		_, err = cooldownTicketsScript.Do(redisConn, args...)
+	} else {
+		cmds := make([]interface{}, 0, 2*len(ids)+1)
+		cmds = append(cmds, pendingReleaseKey(scope))
+		for _, id := range ids {
+			cmds = append(cmds, score, id)
+		}
+		_, err = redisConn.Do("ZADD", cmds...)
+	}
 	if err != nil {
 		err = errors.Wrap(err, "failed to append proposed tickets to pending release")
-		return status.Error(codes.Internal, err.Error())
+		return internalErrorf("%v", err)
 	}
 
 	return nil
 }
 
-// DeleteTicketsFromPendingRelease deletes tickets from the proposed sorted set
+// DeleteTicketsFromPendingRelease deletes tickets from the proposed sorted sets of all scopes
 func (rb *redisBackend) DeleteTicketsFromPendingRelease(ctx context.Context, ids []string) error {
 	if len(ids) == 0 {
 		return nil
@@ -396,21 +1517,33 @@ func (rb *redisBackend) DeleteTicketsFromPendingRelease(ctx context.Context, ids
 	}
 	defer handleConnectionClose(&redisConn)
 
-	cmds := make([]interface{}, 0, len(ids)+1)
-	cmds = append(cmds, proposedTicketIDs)
-	for _, id := range ids {
-		cmds = append(cmds, id)
+	// Tickets are removed from every scope, as they may have been proposed by
+	// an instance with a different scope.
+	keys, err := allPendingReleaseKeys(redisConn)
+	if err != nil {
+		err = errors.Wrap(err, "failed to get pending release scopes")
+		return internalErrorf("%v", err)
 	}
 
-	_, err = redisConn.Do("ZREM", cmds...)
-	if err != nil {
-		err = errors.Wrap(err, "failed to delete proposed tickets from pending release")
-		return status.Error(codes.Internal, err.Error())
+	for _, key := range keys {
+		cmds := make([]interface{}, 0, len(ids)+1)
+		cmds = append(cmds, key)
+		for _, id := range ids {
+			cmds = append(cmds, id)
+		}
+
+		_, err = redisConn.Do("ZREM", cmds...)
+		if err != nil {
+			err = errors.Wrap(err, "failed to delete proposed tickets from pending release")
+			return internalErrorf("%v", err)
+		}
 	}
 
 	return nil
 }
 
+// ReleaseAllTickets releases all pending tickets of the configured scope back to active.
+// Tickets pending in the global scope or the scopes of other deployments are left pending.
 func (rb *redisBackend) ReleaseAllTickets(ctx context.Context) error {
 	redisConn, err := rb.redisPool.GetContext(ctx)
 	if err != nil {
@@ -418,12 +1551,58 @@ func (rb *redisBackend) ReleaseAllTickets(ctx context.Context) error {
 	}
 	defer handleConnectionClose(&redisConn)
 
-	_, err = redisConn.Do("DEL", proposedTicketIDs)
+	_, err = redisConn.Do("DEL", pendingReleaseKey(rb.pendingReleaseScope()))
 	return err
 }
 
-func (rb *redisBackend) newConstantBackoffStrategy() backoff.BackOff {
-	backoffStrat := backoff.NewConstantBackOff(rb.cfg.GetDuration("backoff.initialInterval"))
+// GetPendingReleaseTime returns when the pending release of the ticket times out, if
+// it was proposed in the global scope or the configured scope within the timeout.
+func (rb *redisBackend) GetPendingReleaseTime(ctx context.Context, id string) (time.Time, bool, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return time.Time{}, false, status.Errorf(codes.Unavailable, "GetPendingReleaseTime, failed to connect to redis: %v", err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	keys := []string{proposedTicketIDs}
+	if scope := rb.pendingReleaseScope(); scope != "" {
+		keys = append(keys, pendingReleaseKey(scope))
+	}
+
+	// The ticket stays pending until the timeout of its latest proposal.
+	var proposed float64
+	found := false
+	for _, key := range keys {
+		score, err := redis.Float64(redisConn.Do("ZSCORE", key, id))
+		if err == redis.ErrNil {
+			continue
+		}
+		if err != nil {
+			return time.Time{}, false, internalErrorf("error getting pending release of ticket %s: %v", id, err)
+		}
+		if !found || score > proposed {
+			proposed = score
+			found = true
+		}
+	}
+	if !found {
+		return time.Time{}, false, nil
+	}
+
+	releaseTime := time.Unix(0, int64(proposed)).Add(rb.cfg.GetDuration("pendingReleaseTimeout"))
+	if !releaseTime.After(rb.clock.Now()) {
+		return time.Time{}, false, nil
+	}
+	return releaseTime, true, nil
+}
+
+// newConstantBackoffStrategy retries every interval, or every
+// backoff.initialInterval if interval is not positive.
+func (rb *redisBackend) newConstantBackoffStrategy(interval time.Duration) backoff.BackOff {
+	if interval <= 0 {
+		interval = rb.cfg.GetDuration("backoff.initialInterval")
+	}
+	backoffStrat := backoff.NewConstantBackOff(interval)
 	return backoff.BackOff(backoffStrat)
 }
 
@@ -25,6 +25,10 @@ import (
 
 	"github.com/Bose/minisentinel"
 	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/gomodule/redigo/redis"
 	"github.com/rs/xid"
 	"github.com/spf13/viper"
@@ -112,7 +116,7 @@ func TestGetAssignmentBeforeSet(t *testing.T) {
 
 	var assignmentResp *pb.Assignment
 
-	err := service.GetAssignments(ctx, "id", func(assignment *pb.Assignment) error {
+	err := service.GetAssignments(ctx, "id", 0, func(assignment *pb.Assignment) error {
 		assignmentResp = assignment
 		return nil
 	})
@@ -121,6 +125,32 @@ func TestGetAssignmentBeforeSet(t *testing.T) {
 	require.Nil(t, assignmentResp)
 }
 
+func TestGetAssignmentsPollInterval(t *testing.T) {
+	const pollInterval = 200 * time.Millisecond
+
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+	require.NoError(t, service.CreateTicket(ctx, &pb.Ticket{Id: "1"}))
+
+	var polls []time.Time
+	done := errors.New("done")
+	err := service.GetAssignments(ctx, "1", pollInterval, func(assignment *pb.Assignment) error {
+		polls = append(polls, time.Now())
+		if len(polls) == 3 {
+			return done
+		}
+		return nil
+	})
+	require.Equal(t, done, err)
+	for i := 1; i < len(polls); i++ {
+		require.GreaterOrEqual(t, int64(polls[i].Sub(polls[i-1])), int64(pollInterval))
+	}
+}
+
 func TestGetAssignmentNormal(t *testing.T) {
 	cfg, closer := createRedis(t, true, "")
 	defer closer()
@@ -140,7 +170,7 @@ func TestGetAssignmentNormal(t *testing.T) {
 	callbackCount := 0
 	returnedErr := errors.New("some errors")
 
-	err = service.GetAssignments(ctx, "1", func(assignment *pb.Assignment) error {
+	err = service.GetAssignments(ctx, "1", 0, func(assignment *pb.Assignment) error {
 		assignmentResp = assignment
 
 		if callbackCount == 5 {
@@ -163,7 +193,7 @@ func TestGetAssignmentNormal(t *testing.T) {
 	ctx, cancel = context.WithCancel(context.Background())
 	cancel()
 	service = New(cfg)
-	err = service.GetAssignments(ctx, "1", func(assignment *pb.Assignment) error { return nil })
+	err = service.GetAssignments(ctx, "1", 0, func(assignment *pb.Assignment) error { return nil })
 	require.Error(t, err)
 	require.Equal(t, codes.Unavailable.String(), status.Convert(err).Code().String())
 	require.Contains(t, status.Convert(err).Message(), "GetAssignments, id: 1, failed to connect to redis:")
@@ -287,6 +317,28 @@ func TestUpdateAssignments(t *testing.T) {
 				assignedTicketsIDs: []string{},
 			},
 		},
+		{
+			description: "some tickets don't exist, others assigned, response failures expected",
+			request: &pb.AssignTicketsRequest{
+				Assignments: []*pb.AssignmentGroup{
+					{
+						TicketIds:  []string{"1", "11111"},
+						Assignment: &pb.Assignment{Connection: "2"},
+					},
+				},
+			},
+			expected: expected{
+				resp: &pb.AssignTicketsResponse{
+					Failures: []*pb.AssignmentFailure{{
+						TicketId: "11111",
+						Cause:    pb.AssignmentFailure_TICKET_NOT_FOUND,
+					}},
+				},
+				errCode:            codes.OK,
+				errMessage:         "",
+				assignedTicketsIDs: []string{"1"},
+			},
+		},
 		{
 			description: "wrong value, error expected",
 			request: &pb.AssignTicketsRequest{
@@ -577,6 +629,150 @@ func TestDeleteTicket(t *testing.T) {
 	require.Contains(t, status.Convert(err).Message(), "DeleteTicket, id: 12345, failed to connect to redis:")
 }
 
+func TestDeleteTicketLeavesNoReferences(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	store := New(cfg)
+	defer store.Close()
+	ctx := utilTesting.NewContext(t)
+
+	rb := store.(*instrumentedService).s.(*redisBackend)
+	conn, err := rb.redisPool.GetContext(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	newTicket := func(id string) *pb.Ticket {
+		return &pb.Ticket{
+			Id:       id,
+			PlayerId: "player-" + id,
+			SearchFields: &pb.SearchFields{
+				DoubleArgs: map[string]float64{"level": 10, "mmr": 1500},
+				StringArgs: map[string]string{"region": "europe-west1", "mode": "ranked"},
+				Tags:       []string{"", "beta", "crossplay"},
+			},
+		}
+	}
+
+	// Deleted the way the frontend deletes tickets.
+	deindexed := newTicket("deindexed")
+	require.NoError(t, store.CreateTicket(ctx, deindexed))
+	require.NoError(t, store.IndexTicket(ctx, deindexed))
+	require.NoError(t, store.SetPlayerTicketID(ctx, deindexed.PlayerId, deindexed.Id))
+	require.NoError(t, store.AddTicketsToPendingRelease(ctx, []string{deindexed.Id}))
+	require.NoError(t, store.DeindexTicket(ctx, deindexed.Id))
+	require.NoError(t, store.DeleteTicket(ctx, deindexed.Id))
+	require.NoError(t, store.DeleteTicketsFromPendingRelease(ctx, []string{deindexed.Id}))
+
+	// Indexed under two player ids, and deleted without being deindexed.
+	moved := newTicket("moved")
+	require.NoError(t, store.CreateTicket(ctx, moved))
+	require.NoError(t, store.IndexTicket(ctx, moved))
+	moved.PlayerId = "player-moved-again"
+	require.NoError(t, store.UpdateTicket(ctx, moved))
+	require.NoError(t, store.IndexTicket(ctx, moved))
+	require.NoError(t, store.SetPlayerTicketID(ctx, moved.PlayerId, moved.Id))
+	require.NoError(t, store.DeleteTicket(ctx, moved.Id))
+
+	// The player's newer ticket keeps its record.
+	require.NoError(t, store.SetPlayerTicketID(ctx, "player-newer", "newer"))
+	require.NoError(t, store.CreateTicket(ctx, &pb.Ticket{Id: "stale", PlayerId: "player-newer"}))
+	require.NoError(t, store.DeleteTicket(ctx, "stale"))
+	id, err := store.GetPlayerTicketID(ctx, "player-newer")
+	require.NoError(t, err)
+	require.Equal(t, "newer", id)
+
+	requireNoReferences(t, conn, deindexed.Id, moved.Id, "stale")
+}
+
+func TestDeleteTickets(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	cfg.(*viper.Viper).Set("poolSaturation.tags", []string{"mode.ctf"})
+	store := New(cfg)
+	defer store.Close()
+	ctx := utilTesting.NewContext(t)
+
+	rb := store.(*instrumentedService).s.(*redisBackend)
+	conn, err := rb.redisPool.GetContext(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var ids []string
+	for _, id := range []string{"a", "b", "c"} {
+		ticket := &pb.Ticket{
+			Id:           id,
+			PlayerId:     "player-" + id,
+			SearchFields: &pb.SearchFields{Tags: []string{"mode.ctf"}},
+		}
+		require.NoError(t, store.CreateTicket(ctx, ticket))
+		require.NoError(t, store.IndexTicket(ctx, ticket))
+		require.NoError(t, store.SetPlayerTicketID(ctx, ticket.PlayerId, ticket.Id))
+		ids = append(ids, id)
+	}
+	// Indexed under another player id since it was created.
+	require.NoError(t, store.IndexTicket(ctx, &pb.Ticket{Id: "c", PlayerId: "player-c-again"}))
+
+	deleted, err := store.DeleteTickets(ctx, []string{"a", "c", "missing"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "c"}, deleted)
+
+	indexed, err := store.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"b": {}}, indexed)
+	count, err := store.GetPoolTicketCount(ctx, "mode.ctf")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+	_, err = store.GetTicket(ctx, "a")
+	require.Equal(t, codes.NotFound.String(), status.Convert(err).Code().String())
+
+	requireNoReferences(t, conn, "a", "c")
+
+	deleted, err = store.DeleteTickets(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, deleted)
+
+	// pass an expired context, err expected
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	store = New(cfg)
+	_, err = store.DeleteTickets(ctx, ids)
+	require.Error(t, err)
+	require.Equal(t, codes.Unavailable.String(), status.Convert(err).Code().String())
+	require.Contains(t, status.Convert(err).Message(), "DeleteTickets, failed to connect to redis:")
+}
+
+// requireNoReferences fails unless no key of redis is, or references, the
+// ticket ids.
+func requireNoReferences(t *testing.T, conn redis.Conn, ids ...string) {
+	keys, err := redis.Strings(conn.Do("KEYS", "*"))
+	require.NoError(t, err)
+	for _, key := range keys {
+		typ, err := redis.String(conn.Do("TYPE", key))
+		require.NoError(t, err)
+
+		for _, id := range ids {
+			require.NotEqual(t, id, key)
+			switch typ {
+			case "set":
+				member, err := redis.Bool(conn.Do("SISMEMBER", key, id))
+				require.NoError(t, err)
+				require.False(t, member, "set %s references %s", key, id)
+			case "zset":
+				score, err := conn.Do("ZSCORE", key, id)
+				require.NoError(t, err)
+				require.Nil(t, score, "sorted set %s references %s", key, id)
+			case "hash":
+				fields, err := redis.StringMap(conn.Do("HGETALL", key))
+				require.NoError(t, err)
+				for f, v := range fields {
+					require.NotEqual(t, id, f, "hash %s references %s", key, id)
+					require.NotEqual(t, id, v, "hash %s references %s", key, id)
+				}
+			}
+		}
+	}
+}
+
 func TestIndexTicket(t *testing.T) {
 	cfg, closer := createRedis(t, false, "")
 	defer closer()
@@ -609,6 +805,45 @@ func TestIndexTicket(t *testing.T) {
 	require.Contains(t, status.Convert(err).Message(), "IndexTicket, id: 12345, failed to connect to redis:")
 }
 
+func TestTicketExtensionsNotIndexed(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+
+	ctx := utilTesting.NewContext(t)
+
+	version, err := ptypes.MarshalAny(&wrappers.StringValue{Value: "1.2.3"})
+	require.NoError(t, err)
+	ticket := &pb.Ticket{
+		Id: "withExtensions",
+		SearchFields: &pb.SearchFields{
+			Tags: []string{"mode.ranked"},
+		},
+		Extensions: map[string]*any.Any{
+			"client_version": version,
+		},
+	}
+	require.NoError(t, service.CreateTicket(ctx, ticket))
+	require.NoError(t, service.IndexTicket(ctx, ticket))
+
+	got, err := service.GetTicket(ctx, ticket.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(ticket, got))
+
+	// Only the ticket itself and the index of ticket ids are stored.
+	c, err := redis.Dial("tcp", fmt.Sprintf("%s:%s", cfg.GetString("redis.hostname"), cfg.GetString("redis.port")))
+	require.NoError(t, err)
+	keys, err := redis.Strings(c.Do("KEYS", "*"))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{ticket.Id, allTickets}, keys)
+
+	idsIndexed, err := redis.Strings(c.Do("SMEMBERS", allTickets))
+	require.NoError(t, err)
+	require.Equal(t, []string{ticket.Id}, idsIndexed)
+}
+
 func TestDeindexTicket(t *testing.T) {
 	cfg, closer := createRedis(t, false, "")
 	defer closer()
@@ -646,6 +881,104 @@ func TestDeindexTicket(t *testing.T) {
 	require.Contains(t, status.Convert(err).Message(), "DeindexTicket, id: 12345, failed to connect to redis:")
 }
 
+func TestIndexPlayerTickets(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+
+	ctx := utilTesting.NewContext(t)
+
+	for _, ticket := range []*pb.Ticket{
+		{Id: "first", PlayerId: "player"},
+		{Id: "second", PlayerId: "player"},
+		{Id: "other", PlayerId: "other-player"},
+		{Id: "anonymous"},
+	} {
+		require.NoError(t, service.CreateTicket(ctx, ticket))
+		require.NoError(t, service.IndexTicket(ctx, ticket))
+	}
+
+	ids, err := service.GetIndexedPlayerTicketIDs(ctx, "player")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"first", "second"}, ids)
+
+	require.NoError(t, service.DeindexTicket(ctx, "first"))
+	require.NoError(t, service.DeindexTicket(ctx, "anonymous"))
+
+	ids, err = service.GetIndexedPlayerTicketIDs(ctx, "player")
+	require.NoError(t, err)
+	require.Equal(t, []string{"second"}, ids)
+
+	ids, err = service.GetIndexedPlayerTicketIDs(ctx, "unknown")
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	// pass an expired context, err expected
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	service = New(cfg)
+	_, err = service.GetIndexedPlayerTicketIDs(ctx, "player")
+	require.Error(t, err)
+	require.Equal(t, codes.Unavailable.String(), status.Convert(err).Code().String())
+	require.Contains(t, status.Convert(err).Message(), "GetIndexedPlayerTicketIDs, player id: player, failed to connect to redis:")
+}
+
+func TestIndexPoolTickets(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	cfg.(*viper.Viper).Set("poolSaturation.tags", []string{"mode.ctf", "mode.dm"})
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+
+	ctx := utilTesting.NewContext(t)
+
+	tagged := func(id string, tags ...string) *pb.Ticket {
+		return &pb.Ticket{Id: id, SearchFields: &pb.SearchFields{Tags: tags}}
+	}
+	for _, ticket := range []*pb.Ticket{
+		tagged("first", "mode.ctf"),
+		tagged("second", "platform.pc", "mode.ctf", "mode.dm"),
+		tagged("third", "mode.dm"),
+		tagged("untracked", "platform.pc"),
+	} {
+		require.NoError(t, service.CreateTicket(ctx, ticket))
+		require.NoError(t, service.IndexTicket(ctx, ticket))
+	}
+
+	requireCount := func(pool string, expected int64) {
+		t.Helper()
+		count, err := service.GetPoolTicketCount(ctx, pool)
+		require.NoError(t, err)
+		require.Equal(t, expected, count)
+	}
+	requireCount("mode.ctf", 2)
+	requireCount("mode.dm", 1)
+	requireCount("platform.pc", 0)
+
+	// Reindexing moves the ticket to its new pool.
+	require.NoError(t, service.IndexTicket(ctx, tagged("second", "mode.dm")))
+	requireCount("mode.ctf", 1)
+	requireCount("mode.dm", 2)
+
+	require.NoError(t, service.DeindexTicket(ctx, "first"))
+	requireCount("mode.ctf", 0)
+
+	require.NoError(t, service.DeleteTicket(ctx, "third"))
+	requireCount("mode.dm", 1)
+
+	// pass an expired context, err expected
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	service = New(cfg)
+	_, err := service.GetPoolTicketCount(ctx, "mode.ctf")
+	require.Error(t, err)
+	require.Equal(t, codes.Unavailable.String(), status.Convert(err).Code().String())
+	require.Contains(t, status.Convert(err).Message(), "GetPoolTicketCount, pool: mode.ctf, failed to connect to redis:")
+}
+
 func TestGetIndexedIDSet(t *testing.T) {
 	cfg, closer := createRedis(t, false, "")
 	defer closer()
@@ -691,6 +1024,593 @@ func TestGetIndexedIDSet(t *testing.T) {
 	require.Contains(t, status.Convert(err).Message(), "GetIndexedIDSet, failed to connect to redis:")
 }
 
+func TestGetIndexedIDSetScansPages(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	cfg.(*viper.Viper).Set("redis.scanCount", 3)
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	tickets, _ := generateTickets(ctx, t, service, 50)
+
+	ids, err := service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Len(t, ids, len(tickets))
+	for _, ticket := range tickets {
+		require.Contains(t, ids, ticket.GetId())
+	}
+}
+
+func TestGetScanCount(t *testing.T) {
+	cfg := viper.New()
+	require.Equal(t, 1000, getScanCount(cfg))
+	cfg.Set("redis.scanCount", 0)
+	require.Equal(t, 1000, getScanCount(cfg))
+	cfg.Set("redis.scanCount", 50)
+	require.Equal(t, 50, getScanCount(cfg))
+}
+
+func TestPendingReleaseExpiresWithClock(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	clock := utilTesting.NewFakeClock(time.Now())
+	service := NewWithClock(cfg, clock)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	tickets, _ := generateTickets(ctx, t, service, 2)
+	require.NoError(t, service.AddTicketsToPendingRelease(ctx, []string{tickets[0].GetId()}))
+
+	ids, err := service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, ids, tickets[0].GetId())
+	require.Contains(t, ids, tickets[1].GetId())
+
+	_, pending, err := service.GetPendingReleaseTime(ctx, tickets[0].GetId())
+	require.NoError(t, err)
+	require.True(t, pending)
+
+	// Advancing the clock past the timeout releases the ticket without waiting.
+	clock.Advance(cfg.GetDuration("pendingReleaseTimeout") + time.Millisecond)
+
+	ids, err = service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Contains(t, ids, tickets[0].GetId())
+	require.Contains(t, ids, tickets[1].GetId())
+
+	_, pending, err = service.GetPendingReleaseTime(ctx, tickets[0].GetId())
+	require.NoError(t, err)
+	require.False(t, pending)
+}
+
+func TestAddTicketsToCooldown(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	clock := utilTesting.NewFakeClock(time.Now())
+	service := NewWithClock(cfg, clock)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	cooldown := cfg.GetDuration("pendingReleaseTimeout") / 2
+	tickets, _ := generateTickets(ctx, t, service, 3)
+	require.NoError(t, service.AddTicketsToPendingRelease(ctx, []string{tickets[1].GetId()}))
+	require.NoError(t, service.AddTicketsToCooldown(ctx, []string{tickets[0].GetId(), tickets[1].GetId()}, cooldown))
+
+	ids, err := service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, ids, tickets[0].GetId())
+	require.NotContains(t, ids, tickets[1].GetId())
+	require.Contains(t, ids, tickets[2].GetId())
+
+	// The cooldown releases the ticket before the pending release timeout.
+	clock.Advance(cooldown + time.Millisecond)
+
+	ids, err = service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Contains(t, ids, tickets[0].GetId())
+	require.NotContains(t, ids, tickets[1].GetId())
+
+	// A ticket already pending release keeps its pending release.
+	clock.Advance(cooldown)
+
+	ids, err = service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Contains(t, ids, tickets[1].GetId())
+}
+
+func TestAddTicketsToCooldownReplacesStaleRelease(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	clock := utilTesting.NewFakeClock(time.Now())
+	service := NewWithClock(cfg, clock)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	timeout := cfg.GetDuration("pendingReleaseTimeout")
+	tickets, _ := generateTickets(ctx, t, service, 1)
+	id := tickets[0].GetId()
+
+	// The ticket was proposed long ago and never released, so its entry is stale.
+	require.NoError(t, service.AddTicketsToPendingRelease(ctx, []string{id}))
+	clock.Advance(2 * timeout)
+	ids, err := service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Contains(t, ids, id)
+
+	require.NoError(t, service.AddTicketsToCooldown(ctx, []string{id}, timeout/2))
+	ids, err = service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, ids, id)
+
+	clock.Advance(timeout/2 + time.Millisecond)
+	ids, err = service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Contains(t, ids, id)
+}
+
+func TestProposeTickets(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	clock := utilTesting.NewFakeClock(time.Now())
+	service := NewWithClock(cfg, clock)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	tickets, _ := generateTickets(ctx, t, service, 3)
+	require.NoError(t, service.ProposeTickets(ctx, []string{tickets[0].GetId()}))
+
+	// A proposal overlapping a pending ticket adds none of its tickets.
+	err := service.ProposeTickets(ctx, []string{tickets[1].GetId(), tickets[0].GetId()})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	ids, err := service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, ids, tickets[0].GetId())
+	require.Contains(t, ids, tickets[1].GetId())
+	require.Contains(t, ids, tickets[2].GetId())
+
+	require.NoError(t, service.ProposeTickets(ctx, []string{tickets[1].GetId(), tickets[2].GetId()}))
+
+	// Once the pending release times out, the ticket can be proposed again.
+	clock.Advance(cfg.GetDuration("pendingReleaseTimeout") + time.Millisecond)
+	require.NoError(t, service.ProposeTickets(ctx, []string{tickets[0].GetId()}))
+}
+
+func TestCreateTickets(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	cfg.(*viper.Viper).Set("poolSaturation.tags", []string{"mode.ctf"})
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	require.NoError(t, service.CreateTickets(ctx, nil))
+
+	tickets := []*pb.Ticket{
+		{Id: "a", SearchFields: &pb.SearchFields{Tags: []string{"mode.ctf"}}},
+		{Id: "b", PlayerId: "player"},
+		{Id: "c"},
+	}
+	require.NoError(t, service.CreateTickets(ctx, tickets))
+
+	for _, want := range tickets {
+		got, err := service.GetTicket(ctx, want.Id)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(want, got))
+	}
+
+	ids, err := service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Len(t, ids, 3)
+
+	playerIDs, err := service.GetIndexedPlayerTicketIDs(ctx, "player")
+	require.NoError(t, err)
+	require.Equal(t, []string{"b"}, playerIDs)
+
+	count, err := service.GetPoolTicketCount(ctx, "mode.ctf")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	// A command failing within the pipeline fails the call.
+	rb := service.(*instrumentedService).s.(*redisBackend)
+	conn, err := rb.redisPool.GetContext(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Do("SET", playerIndexKey("wrong-type"), "wrong-type-value")
+	require.NoError(t, err)
+	err = service.CreateTickets(ctx, []*pb.Ticket{{Id: "e"}, {Id: "f", PlayerId: "wrong-type"}})
+	require.Equal(t, codes.Internal, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "failed to create ticket, id: f")
+
+	// Pass an expired context, err expected
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	service = New(cfg)
+	err = service.CreateTickets(ctx, []*pb.Ticket{{Id: "d"}})
+	require.Equal(t, codes.Unavailable.String(), status.Convert(err).Code().String())
+	require.Contains(t, status.Convert(err).Message(), "CreateTickets, failed to connect to redis:")
+}
+
+func TestUpdateTicket(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	cfg.(*viper.Viper).Set("poolSaturation.tags", []string{"mode.ctf", "mode.dm"})
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	ticket := &pb.Ticket{Id: "1", SearchFields: &pb.SearchFields{Tags: []string{"mode.ctf"}}}
+	require.NoError(t, service.CreateTicket(ctx, ticket))
+	require.NoError(t, service.IndexTicket(ctx, ticket))
+
+	// The ticket moves to the index of its new pool.
+	ticket.SearchFields.Tags = []string{"mode.dm"}
+	require.NoError(t, service.UpdateTicket(ctx, ticket))
+	got, err := service.GetTicket(ctx, ticket.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(ticket, got))
+	for pool, want := range map[string]int64{"mode.ctf": 0, "mode.dm": 1} {
+		count, err := service.GetPoolTicketCount(ctx, pool)
+		require.NoError(t, err)
+		require.Equal(t, want, count, pool)
+	}
+
+	// Tickets which are no longer indexed, such as deleted or assigned ones,
+	// are not updated, nor indexed again.
+	require.NoError(t, service.DeindexTicket(ctx, ticket.Id))
+	ticket.SearchFields.Tags = []string{"mode.ctf"}
+	err = service.UpdateTicket(ctx, ticket)
+	require.Equal(t, codes.NotFound.String(), status.Convert(err).Code().String())
+	ids, err := service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+	count, err := service.GetPoolTicketCount(ctx, "mode.ctf")
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	err = service.UpdateTicket(ctx, &pb.Ticket{Id: "missing"})
+	require.Equal(t, codes.NotFound.String(), status.Convert(err).Code().String())
+
+	// An assigned ticket isn't overwritten with a stale unassigned copy, even
+	// before it is deindexed.
+	assigned := &pb.Ticket{Id: "2"}
+	require.NoError(t, service.CreateTicket(ctx, assigned))
+	require.NoError(t, service.IndexTicket(ctx, assigned))
+	_, _, err = service.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{{TicketIds: []string{assigned.Id}, Assignment: &pb.Assignment{Connection: "1"}}},
+	})
+	require.NoError(t, err)
+	err = service.UpdateTicket(ctx, assigned)
+	require.Equal(t, codes.NotFound.String(), status.Convert(err).Code().String())
+	got, err = service.GetTicket(ctx, assigned.Id)
+	require.NoError(t, err)
+	require.Equal(t, "1", got.GetAssignment().GetConnection())
+
+	revisions, err := service.GetTicketRevisions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{ticket.Id: 1}, revisions)
+}
+
+func TestUpdateTicketScriptChecksValueRead(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	service := New(cfg)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	rb := service.(*instrumentedService).s.(*redisBackend)
+	conn, err := rb.redisPool.GetContext(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ticket := &pb.Ticket{Id: "1"}
+	require.NoError(t, service.CreateTicket(ctx, ticket))
+	require.NoError(t, service.IndexTicket(ctx, ticket))
+
+	// The ticket changed since the stale value was read, as it does when it
+	// is assigned concurrently.
+	stale, err := rb.marshalTicket(&pb.Ticket{Id: "1", PlayerId: "stale"})
+	require.NoError(t, err)
+	value, err := rb.marshalTicket(&pb.Ticket{Id: "1", PlayerId: "update"})
+	require.NoError(t, err)
+	updated, err := redis.Int(updateTicketScript.Do(conn,
+		ticket.Id, allTickets, ticketRevisions, ticketPools, poolIndexKey(""), poolIndexKey(""),
+		value, "0", "0", "", "", stale))
+	require.NoError(t, err)
+	require.Equal(t, -1, updated)
+
+	got, err := service.GetTicket(ctx, ticket.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(ticket, got))
+}
+
+func TestAssignUnassignedTickets(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	cfg.(*viper.Viper).Set("fallbackAssignment.connection", "lobby:7777")
+	cfg.(*viper.Viper).Set("fallbackAssignment.timeout", time.Minute)
+	service := New(cfg)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	createTime, err := ptypes.TimestampProto(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	for _, id := range []string{"waiting", "proposed", "assigned", "deindexed"} {
+		ticket := &pb.Ticket{Id: id, CreateTime: createTime}
+		require.NoError(t, service.CreateTicket(ctx, ticket))
+		require.NoError(t, service.IndexTicket(ctx, ticket))
+	}
+	require.NoError(t, service.CreateTicket(ctx, &pb.Ticket{Id: "new", CreateTime: ptypes.TimestampNow()}))
+	require.NoError(t, service.AddTicketsToPendingRelease(ctx, []string{"proposed"}))
+	_, _, err = service.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{{TicketIds: []string{"assigned"}, Assignment: &pb.Assignment{Connection: "game:1"}}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, service.DeindexTicket(ctx, "deindexed"))
+
+	ids, err := service.GetTicketIDsCreatedBefore(ctx, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"waiting", "proposed", "deindexed"}, ids)
+
+	assignment := &pb.Assignment{Connection: "lobby:7777"}
+	assigned, err := service.AssignUnassignedTickets(ctx, append(ids, "assigned", "missing"), assignment)
+	require.NoError(t, err)
+	require.Len(t, assigned, 1)
+	require.Equal(t, "waiting", assigned[0].Id)
+
+	for id, want := range map[string]string{"waiting": "lobby:7777", "assigned": "game:1", "proposed": "", "deindexed": ""} {
+		ticket, err := service.GetTicket(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, want, ticket.GetAssignment().GetConnection(), id)
+	}
+
+	// Only the tickets which may still need the fallback assignment are left.
+	ids, err = service.GetTicketIDsCreatedBefore(ctx, time.Now())
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"proposed", "deindexed", "new"}, ids)
+}
+
+func TestAssignUnassignedTicketsScriptChecksValueRead(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	service := New(cfg)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	rb := service.(*instrumentedService).s.(*redisBackend)
+	conn, err := rb.redisPool.GetContext(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ticket := &pb.Ticket{Id: "1"}
+	require.NoError(t, service.CreateTicket(ctx, ticket))
+	require.NoError(t, service.IndexTicket(ctx, ticket))
+
+	// The ticket changed since the stale value was read, as it does when a
+	// backend assigns it concurrently.
+	stale, err := rb.marshalTicket(&pb.Ticket{Id: "1", PlayerId: "stale"})
+	require.NoError(t, err)
+	value, err := rb.marshalTicket(&pb.Ticket{Id: "1", Assignment: &pb.Assignment{Connection: "lobby:7777"}})
+	require.NoError(t, err)
+	assigned, err := redis.Strings(assignUnassignedTicketsScript.Do(conn,
+		4, allTickets, ticketCreateTimes, ticketExpirations, proposedTicketIDs,
+		0, 0, ticket.Id, stale, value))
+	require.NoError(t, err)
+	require.Empty(t, assigned)
+
+	got, err := service.GetTicket(ctx, ticket.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(ticket, got))
+}
+
+func TestTicketTTL(t *testing.T) {
+	const ttl = time.Minute
+
+	mredis, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mredis.Close()
+
+	cfg := viper.New()
+	cfg.Set("redis.hostname", mredis.Host())
+	cfg.Set("redis.port", mredis.Port())
+	cfg.Set("assignedDeleteTimeout", time.Hour)
+	cfg.Set("poolSaturation.tags", []string{"mode.ctf"})
+	clock := utilTesting.NewFakeClock(time.Now())
+	service := NewWithClock(cfg, clock)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	// Tickets don't expire by default.
+	require.NoError(t, service.CreateTicket(ctx, &pb.Ticket{Id: "kept"}))
+	require.NoError(t, service.IndexTicket(ctx, &pb.Ticket{Id: "kept"}))
+	require.Zero(t, mredis.TTL("kept"))
+
+	cfg.Set("ticketTTL", ttl)
+	expiring := &pb.Ticket{
+		Id:           "expiring",
+		PlayerId:     "player",
+		SearchFields: &pb.SearchFields{Tags: []string{"mode.ctf"}},
+	}
+	require.NoError(t, service.CreateTicket(ctx, expiring))
+	require.NoError(t, service.IndexTicket(ctx, expiring))
+	require.NoError(t, service.CreateTickets(ctx, []*pb.Ticket{{Id: "bulk"}}))
+	require.NoError(t, service.CreateTicket(ctx, &pb.Ticket{Id: "assigned"}))
+
+	// Updating a ticket keeps its expiration.
+	require.NoError(t, service.UpdateTicket(ctx, expiring))
+	require.Equal(t, ttl, mredis.TTL("expiring"))
+
+	// Assigned tickets expire after the assignedDeleteTimeout instead.
+	_, _, err = service.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{{TicketIds: []string{"assigned"}, Assignment: &pb.Assignment{Connection: "1"}}},
+	})
+	require.NoError(t, err)
+
+	ids, err := service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Len(t, ids, 3)
+
+	clock.Advance(ttl)
+	mredis.FastForward(ttl)
+
+	for _, id := range []string{"expiring", "bulk"} {
+		_, err = service.GetTicket(ctx, id)
+		require.Equal(t, codes.NotFound.String(), status.Convert(err).Code().String())
+	}
+	_, err = service.GetTicket(ctx, "assigned")
+	require.NoError(t, err)
+
+	ids, err = service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"kept": {}}, ids)
+
+	// The indexing of the expired tickets is removed as well.
+	playerIDs, err := service.GetIndexedPlayerTicketIDs(ctx, "player")
+	require.NoError(t, err)
+	require.Empty(t, playerIDs)
+	count, err := service.GetPoolTicketCount(ctx, "mode.ctf")
+	require.NoError(t, err)
+	require.Zero(t, count)
+	require.False(t, mredis.Exists(ticketExpirations))
+}
+
+func TestGetAssignmentsTicketExpired(t *testing.T) {
+	const ttl = time.Minute
+
+	mredis, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mredis.Close()
+
+	cfg := viper.New()
+	cfg.Set("redis.hostname", mredis.Host())
+	cfg.Set("redis.port", mredis.Port())
+	cfg.Set("ticketTTL", ttl)
+	clock := utilTesting.NewFakeClock(time.Now())
+	service := NewWithClock(cfg, clock)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	// The expired ticket is cleaned up by a query before the next poll, yet
+	// the watcher still learns that it expired.
+	require.NoError(t, service.CreateTicket(ctx, &pb.Ticket{Id: "expiring"}))
+	require.NoError(t, service.IndexTicket(ctx, &pb.Ticket{Id: "expiring"}))
+	err = service.GetAssignments(ctx, "expiring", 10*time.Millisecond, func(*pb.Assignment) error {
+		clock.Advance(ttl)
+		mredis.FastForward(ttl)
+		_, err := service.GetIndexedIDSet(ctx)
+		return err
+	})
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "ticket expired")
+
+	// A deleted ticket is still reported as not found.
+	require.NoError(t, service.CreateTicket(ctx, &pb.Ticket{Id: "deleted"}))
+	err = service.GetAssignments(ctx, "deleted", 10*time.Millisecond, func(*pb.Assignment) error {
+		return service.DeleteTicket(ctx, "deleted")
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestReserveTickets(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	clock := utilTesting.NewFakeClock(time.Now())
+	service := NewWithClock(cfg, clock)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	lease := cfg.GetDuration("pendingReleaseTimeout") * 2
+	tickets, _ := generateTickets(ctx, t, service, 3)
+	require.NoError(t, service.AddTicketsToPendingRelease(ctx, []string{tickets[1].GetId()}))
+	require.NoError(t, service.ReserveTickets(ctx, []string{tickets[0].GetId(), tickets[1].GetId()}, lease))
+
+	ids, err := service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, ids, tickets[0].GetId())
+	require.NotContains(t, ids, tickets[1].GetId())
+	require.Contains(t, ids, tickets[2].GetId())
+
+	// The lease outlasts the pending release timeout, also for the ticket
+	// which was already pending release.
+	clock.Advance(lease - time.Millisecond)
+
+	ids, err = service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, ids, tickets[0].GetId())
+	require.NotContains(t, ids, tickets[1].GetId())
+
+	clock.Advance(2 * time.Millisecond)
+
+	ids, err = service.GetIndexedIDSet(ctx)
+	require.NoError(t, err)
+	require.Contains(t, ids, tickets[0].GetId())
+	require.Contains(t, ids, tickets[1].GetId())
+}
+
+func TestPendingReleaseScopes(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	ctx := utilTesting.NewContext(t)
+
+	withScope := func(scope string) Service {
+		scoped := viper.New()
+		for _, k := range cfg.(*viper.Viper).AllKeys() {
+			scoped.Set(k, cfg.(*viper.Viper).Get(k))
+		}
+		scoped.Set("pendingReleaseScope", scope)
+		service := New(scoped)
+		t.Cleanup(func() { service.Close() })
+		return service
+	}
+	global := withScope("")
+	a := withScope("a")
+	b := withScope("b")
+
+	tickets, _ := generateTickets(ctx, t, global, 3)
+
+	verifyIndexed := func(service Service, want ...*pb.Ticket) {
+		ids, err := service.GetIndexedIDSet(ctx)
+		require.NoError(t, err)
+		require.Len(t, ids, len(want))
+		for _, tt := range want {
+			require.Contains(t, ids, tt.GetId())
+		}
+	}
+
+	// A ticket proposed in a scope is only hidden from that scope.
+	require.NoError(t, a.AddTicketsToPendingRelease(ctx, []string{tickets[0].GetId()}))
+	require.NoError(t, b.AddTicketsToPendingRelease(ctx, []string{tickets[1].GetId()}))
+	verifyIndexed(a, tickets[1], tickets[2])
+	verifyIndexed(b, tickets[0], tickets[2])
+	verifyIndexed(global, tickets...)
+
+	// A ticket proposed in the global scope is hidden from every scope.
+	require.NoError(t, global.AddTicketsToPendingRelease(ctx, []string{tickets[2].GetId()}))
+	verifyIndexed(a, tickets[1])
+	verifyIndexed(b, tickets[0])
+	verifyIndexed(global, tickets[0], tickets[1])
+
+	// Releasing all tickets only affects the releasing scope.
+	require.NoError(t, a.ReleaseAllTickets(ctx))
+	verifyIndexed(a, tickets[0], tickets[1])
+	verifyIndexed(b, tickets[0])
+
+	// Deleting from pending release removes tickets from every scope.
+	require.NoError(t, a.DeleteTicketsFromPendingRelease(ctx, []string{tickets[1].GetId(), tickets[2].GetId()}))
+	verifyIndexed(b, tickets...)
+}
+
 func TestGetTickets(t *testing.T) {
 	cfg, closer := createRedis(t, false, "")
 	defer closer()
@@ -0,0 +1,72 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"net"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// timeoutConn is a redis.Conn which bounds how long Do and Receive wait for a
+// reply, independently of the timeouts used to dial the connection.  A command
+// which runs out of time fails with DeadlineExceeded, and the connection is
+// closed as its reply may still arrive.
+type timeoutConn struct {
+	redis.Conn
+	timeout time.Duration
+}
+
+// withCommandTimeout returns conn bounded by timeout, or conn itself if
+// timeout is not positive.
+func withCommandTimeout(conn redis.Conn, timeout time.Duration) redis.Conn {
+	if timeout <= 0 {
+		return conn
+	}
+	return &timeoutConn{Conn: conn, timeout: timeout}
+}
+
+func (c *timeoutConn) Do(commandName string, args ...interface{}) (interface{}, error) {
+	reply, err := redis.DoWithTimeout(c.Conn, c.timeout, commandName, args...)
+	return reply, c.convert(commandName, err)
+}
+
+func (c *timeoutConn) Receive() (interface{}, error) {
+	reply, err := redis.ReceiveWithTimeout(c.Conn, c.timeout)
+	return reply, c.convert("RECEIVE", err)
+}
+
+func (c *timeoutConn) convert(commandName string, err error) error {
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return status.Errorf(codes.DeadlineExceeded, "redis command %s did not complete within %s", commandName, c.timeout)
+	}
+	return err
+}
+
+// internalErrorf returns an Internal status error, unless one of the args is
+// an error caused by a command timing out, in which case the error keeps the
+// DeadlineExceeded code so that callers can tell it apart.
+func internalErrorf(format string, args ...interface{}) error {
+	for _, arg := range args {
+		if err, ok := arg.(error); ok && status.Code(errors.Cause(err)) == codes.DeadlineExceeded {
+			return status.Errorf(codes.DeadlineExceeded, format, args...)
+		}
+	}
+	return status.Errorf(codes.Internal, format, args...)
+}
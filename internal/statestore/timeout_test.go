@@ -0,0 +1,105 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// startSlowRedis starts a server which accepts commands like Redis, but never
+// replies to them, as Redis does while it runs a pathological command.
+func startSlowRedis(t *testing.T) net.Listener {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(ioutil.Discard, conn)
+			}()
+		}
+	}()
+
+	return l
+}
+
+func commandTimeoutConfig(t *testing.T, addr string) *viper.Viper {
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+
+	cfg := viper.New()
+	cfg.Set("redis.hostname", host)
+	cfg.Set("redis.port", port)
+	cfg.Set("redis.pool.maxIdle", 1)
+	cfg.Set("redis.pool.maxActive", 1)
+	// The read timeout used when dialing is far longer than the command
+	// timeout, so only the command timeout can end the commands.
+	cfg.Set("redis.pool.idleTimeout", time.Minute)
+	cfg.Set("redis.pool.healthCheckTimeout", time.Minute)
+	cfg.Set("redis.commandTimeout", 100*time.Millisecond)
+	return cfg
+}
+
+func TestCommandTimeout(t *testing.T) {
+	l := startSlowRedis(t)
+	defer l.Close()
+
+	service := New(commandTimeoutConfig(t, l.Addr().String()))
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	start := time.Now()
+	_, err := service.GetTicket(ctx, "1")
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err), err)
+	require.Less(t, int64(time.Since(start)), int64(10*time.Second))
+
+	_, err = service.GetIndexedIDSet(ctx)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err), err)
+
+	err = service.CreateTicket(ctx, &pb.Ticket{Id: "1"})
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err), err)
+}
+
+func TestCommandTimeoutNotReached(t *testing.T) {
+	mredis, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mredis.Close()
+
+	service := New(commandTimeoutConfig(t, mredis.Addr()))
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	require.NoError(t, service.CreateTicket(ctx, &pb.Ticket{Id: "1"}))
+	ticket, err := service.GetTicket(ctx, "1")
+	require.NoError(t, err)
+	require.Equal(t, "1", ticket.Id)
+}
@@ -0,0 +1,75 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats/view"
+)
+
+// pushExporter is a view exporter which buffers data before sending it to a
+// backend, as opposed to Prometheus which is scraped.
+type pushExporter interface {
+	view.Exporter
+	Flush()
+}
+
+var (
+	pushExportersMu sync.Mutex
+	pushExporters   = make(map[pushExporter]struct{})
+)
+
+// registerPushExporter registers e with OpenCensus, and includes it in FlushViews.
+func registerPushExporter(e pushExporter) {
+	pushExportersMu.Lock()
+	defer pushExportersMu.Unlock()
+	pushExporters[e] = struct{}{}
+	view.RegisterExporter(e)
+}
+
+func unregisterPushExporter(e pushExporter) {
+	pushExportersMu.Lock()
+	defer pushExportersMu.Unlock()
+	delete(pushExporters, e)
+	view.UnregisterExporter(e)
+}
+
+// FlushViews exports the current data of the views to every push exporter and
+// flushes them.  OpenCensus only exports views once per reporting period, so
+// without this the data recorded since the last report is lost on shutdown.
+// start is when the views began collecting data.
+func FlushViews(views []*view.View, start time.Time) {
+	var data []*view.Data
+	now := time.Now()
+	for _, v := range views {
+		rows, err := view.RetrieveData(v.Name)
+		if err != nil {
+			logger.WithError(err).Warningf("Failed to retrieve data for view %s", v.Name)
+			continue
+		}
+		data = append(data, &view.Data{View: v, Start: start, End: now, Rows: rows})
+	}
+
+	pushExportersMu.Lock()
+	defer pushExportersMu.Unlock()
+	for e := range pushExporters {
+		for _, d := range data {
+			e.ExportView(d)
+		}
+		e.Flush()
+	}
+}
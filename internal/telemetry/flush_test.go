@@ -0,0 +1,63 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+)
+
+type fakePushExporter struct {
+	exported []*view.Data
+	flushes  int
+}
+
+func (e *fakePushExporter) ExportView(d *view.Data) {
+	e.exported = append(e.exported, d)
+}
+
+func (e *fakePushExporter) Flush() {
+	e.flushes++
+}
+
+func TestFlushViews(t *testing.T) {
+	measure := stats.Int64("open-match.dev/test/flush", "Test measure", stats.UnitDimensionless)
+	v := &view.View{Name: "open-match.dev/test/flush", Measure: measure, Aggregation: view.Count()}
+	require.NoError(t, view.Register(v))
+	defer view.Unregister(v)
+
+	e := &fakePushExporter{}
+	registerPushExporter(e)
+
+	stats.Record(context.Background(), measure.M(1))
+	start := time.Now().Add(-time.Minute)
+	FlushViews([]*view.View{v}, start)
+
+	require.Equal(t, 1, e.flushes)
+	require.Len(t, e.exported, 1)
+	require.Equal(t, v, e.exported[0].View)
+	require.Equal(t, start, e.exported[0].Start)
+	require.Len(t, e.exported[0].Rows, 1)
+	require.Equal(t, int64(1), e.exported[0].Rows[0].Data.(*view.CountData).Value)
+
+	unregisterPushExporter(e)
+	FlushViews([]*view.View{v}, start)
+	require.Equal(t, 1, e.flushes)
+}
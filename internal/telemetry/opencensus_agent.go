@@ -18,7 +18,6 @@ import (
 	"contrib.go.opencensus.io/exporter/ocagent"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"go.opencensus.io/stats/view"
 	"go.opencensus.io/trace"
 )
 
@@ -41,10 +40,10 @@ func bindOpenCensusAgent(p Params, b Bindings) error {
 	}
 
 	trace.RegisterExporter(oce)
-	view.RegisterExporter(oce)
+	registerPushExporter(oce)
 
 	b.AddCloserErr(func() error {
-		view.UnregisterExporter(oce)
+		unregisterPushExporter(oce)
 		trace.UnregisterExporter(oce)
 		// Before the program stops, please remember to stop the exporter.
 		return oce.Stop()
@@ -0,0 +1,75 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/bridge/opencensus"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+func bindOpenTelemetry(p Params, b Bindings) error {
+	cfg := p.Config()
+
+	if !cfg.GetBool("telemetry.opentelemetry.enable") {
+		logger.Info("OpenTelemetry Tracing: Disabled")
+		return nil
+	}
+
+	collectorEndpoint := cfg.GetString("telemetry.opentelemetry.collectorEndpoint")
+	logger.WithFields(logrus.Fields{
+		"collectorEndpoint": collectorEndpoint,
+		"serviceName":       p.ServiceName(),
+	}).Info("OpenTelemetry Tracing: ENABLED")
+
+	exporter, err := otlptracegrpc.New(context.Background(),
+		otlptracegrpc.WithEndpoint(collectorEndpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create the OpenTelemetry exporter")
+	}
+
+	samplingFraction := cfg.GetFloat64("telemetry.traceSamplingFraction")
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingFraction))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(p.ServiceName()))),
+	)
+	b.AddCloserErr(bridgeOpenTelemetry(tp))
+
+	return nil
+}
+
+// bridgeOpenTelemetry sends the spans of the OpenCensus instrumentation, such
+// as the ocgrpc handlers, to the OpenTelemetry tracer provider instead of the
+// OpenCensus exporters.  The returned function restores the OpenCensus tracer
+// and flushes the provider.
+func bridgeOpenTelemetry(tp *sdktrace.TracerProvider) func() error {
+	ocTracer := trace.DefaultTracer
+	trace.DefaultTracer = opencensus.NewTracer(tp.Tracer("open-match"))
+
+	return func() error {
+		trace.DefaultTracer = ocTracer
+		return tp.Shutdown(context.Background())
+	}
+}
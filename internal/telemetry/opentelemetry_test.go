@@ -0,0 +1,52 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestBridgeOpenTelemetry(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	)
+	ocTracer := trace.DefaultTracer
+	restore := bridgeOpenTelemetry(tp)
+
+	// The ocgrpc handlers start spans named after the method, and the server
+	// span of the match function continues the span of the backend.
+	ctx, fetch := trace.StartSpan(context.Background(), "openmatch.BackendService.FetchMatches")
+	_, run := trace.StartSpanWithRemoteParent(ctx, "openmatch.MatchFunction.Run", fetch.SpanContext())
+	run.End()
+	fetch.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	require.Equal(t, "openmatch.MatchFunction.Run", spans[0].Name)
+	require.Equal(t, "openmatch.BackendService.FetchMatches", spans[1].Name)
+	require.Equal(t, spans[1].SpanContext.TraceID(), spans[0].SpanContext.TraceID())
+	require.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+
+	require.NoError(t, restore())
+	require.Equal(t, ocTracer, trace.DefaultTracer)
+}
@@ -37,6 +37,7 @@ func Setup(p Params, b Bindings) error {
 	bindings := []func(p Params, b Bindings) error{
 		configureOpenCensus,
 		bindJaeger,
+		bindOpenTelemetry,
 		bindPrometheus,
 		bindStackDriverMetrics,
 		bindOpenCensusAgent,
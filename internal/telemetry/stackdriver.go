@@ -18,7 +18,6 @@ import (
 	"contrib.go.opencensus.io/exporter/stackdriver"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"go.opencensus.io/stats/view"
 	"go.opencensus.io/trace"
 )
 
@@ -46,11 +45,11 @@ func bindStackDriverMetrics(p Params, b Bindings) error {
 		return errors.Wrap(err, "Failed to initialize OpenCensus exporter to Stack Driver")
 	}
 
-	view.RegisterExporter(sd)
+	registerPushExporter(sd)
 	trace.RegisterExporter(sd)
 
 	b.AddCloser(func() {
-		view.UnregisterExporter(sd)
+		unregisterPushExporter(sd)
 		trace.UnregisterExporter(sd)
 		// It is imperative to invoke flush before your main function exits
 		sd.Flush()
@@ -28,7 +28,10 @@ import (
 	mmfService "open-match.dev/open-match/internal/testing/mmf"
 )
 
-func start(t *testing.T, eval evaluator.Evaluator, mmf mmfService.MatchFunction) (config.View, func(time.Duration)) {
+func start(t *testing.T, eval evaluator.Evaluator, mmf mmfService.MatchFunction, overrides map[string]interface{}) (config.View, func(time.Duration)) {
+	if len(overrides) > 0 {
+		t.Skip("The cluster's configuration can't be overridden")
+	}
 	clusterLock.Lock()
 	t.Cleanup(func() {
 		clusterLock.Unlock()
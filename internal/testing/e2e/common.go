@@ -36,6 +36,12 @@ var (
 )
 
 func newOM(t *testing.T) *om {
+	return newOMWithConfig(t, nil)
+}
+
+// newOMWithConfig is newOM with some configuration values replaced.  Only the
+// in memory tests support this, tests which need it are skipped on a cluster.
+func newOMWithConfig(t *testing.T, overrides map[string]interface{}) *om {
 	om := &om{
 		t: t,
 	}
@@ -53,7 +59,7 @@ func newOM(t *testing.T) *om {
 		}
 	})
 
-	om.cfg, om.AdvanceTTLTime = start(t, om.evaluate, om.runMMF)
+	om.cfg, om.AdvanceTTLTime = start(t, om.evaluate, om.runMMF, overrides)
 	om.fe = pb.NewFrontendServiceClient(apptest.GRPCClient(t, om.cfg, "api.frontend"))
 	om.be = pb.NewBackendServiceClient(apptest.GRPCClient(t, om.cfg, "api.backend"))
 	om.query = pb.NewQueryServiceClient(apptest.GRPCClient(t, om.cfg, "api.query"))
@@ -22,10 +22,13 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/app/evaluator/defaulteval"
 	"open-match.dev/open-match/pkg/matchfunction"
 	"open-match.dev/open-match/pkg/pb"
 )
@@ -217,6 +220,84 @@ func TestSynchronizerMatchCollision(t *testing.T) {
 	require.Nil(t, resp)
 }
 
+// TestSynchronizerRejectsMatchCollision covers two match functions using the
+// same match id when collisions are rejected: the later proposal is dropped,
+// and the earlier one is still evaluated.
+func TestSynchronizerRejectsMatchCollision(t *testing.T) {
+	ctx := context.Background()
+	om := newOMWithConfig(t, map[string]interface{}{
+		"rejectMatchIdCollisions": true,
+	})
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	m1 := &pb.Match{
+		MatchId: "1",
+		Tickets: []*pb.Ticket{t1},
+	}
+	m2 := &pb.Match{
+		MatchId: "1",
+		Tickets: []*pb.Ticket{t2},
+	}
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		if profile.Name == "first" {
+			out <- m1
+		} else {
+			out <- m2
+		}
+		return nil
+	})
+
+	timesEvaluatorCalled := 0
+
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		timesEvaluatorCalled++
+		require.Equal(t, 1, timesEvaluatorCalled)
+		p, ok := <-in
+		require.True(t, ok)
+		require.True(t, proto.Equal(m1, p))
+		out <- p.MatchId
+		_, ok = <-in
+		require.False(t, ok)
+		return nil
+	})
+
+	s1, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:  om.MMFConfigGRPC(),
+		Profile: &pb.MatchProfile{Name: "first"},
+	})
+	require.Nil(t, err)
+
+	// Flow first match through before starting second mmf, so the second mmf
+	// is the one which collides.
+	resp, err := s1.Recv()
+	require.Nil(t, err)
+	require.True(t, proto.Equal(m1, resp.Match))
+
+	s2, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:  om.MMFConfigGRPC(),
+		Profile: &pb.MatchProfile{Name: "second"},
+	})
+	require.Nil(t, err)
+
+	resp, err = s2.Recv()
+	require.Equal(t, io.EOF, err)
+	require.Nil(t, resp)
+
+	resp, err = s1.Recv()
+	require.Equal(t, io.EOF, err)
+	require.Nil(t, resp)
+
+	// The dropped proposal's ticket is still available for matchmaking.
+	tickets, err := matchfunction.QueryPool(ctx, om.Query(), &pb.Pool{})
+	require.Nil(t, err)
+	require.Len(t, tickets, 1)
+	require.Equal(t, t2.Id, tickets[0].Id)
+}
+
 // TestEvaluatorReturnInvalidId covers the evaluator returning an ID which does
 // not correspond to any match passed to it.
 func TestEvaluatorReturnInvalidId(t *testing.T) {
@@ -415,6 +496,231 @@ func TestNoMatches(t *testing.T) {
 	require.Nil(t, resp)
 }
 
+// TestRejectedTicketCooldown covers that the tickets of proposals rejected by
+// the evaluator are not returned by queries until the cooldown elapses.
+func TestRejectedTicketCooldown(t *testing.T) {
+	const cooldown = pendingReleaseTimeout / 2
+	ctx := context.Background()
+	om := newOMWithConfig(t, map[string]interface{}{
+		"rejectedTicketCooldown": cooldown.String(),
+	})
+
+	accepted, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	rejected, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		out <- &pb.Match{MatchId: "accepted", Tickets: []*pb.Ticket{accepted}}
+		out <- &pb.Match{MatchId: "rejected", Tickets: []*pb.Ticket{rejected}}
+		return nil
+	})
+
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for m := range in {
+			if m.GetMatchId() == "accepted" {
+				out <- m.GetMatchId()
+			}
+		}
+		return nil
+	})
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:  om.MMFConfigGRPC(),
+		Profile: &pb.MatchProfile{Name: "test-profile", Pools: []*pb.Pool{{Name: "pool"}}},
+	})
+	require.Nil(t, err)
+
+	startTime := time.Now()
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.Equal(t, "accepted", resp.GetMatch().GetMatchId())
+
+	resp, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+	require.Nil(t, resp)
+
+	queryIds := func() []string {
+		ids := []string{}
+		stream, err := om.Query().QueryTicketIds(ctx, &pb.QueryTicketIdsRequest{Pool: &pb.Pool{}})
+		require.Nil(t, err)
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return ids
+			}
+			require.Nil(t, err)
+			ids = append(ids, resp.GetIds()...)
+		}
+	}
+
+	require.Empty(t, queryIds())
+	require.True(t, time.Since(startTime) < cooldown, "%s", time.Since(startTime))
+
+	// The accepted ticket is still pending release.
+	time.Sleep(cooldown)
+	require.Equal(t, []string{rejected.GetId()}, queryIds())
+}
+
+// TestInvalidProposal covers the backend dropping a proposal with a ticket
+// outside of the profile's pools when proposals are validated.
+func TestInvalidProposal(t *testing.T) {
+	ctx := context.Background()
+	om := newOMWithConfig(t, map[string]interface{}{
+		"validateProposals": true,
+	})
+
+	member, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{
+		SearchFields: &pb.SearchFields{Tags: []string{"mode.demo"}},
+	}})
+	require.Nil(t, err)
+	foreign, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		out <- &pb.Match{MatchId: "valid", Tickets: []*pb.Ticket{member}}
+		out <- &pb.Match{MatchId: "foreign", Tickets: []*pb.Ticket{foreign}}
+		return nil
+	})
+
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for m := range in {
+			out <- m.GetMatchId()
+		}
+		return nil
+	})
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config: om.MMFConfigGRPC(),
+		Profile: &pb.MatchProfile{
+			Name: "test-profile",
+			Pools: []*pb.Pool{{
+				Name:              "demo",
+				TagPresentFilters: []*pb.TagPresentFilter{{Tag: "mode.demo"}},
+			}},
+		},
+	})
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.Equal(t, "valid", resp.GetMatch().GetMatchId())
+
+	resp, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+	require.Nil(t, resp)
+
+	// The foreign ticket was never proposed, so it is still in the pool.
+	ids := []string{}
+	qs, err := om.Query().QueryTicketIds(ctx, &pb.QueryTicketIdsRequest{Pool: &pb.Pool{}})
+	require.Nil(t, err)
+	for {
+		resp, err := qs.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.Nil(t, err)
+		ids = append(ids, resp.GetIds()...)
+	}
+	require.Equal(t, []string{foreign.GetId()}, ids)
+}
+
+// TestNoMatchSummary covers a fetch matches call which returns no matches
+// sending a summary when one is requested.
+func TestNoMatchSummary(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		out <- &pb.Match{
+			MatchId: "1",
+			Tickets: []*pb.Ticket{t1, t2},
+		}
+		return nil
+	})
+
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for range in {
+		}
+		return nil
+	})
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:                om.MMFConfigGRPC(),
+		Profile:               &pb.MatchProfile{},
+		IncludeNoMatchSummary: true,
+	})
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.Nil(t, resp.Match)
+	require.Equal(t, int32(1), resp.NoMatchSummary.Proposals)
+	require.Equal(t, int32(2), resp.NoMatchSummary.TicketsConsidered)
+	require.Equal(t, "no proposals were accepted by the evaluator", resp.NoMatchSummary.Reason)
+
+	resp, err = stream.Recv()
+	require.Equal(t, err, io.EOF)
+	require.Nil(t, resp)
+}
+
+// TestOversizedProposal covers a match function returning a proposal larger
+// than the maximum proposal size, which is dropped while the rest of the
+// proposals are still returned.
+func TestOversizedProposal(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t3, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		out <- &pb.Match{MatchId: "before", Tickets: []*pb.Ticket{t1}}
+		out <- &pb.Match{
+			MatchId: "oversized",
+			Tickets: []*pb.Ticket{t2},
+			Extensions: map[string]*any.Any{
+				"payload": {TypeUrl: "type.googleapis.com/payload", Value: make([]byte, 5*1024*1024)},
+			},
+		}
+		out <- &pb.Match{MatchId: "after", Tickets: []*pb.Ticket{t3}}
+		return nil
+	})
+
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for m := range in {
+			out <- m.GetMatchId()
+		}
+		return nil
+	})
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:  om.MMFConfigGRPC(),
+		Profile: &pb.MatchProfile{},
+	})
+	require.Nil(t, err)
+
+	ids := []string{}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.Nil(t, err)
+		ids = append(ids, resp.GetMatch().GetMatchId())
+	}
+	require.ElementsMatch(t, []string{"before", "after"}, ids)
+}
+
 // TestNoProfile covers missing the profile field on fetch matches.
 func TestNoProfile(t *testing.T) {
 	ctx := context.Background()
@@ -492,6 +798,41 @@ func TestCancel(t *testing.T) {
 	require.True(t, time.Since(startTime) > registrationInterval, "%s", time.Since(startTime))
 }
 
+// TestMaxFetchMatchesDuration covers that a fetch matches stream running
+// longer than the configured maximum is ended cleanly.
+func TestMaxFetchMatchesDuration(t *testing.T) {
+	const maxDuration = 500 * time.Millisecond
+	ctx := context.Background()
+	om := newOMWithConfig(t, map[string]interface{}{
+		"maxFetchMatchesDuration": maxDuration.String(),
+	})
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		return nil
+	})
+
+	// The synchronizer cancels slow match functions, so it's a slow evaluator
+	// which keeps the stream open.
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for range in {
+		}
+		<-ctx.Done()
+		return nil
+	})
+
+	startTime := time.Now()
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:  om.MMFConfigGRPC(),
+		Profile: &pb.MatchProfile{},
+	})
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+	require.Equal(t, io.EOF, err)
+	require.Nil(t, resp)
+	require.True(t, time.Since(startTime) >= maxDuration, "%s", time.Since(startTime))
+}
+
 // TestStreaming covers that matches can stream through the mmf, evaluator, and
 // return to the fetch matches call.  At no point are all matches accumulated
 // and then passed on.  This keeps things efficiently moving.
@@ -703,6 +1044,66 @@ func TestMultipleFetchCalls(t *testing.T) {
 	require.Nil(t, resp)
 }
 
+// TestCrossProfileCollision covers proposals from fetch matches calls for
+// different profiles, collected in the same cycle, being evaluated together so
+// that a ticket shared across profiles is only used by one winning match.
+func TestCrossProfileCollision(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	scores := map[string]float64{"low": 1, "high": 2}
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		score, ok := scores[profile.Name]
+		if !ok {
+			return errors.New("Unknown profile")
+		}
+		input, err := ptypes.MarshalAny(&pb.DefaultEvaluationCriteria{Score: score})
+		if err != nil {
+			return err
+		}
+		out <- &pb.Match{
+			MatchId:    profile.Name,
+			Tickets:    []*pb.Ticket{t1},
+			Extensions: map[string]*any.Any{"evaluation_input": input},
+		}
+		return nil
+	})
+
+	timesEvaluatorCalled := 0
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		timesEvaluatorCalled++
+		require.Equal(t, 1, timesEvaluatorCalled)
+		return defaulteval.Evaluate(ctx, in, out)
+	})
+
+	sLow, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:  om.MMFConfigGRPC(),
+		Profile: &pb.MatchProfile{Name: "low"},
+	})
+	require.Nil(t, err)
+
+	sHigh, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:  om.MMFConfigGRPC(),
+		Profile: &pb.MatchProfile{Name: "high"},
+	})
+	require.Nil(t, err)
+
+	resp, err := sHigh.Recv()
+	require.Nil(t, err)
+	require.Equal(t, "high", resp.Match.MatchId)
+
+	resp, err = sHigh.Recv()
+	require.Equal(t, err, io.EOF)
+	require.Nil(t, resp)
+
+	resp, err = sLow.Recv()
+	require.Equal(t, err, io.EOF)
+	require.Nil(t, resp)
+}
+
 // TestSlowBackendDoesntBlock covers that after the evaluator has returned, a
 // new cycle can start despite and slow fetch matches caller.  Additionally, it
 // confirms that the tickets are marked as pending, so the second cycle won't be
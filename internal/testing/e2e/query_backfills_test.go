@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/pkg/matchfunction"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -216,3 +217,83 @@ func TestBackfillQueryAfterGSUpdate(t *testing.T) {
 		require.Nil(t, resp)
 	}
 }
+
+func TestQueryBackfillsGenerationRange(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	// Backfill i is updated i times, giving generations 1 through 4.
+	generations := map[string]int64{}
+	for i := 0; i < 4; i++ {
+		backfill, err := om.Frontend().CreateBackfill(ctx, &pb.CreateBackfillRequest{Backfill: &pb.Backfill{}})
+		require.NoError(t, err)
+		for j := 0; j < i; j++ {
+			backfill, err = om.Frontend().UpdateBackfill(ctx, &pb.UpdateBackfillRequest{Backfill: backfill})
+			require.NoError(t, err)
+		}
+		require.Equal(t, int64(firstBackfillGeneration+i), backfill.Generation)
+		generations[backfill.Id] = backfill.Generation
+	}
+
+	stream, err := om.Query().QueryBackfills(ctx, &pb.QueryBackfillsRequest{
+		Pool:       &pb.Pool{},
+		Generation: &pb.GenerationRange{Min: 2, Max: 3},
+	})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Len(t, resp.Backfills, 2)
+	for _, backfill := range resp.Backfills {
+		require.Contains(t, []int64{2, 3}, generations[backfill.Id])
+		require.Equal(t, generations[backfill.Id], backfill.Generation)
+	}
+
+	resp, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+	require.Nil(t, resp)
+}
+
+func TestQueryBackfillsInvalidGenerationRange(t *testing.T) {
+	om := newOM(t)
+	stream, err := om.Query().QueryBackfills(context.Background(), &pb.QueryBackfillsRequest{
+		Pool:       &pb.Pool{},
+		Generation: &pb.GenerationRange{Min: 3, Max: 2},
+	})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+	require.Nil(t, resp)
+}
+
+func TestQueryBackfillPoolSince(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	var backfills []*pb.Backfill
+	for i := 0; i < 4; i++ {
+		backfill, err := om.Frontend().CreateBackfill(ctx, &pb.CreateBackfillRequest{Backfill: &pb.Backfill{}})
+		require.NoError(t, err)
+		backfills = append(backfills, backfill)
+	}
+
+	// Only the updated backfills are above the watermark of their creation.
+	updated := map[string]int64{}
+	for _, backfill := range backfills[:2] {
+		backfill, err := om.Frontend().UpdateBackfill(ctx, &pb.UpdateBackfillRequest{Backfill: backfill})
+		require.NoError(t, err)
+		updated[backfill.Id] = backfill.Generation
+	}
+
+	result, err := matchfunction.QueryBackfillPoolSince(ctx, om.Query(), &pb.Pool{}, firstBackfillGeneration)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	for _, backfill := range result {
+		require.Contains(t, updated, backfill.Id)
+		require.Equal(t, updated[backfill.Id], backfill.Generation)
+	}
+
+	result, err = matchfunction.QueryBackfillPoolSince(ctx, om.Query(), &pb.Pool{}, firstBackfillGeneration+1)
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
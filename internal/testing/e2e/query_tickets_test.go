@@ -19,10 +19,12 @@ import (
 	"io"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/filter/testcases"
+	"open-match.dev/open-match/internal/statestore"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -117,6 +119,153 @@ func TestPaging(t *testing.T) {
 	require.Nil(t, resp)
 }
 
+// TestUpdatedTicketChangesPool covers a ticket updated with new search fields
+// being returned by the pool matching its new search fields, and no longer by
+// the pool matching its previous search fields.
+func TestUpdatedTicketChangesPool(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	ticket, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{
+		SearchFields: &pb.SearchFields{
+			DoubleArgs: map[string]float64{"mmr": 10},
+		},
+	}})
+	require.Nil(t, err)
+
+	low := &pb.Pool{DoubleRangeFilters: []*pb.DoubleRangeFilter{{DoubleArg: "mmr", Min: 0, Max: 50}}}
+	high := &pb.Pool{DoubleRangeFilters: []*pb.DoubleRangeFilter{{DoubleArg: "mmr", Min: 50, Max: 100}}}
+
+	queryPool := func(pool *pb.Pool) []*pb.Ticket {
+		stream, err := om.Query().QueryTickets(ctx, &pb.QueryTicketsRequest{Pool: pool})
+		require.Nil(t, err)
+
+		tickets := []*pb.Ticket{}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return tickets
+			}
+			require.Nil(t, err)
+			tickets = append(tickets, resp.Tickets...)
+		}
+	}
+
+	require.Len(t, queryPool(low), 1)
+	require.Len(t, queryPool(high), 0)
+
+	updated, err := om.Frontend().UpdateTicket(ctx, &pb.UpdateTicketRequest{
+		TicketId: ticket.Id,
+		SearchFields: &pb.SearchFields{
+			DoubleArgs: map[string]float64{"mmr": 80},
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, ticket.Id, updated.Id)
+	require.True(t, proto.Equal(ticket.CreateTime, updated.CreateTime))
+
+	require.Len(t, queryPool(low), 0)
+	tickets := queryPool(high)
+	require.Len(t, tickets, 1)
+	require.Equal(t, ticket.Id, tickets[0].Id)
+	require.True(t, proto.Equal(ticket.CreateTime, tickets[0].CreateTime))
+
+	_, err = om.Frontend().UpdateTicket(ctx, &pb.UpdateTicketRequest{TicketId: "missing"})
+	require.Equal(t, codes.NotFound, status.Convert(err).Code())
+}
+
+func TestQueryTicketsIncludeAssignments(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	ticket, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.NoError(t, err)
+
+	query := func() *pb.Ticket {
+		stream, err := om.Query().QueryTickets(ctx, &pb.QueryTicketsRequest{Pool: &pb.Pool{}, IncludeAssignments: true})
+		require.NoError(t, err)
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		require.Len(t, resp.Tickets, 1)
+		_, err = stream.Recv()
+		require.Equal(t, io.EOF, err)
+		return resp.Tickets[0]
+	}
+
+	require.Equal(t, ticket.Id, query().Id)
+	require.Nil(t, query().Assignment)
+
+	// Assign the ticket without deindexing it, as happens when deindexing
+	// fails after an assignment.
+	store := statestore.New(om.cfg)
+	defer store.Close()
+	_, _, err = store.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{{TicketIds: []string{ticket.Id}, Assignment: &pb.Assignment{Connection: "1.2.3.4"}}},
+	})
+	require.NoError(t, err)
+
+	got := query()
+	require.Equal(t, ticket.Id, got.Id)
+	require.Equal(t, "1.2.3.4", got.GetAssignment().GetConnection())
+}
+
+func TestQueryTicketsOrder(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	var created []string
+	for i := 0; i < 3; i++ {
+		ticket, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+		require.NoError(t, err)
+		created = append(created, ticket.Id)
+	}
+	newest := []string{created[2], created[1], created[0]}
+
+	queryTickets := func(pool *pb.Pool) []string {
+		stream, err := om.Query().QueryTickets(ctx, &pb.QueryTicketsRequest{Pool: pool})
+		require.NoError(t, err)
+		var ids []string
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return ids
+			}
+			require.NoError(t, err)
+			for _, ticket := range resp.Tickets {
+				ids = append(ids, ticket.Id)
+			}
+		}
+	}
+	queryTicketIds := func(pool *pb.Pool) []string {
+		stream, err := om.Query().QueryTicketIds(ctx, &pb.QueryTicketIdsRequest{Pool: pool})
+		require.NoError(t, err)
+		var ids []string
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return ids
+			}
+			require.NoError(t, err)
+			ids = append(ids, resp.Ids...)
+		}
+	}
+
+	for _, query := range []func(*pb.Pool) []string{queryTickets, queryTicketIds} {
+		require.Equal(t, created, query(&pb.Pool{Order: pb.Pool_OLDEST_FIRST}))
+		require.Equal(t, newest, query(&pb.Pool{Order: pb.Pool_NEWEST_FIRST}))
+
+		random := query(&pb.Pool{Order: pb.Pool_RANDOM, OrderSeed: 1})
+		require.ElementsMatch(t, created, random)
+		require.Equal(t, random, query(&pb.Pool{Order: pb.Pool_RANDOM, OrderSeed: 1}))
+	}
+	require.Equal(t, queryTickets(&pb.Pool{Order: pb.Pool_RANDOM, OrderSeed: 1}), queryTicketIds(&pb.Pool{Order: pb.Pool_RANDOM, OrderSeed: 1}))
+
+	stream, err := om.Query().QueryTickets(ctx, &pb.QueryTicketsRequest{Pool: &pb.Pool{Order: pb.Pool_Order(42)}})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestTicketFound(t *testing.T) {
 	for _, tc := range testcases.IncludedTestCases() {
 		tc := tc
@@ -176,6 +176,47 @@ func TestAssignTicketsMissingTicket(t *testing.T) {
 	require.True(t, proto.Equal(expected, resp), fmt.Sprintf("Protobuf messages are not equal\nexpected: %v\nactual: %v", expected, resp))
 }
 
+func TestStreamAssignTickets(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 4; i++ {
+		ticket, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+		require.Nil(t, err)
+		ids = append(ids, ticket.Id)
+	}
+	_, err := om.Frontend().DeleteTicket(ctx, &pb.DeleteTicketRequest{TicketId: ids[3]})
+	require.Nil(t, err)
+
+	stream, err := om.Backend().StreamAssignTickets(ctx)
+	require.Nil(t, err)
+
+	groups := []*pb.AssignmentGroup{
+		{TicketIds: []string{ids[0], ids[1]}, Assignment: &pb.Assignment{Connection: "a"}},
+		{TicketIds: []string{ids[2], ids[3]}, Assignment: &pb.Assignment{Connection: "b"}},
+	}
+	expected := []*pb.StreamAssignTicketsResponse{
+		{},
+		{Failures: []*pb.AssignmentFailure{{TicketId: ids[3], Cause: pb.AssignmentFailure_TICKET_NOT_FOUND}}},
+	}
+	for i, g := range groups {
+		require.Nil(t, stream.Send(&pb.StreamAssignTicketsRequest{Assignment: g}))
+		resp, err := stream.Recv()
+		require.Nil(t, err)
+		require.True(t, proto.Equal(expected[i], resp), fmt.Sprintf("Protobuf messages are not equal\nexpected: %v\nactual: %v", expected[i], resp))
+
+		// The group is committed before the stream ends.
+		got, err := om.Frontend().GetTicket(ctx, &pb.GetTicketRequest{TicketId: g.TicketIds[0]})
+		require.Nil(t, err)
+		require.Equal(t, g.Assignment.Connection, got.GetAssignment().GetConnection())
+	}
+
+	require.Nil(t, stream.CloseSend())
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+}
+
 func TestTicketDelete(t *testing.T) {
 	om := newOM(t)
 	ctx := context.Background()
@@ -192,6 +233,98 @@ func TestTicketDelete(t *testing.T) {
 	require.Equal(t, codes.NotFound, status.Convert(err).Code())
 }
 
+// TestGetTicketsByPlayer covers listing the active tickets of a player as
+// tickets are created and deleted.
+func TestGetTicketsByPlayer(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{PlayerId: "player"}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{PlayerId: "player"}})
+	require.Nil(t, err)
+	_, err = om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{PlayerId: "other-player"}})
+	require.Nil(t, err)
+
+	resp, err := om.Frontend().GetTicketsByPlayer(ctx, &pb.GetTicketsByPlayerRequest{PlayerId: "player"})
+	require.Nil(t, err)
+	ids := []string{}
+	for _, ticket := range resp.Tickets {
+		ids = append(ids, ticket.Id)
+	}
+	require.ElementsMatch(t, []string{t1.Id, t2.Id}, ids)
+
+	_, err = om.Frontend().DeleteTicket(ctx, &pb.DeleteTicketRequest{TicketId: t1.Id})
+	require.Nil(t, err)
+
+	resp, err = om.Frontend().GetTicketsByPlayer(ctx, &pb.GetTicketsByPlayerRequest{PlayerId: "player"})
+	require.Nil(t, err)
+	require.Len(t, resp.Tickets, 1)
+	require.True(t, proto.Equal(t2, resp.Tickets[0]))
+
+	_, err = om.Frontend().GetTicketsByPlayer(ctx, &pb.GetTicketsByPlayerRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+}
+
+// TestCreateAndWatch covers creating tickets and receiving their assignments
+// over a single stream, and the deletion of tickets requested on close.
+func TestCreateAndWatch(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := om.Frontend().CreateAndWatch(streamCtx)
+	require.Nil(t, err)
+
+	require.Nil(t, stream.Send(&pb.CreateAndWatchRequest{Ticket: &pb.Ticket{}}))
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	kept := resp.GetTicket()
+	require.NotEmpty(t, kept.GetId())
+
+	require.Nil(t, stream.Send(&pb.CreateAndWatchRequest{Ticket: &pb.Ticket{}, DeleteOnClose: true}))
+	resp, err = stream.Recv()
+	require.Nil(t, err)
+	deleted := resp.GetTicket()
+	require.NotEmpty(t, deleted.GetId())
+
+	// The client is done creating tickets, but keeps watching them.
+	require.Nil(t, stream.CloseSend())
+
+	for _, a := range []struct {
+		ticket     *pb.Ticket
+		connection string
+	}{
+		{kept, "a"},
+		{deleted, "b"},
+	} {
+		_, err = om.Backend().AssignTickets(ctx, &pb.AssignTicketsRequest{
+			Assignments: []*pb.AssignmentGroup{{
+				TicketIds:  []string{a.ticket.GetId()},
+				Assignment: &pb.Assignment{Connection: a.connection},
+			}},
+		})
+		require.Nil(t, err)
+
+		resp, err = stream.Recv()
+		require.Nil(t, err)
+		require.Nil(t, resp.GetTicket())
+		require.Equal(t, a.ticket.GetId(), resp.GetTicketId())
+		require.Equal(t, a.connection, resp.GetAssignment().GetConnection())
+	}
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, err := om.Frontend().GetTicket(ctx, &pb.GetTicketRequest{TicketId: deleted.GetId()})
+		return status.Code(err) == codes.NotFound
+	}, 5*time.Second, 10*time.Millisecond)
+
+	get, err := om.Frontend().GetTicket(ctx, &pb.GetTicketRequest{TicketId: kept.GetId()})
+	require.Nil(t, err)
+	require.Equal(t, "a", get.GetAssignment().GetConnection())
+}
+
 // TestEmptyReleaseTicketsRequest covers that it is valid to not have any ticket
 // ids when releasing tickets.  (though it's not really doing anything...)
 func TestEmptyReleaseTicketsRequest(t *testing.T) {
@@ -37,6 +37,12 @@ func (s *FakeFrontend) CreateTicket(ctx context.Context, req *pb.CreateTicketReq
 	return &pb.Ticket{}, nil
 }
 
+// BulkCreateTickets creates each of the Tickets as CreateTicket would, writing
+// them to state storage together.
+func (s *FakeFrontend) BulkCreateTickets(ctx context.Context, req *pb.CreateTicketsRequest) (*pb.CreateTicketsResponse, error) {
+	return &pb.CreateTicketsResponse{}, nil
+}
+
 // DeleteTicket removes the Ticket from state storage and from corresponding
 // configured indices. Deleting the ticket stops the ticket from being
 // considered for future matchmaking requests.
@@ -44,17 +50,38 @@ func (s *FakeFrontend) DeleteTicket(ctx context.Context, req *pb.DeleteTicketReq
 	return nil, status.Error(codes.Unimplemented, "not implemented")
 }
 
+// DeleteTickets removes the Tickets, by id or by Pool, from state storage and
+// from corresponding configured indices.
+func (s *FakeFrontend) DeleteTickets(ctx context.Context, req *pb.DeleteTicketsRequest) (*pb.DeleteTicketsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
 // GetTicket fetches the ticket associated with the specified Ticket id.
 func (s *FakeFrontend) GetTicket(ctx context.Context, req *pb.GetTicketRequest) (*pb.Ticket, error) {
 	return nil, status.Error(codes.Unimplemented, "not implemented")
 }
 
+// GetTicketsByPlayer fetches the active tickets of the specified player id.
+func (s *FakeFrontend) GetTicketsByPlayer(ctx context.Context, req *pb.GetTicketsByPlayerRequest) (*pb.GetTicketsByPlayerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+// UpdateTicket replaces the search fields of the specified Ticket.
+func (s *FakeFrontend) UpdateTicket(ctx context.Context, req *pb.UpdateTicketRequest) (*pb.Ticket, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
 // WatchAssignments streams matchmaking results from Open Match for the
 // provided Ticket id.
 func (s *FakeFrontend) WatchAssignments(req *pb.WatchAssignmentsRequest, stream pb.FrontendService_WatchAssignmentsServer) error {
 	return status.Error(codes.Unimplemented, "not implemented")
 }
 
+// CreateAndWatch creates Tickets and streams back their Assignments.
+func (s *FakeFrontend) CreateAndWatch(stream pb.FrontendService_CreateAndWatchServer) error {
+	return status.Error(codes.Unimplemented, "not implemented")
+}
+
 // AcknowledgeBackfill is used to notify OpenMatch about GameServer connection info.
 // This triggers an assignment process.
 func (s *FakeFrontend) AcknowledgeBackfill(ctx context.Context, req *pb.AcknowledgeBackfillRequest) (*pb.Backfill, error) {
@@ -71,6 +98,11 @@ func (s *FakeFrontend) DeleteBackfill(ctx context.Context, req *pb.DeleteBackfil
 	return nil, status.Error(codes.Unimplemented, "not implemented")
 }
 
+// CancelBackfill deletes a Backfill and re-queues its Tickets.
+func (s *FakeFrontend) CancelBackfill(ctx context.Context, req *pb.CancelBackfillRequest) (*pb.CancelBackfillResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
 // GetBackfill fetches a Backfill object by its ID.
 func (s *FakeFrontend) GetBackfill(ctx context.Context, req *pb.GetBackfillRequest) (*pb.Backfill, error) {
 	return nil, status.Error(codes.Unimplemented, "not implemented")
@@ -80,3 +112,9 @@ func (s *FakeFrontend) GetBackfill(ctx context.Context, req *pb.GetBackfillReque
 func (s *FakeFrontend) UpdateBackfill(ctx context.Context, req *pb.UpdateBackfillRequest) (*pb.Backfill, error) {
 	return nil, status.Error(codes.Unimplemented, "not implemented")
 }
+
+// QueryBackfills streams back the Backfills which match all the Filters of the
+// Pool.
+func (s *FakeFrontend) QueryBackfills(req *pb.QueryBackfillsRequest, stream pb.FrontendService_QueryBackfillsServer) error {
+	return status.Error(codes.Unimplemented, "not implemented")
+}
@@ -0,0 +1,177 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/sirupsen/logrus"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/rpc"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	defaultWebhookTimeout        = 5 * time.Second
+	defaultWebhookMaxElapsedTime = 30 * time.Second
+	webhookQueueSize             = 1000
+)
+
+var webhookLogger = logrus.WithFields(logrus.Fields{
+	"app":       "openmatch",
+	"component": "assignmentWebhook",
+})
+
+// AssignmentWebhook posts the Assignments made by Open Match to a configured
+// URL, so that other services learn of them without watching Tickets.  The
+// body is an AssignTicketsRequest in JSON, holding only the Tickets which were
+// assigned.  Posts are made in order by a background worker and retried with
+// backoff; they never block the assignment.  Notifications are dropped when
+// the worker falls too far behind.
+type AssignmentWebhook struct {
+	url    string
+	client *http.Client
+	retry  *rpc.RetryParams
+	queue  chan *pb.AssignTicketsRequest
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewAssignmentWebhook starts the webhook worker, or returns nil if no
+// assignmentWebhook.url is configured.
+func NewAssignmentWebhook(cfg config.View) *AssignmentWebhook {
+	url := cfg.GetString("assignmentWebhook.url")
+	if url == "" {
+		return nil
+	}
+
+	timeout := cfg.GetDuration("assignmentWebhook.timeout")
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	retry := rpc.RetryParamsFromConfig(cfg)
+	retry.MaxElapsedTime = cfg.GetDuration("assignmentWebhook.maxElapsedTime")
+	if retry.MaxElapsedTime <= 0 {
+		retry.MaxElapsedTime = defaultWebhookMaxElapsedTime
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &AssignmentWebhook{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+		retry:  retry,
+		queue:  make(chan *pb.AssignTicketsRequest, webhookQueueSize),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go w.run(ctx)
+	return w
+}
+
+// Notify queues the Assignments of req which did not fail.  It is a no-op on a
+// nil webhook.
+func (w *AssignmentWebhook) Notify(req *pb.AssignTicketsRequest, resp *pb.AssignTicketsResponse) {
+	if w == nil {
+		return
+	}
+
+	failed := map[string]struct{}{}
+	for _, f := range resp.GetFailures() {
+		failed[f.TicketId] = struct{}{}
+	}
+
+	assigned := &pb.AssignTicketsRequest{}
+	for _, ag := range req.GetAssignments() {
+		ids := make([]string, 0, len(ag.TicketIds))
+		for _, id := range ag.TicketIds {
+			if _, ok := failed[id]; !ok {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) > 0 {
+			assigned.Assignments = append(assigned.Assignments, &pb.AssignmentGroup{TicketIds: ids, Assignment: ag.Assignment})
+		}
+	}
+	if len(assigned.Assignments) == 0 {
+		return
+	}
+
+	select {
+	case w.queue <- assigned:
+	default:
+		webhookLogger.WithFields(logrus.Fields{
+			"assignments": len(assigned.Assignments),
+		}).Warning("assignment webhook queue is full, dropping notification")
+	}
+}
+
+func (w *AssignmentWebhook) run(ctx context.Context) {
+	defer close(w.done)
+
+	var m jsonpb.Marshaler
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case assigned := <-w.queue:
+			body, err := m.MarshalToString(assigned)
+			if err != nil {
+				webhookLogger.WithError(err).Error("failed to marshal assignment webhook body")
+				continue
+			}
+			if err = backoff.Retry(func() error { return w.post(ctx, body) }, w.retry.NewBackOff(ctx)); err != nil {
+				webhookLogger.WithError(err).Error("failed to post assignments to webhook")
+			}
+		}
+	}
+}
+
+// post sends body to the webhook.  Client errors are not retried.
+func (w *AssignmentWebhook) post(ctx context.Context, body string) error {
+	req, err := http.NewRequest(http.MethodPost, w.url, strings.NewReader(body))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	if err = resp.Body.Close(); err != nil {
+		webhookLogger.WithError(err).Warning("failed to close assignment webhook response body")
+	}
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return backoff.Permanent(fmt.Errorf("assignment webhook returned %s", resp.Status))
+	default:
+		return fmt.Errorf("assignment webhook returned %s", resp.Status)
+	}
+}
+
+// Close stops the worker, abandoning queued notifications.
+func (w *AssignmentWebhook) Close() {
+	w.cancel()
+	<-w.done
+}
@@ -0,0 +1,85 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestAssignmentWebhook(t *testing.T) {
+	received := make(chan *pb.AssignTicketsRequest, 1)
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		// The first attempt fails, and is retried.
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := &pb.AssignTicketsRequest{}
+		require.NoError(t, jsonpb.UnmarshalString(string(body), req))
+		received <- req
+	}))
+	defer server.Close()
+
+	cfg := viper.New()
+	cfg.Set("assignmentWebhook.url", server.URL)
+	cfg.Set("backoff.initialInterval", 10*time.Millisecond)
+	webhook := NewAssignmentWebhook(cfg)
+	defer webhook.Close()
+
+	assignment := &pb.Assignment{Connection: "1.2.3.4:5678"}
+	webhook.Notify(&pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{TicketIds: []string{"1", "2", "3"}, Assignment: assignment},
+			{TicketIds: []string{"4"}, Assignment: &pb.Assignment{Connection: "5.6.7.8:9012"}},
+		},
+	}, &pb.AssignTicketsResponse{
+		Failures: []*pb.AssignmentFailure{
+			{TicketId: "3", Cause: pb.AssignmentFailure_TICKET_NOT_FOUND},
+			{TicketId: "4", Cause: pb.AssignmentFailure_TICKET_NOT_FOUND},
+		},
+	})
+
+	select {
+	case req := <-received:
+		// The tickets which failed assignment, and groups left empty, are left out.
+		want := &pb.AssignTicketsRequest{
+			Assignments: []*pb.AssignmentGroup{
+				{TicketIds: []string{"1", "2"}, Assignment: assignment},
+			},
+		}
+		require.True(t, proto.Equal(want, req), "got %v", req)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "webhook was not called")
+	}
+}
+
+func TestAssignmentWebhookDisabled(t *testing.T) {
+	require.Nil(t, NewAssignmentWebhook(viper.New()))
+}
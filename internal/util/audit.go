@@ -0,0 +1,250 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	auditSink    = "audit.sink"
+	auditFile    = "audit.file"
+	auditURL     = "audit.url"
+	auditTimeout = "audit.timeout"
+
+	defaultAuditTimeout = 5 * time.Second
+	auditQueueSize      = 10000
+)
+
+// AuditEventType is what happened to the Ticket of an AuditEvent.
+type AuditEventType string
+
+const (
+	// AuditAssigned records a Ticket being assigned.
+	AuditAssigned AuditEventType = "assigned"
+	// AuditDeleted records a Ticket being deleted.
+	AuditDeleted AuditEventType = "deleted"
+)
+
+// AuditEvent is a single record of the audit trail.
+type AuditEvent struct {
+	Time     time.Time      `json:"time"`
+	Type     AuditEventType `json:"type"`
+	TicketID string         `json:"ticketId"`
+	// Connection is set on assignments only.
+	Connection string `json:"connection,omitempty"`
+}
+
+// AuditSink stores AuditEvents.  Write is only called by one goroutine at a
+// time, and must only ever append.
+type AuditSink interface {
+	Write(e *AuditEvent) error
+	Close() error
+}
+
+var auditLogger = logrus.WithFields(logrus.Fields{
+	"app":       "openmatch",
+	"component": "audit",
+})
+
+// AuditLog records an audit trail of Ticket assignments and deletions.  Events
+// are written by a background worker, so recording never blocks the caller.
+// Events are dropped when the worker falls too far behind.  A nil AuditLog
+// records nothing.
+type AuditLog struct {
+	sink  AuditSink
+	queue chan *AuditEvent
+	done  chan struct{}
+}
+
+// NewAuditLog returns the audit log writing to the sink configured by
+// audit.sink, or nil if no sink is configured.  The "file" sink appends JSON
+// lines to audit.file, and the "webhook" sink posts each event as JSON to
+// audit.url.
+func NewAuditLog(cfg config.View) (*AuditLog, error) {
+	var sink AuditSink
+	switch name := cfg.GetString(auditSink); name {
+	case "":
+		return nil, nil
+	case "file":
+		f, err := os.OpenFile(cfg.GetString(auditFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open %s", auditFile)
+		}
+		sink = &auditFileSink{f: f}
+	case "webhook":
+		url := cfg.GetString(auditURL)
+		if url == "" {
+			return nil, errors.Errorf("%s is required by the webhook audit sink", auditURL)
+		}
+		timeout := cfg.GetDuration(auditTimeout)
+		if timeout <= 0 {
+			timeout = defaultAuditTimeout
+		}
+		sink = &auditWebhookSink{url: url, client: &http.Client{Timeout: timeout}}
+	default:
+		return nil, errors.Errorf("unknown %s %q, must be \"file\" or \"webhook\"", auditSink, name)
+	}
+	return NewAuditLogWithSink(sink), nil
+}
+
+// NewAuditLogWithSink returns an audit log writing to sink.
+func NewAuditLogWithSink(sink AuditSink) *AuditLog {
+	l := &AuditLog{
+		sink:  sink,
+		queue: make(chan *AuditEvent, auditQueueSize),
+		done:  make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+// Assigned records the Assignments of req which did not fail.
+func (l *AuditLog) Assigned(req *pb.AssignTicketsRequest, resp *pb.AssignTicketsResponse) {
+	if l == nil {
+		return
+	}
+
+	failed := map[string]struct{}{}
+	for _, f := range resp.GetFailures() {
+		failed[f.TicketId] = struct{}{}
+	}
+
+	now := time.Now().UTC()
+	for _, ag := range req.GetAssignments() {
+		for _, id := range ag.GetTicketIds() {
+			if _, ok := failed[id]; ok {
+				continue
+			}
+			l.record(&AuditEvent{
+				Time:       now,
+				Type:       AuditAssigned,
+				TicketID:   id,
+				Connection: ag.GetAssignment().GetConnection(),
+			})
+		}
+	}
+}
+
+// Deleted records the deletion of a Ticket.
+func (l *AuditLog) Deleted(id string) {
+	if l == nil {
+		return
+	}
+
+	l.record(&AuditEvent{
+		Time:     time.Now().UTC(),
+		Type:     AuditDeleted,
+		TicketID: id,
+	})
+}
+
+func (l *AuditLog) record(e *AuditEvent) {
+	select {
+	case l.queue <- e:
+	default:
+		auditLogger.WithFields(logrus.Fields{
+			"type": e.Type,
+			"id":   e.TicketID,
+		}).Error("audit queue is full, dropping event")
+	}
+}
+
+func (l *AuditLog) run() {
+	defer close(l.done)
+
+	for e := range l.queue {
+		if err := l.sink.Write(e); err != nil {
+			auditLogger.WithError(err).WithFields(logrus.Fields{
+				"type": e.Type,
+				"id":   e.TicketID,
+			}).Error("failed to write audit event")
+		}
+	}
+}
+
+// Close writes the events already recorded, and closes the sink.  Nothing may
+// be recorded after Close.
+func (l *AuditLog) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	close(l.queue)
+	<-l.done
+	return l.sink.Close()
+}
+
+// auditFileSink appends events to a file as JSON lines.
+type auditFileSink struct {
+	f *os.File
+}
+
+func (s *auditFileSink) Write(e *AuditEvent) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = s.f.Write(append(b, '\n'))
+	return err
+}
+
+func (s *auditFileSink) Close() error {
+	return s.f.Close()
+}
+
+// auditWebhookSink posts each event to a URL.
+type auditWebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *auditWebhookSink) Write(e *AuditEvent) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	if err = resp.Body.Close(); err != nil {
+		auditLogger.WithError(err).Warning("failed to close audit webhook response body")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *auditWebhookSink) Close() error {
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestAuditLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	cfg := viper.New()
+	cfg.Set("audit.sink", "file")
+	cfg.Set("audit.file", path)
+
+	start := time.Now()
+	l, err := NewAuditLog(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, l)
+
+	// The ticket which failed assignment is left out.
+	l.Assigned(&pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{TicketIds: []string{"1", "2"}, Assignment: &pb.Assignment{Connection: "1.2.3.4:5678"}},
+		},
+	}, &pb.AssignTicketsResponse{
+		Failures: []*pb.AssignmentFailure{{TicketId: "2", Cause: pb.AssignmentFailure_TICKET_NOT_FOUND}},
+	})
+	l.Deleted("3")
+	require.NoError(t, l.Close())
+
+	events := readAuditFile(t, path)
+	require.Len(t, events, 2)
+	for _, e := range events {
+		require.False(t, e.Time.Before(start))
+		e.Time = time.Time{}
+	}
+	require.Equal(t, []*AuditEvent{
+		{Type: AuditAssigned, TicketID: "1", Connection: "1.2.3.4:5678"},
+		{Type: AuditDeleted, TicketID: "3"},
+	}, events)
+
+	// The file is appended to, not overwritten.
+	l, err = NewAuditLog(cfg)
+	require.NoError(t, err)
+	l.Deleted("4")
+	require.NoError(t, l.Close())
+	require.Len(t, readAuditFile(t, path), 3)
+}
+
+func TestAuditLogWebhook(t *testing.T) {
+	received := make(chan *AuditEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e := &AuditEvent{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(e))
+		received <- e
+	}))
+	defer server.Close()
+
+	cfg := viper.New()
+	cfg.Set("audit.sink", "webhook")
+	cfg.Set("audit.url", server.URL)
+	l, err := NewAuditLog(cfg)
+	require.NoError(t, err)
+
+	l.Deleted("1")
+	require.NoError(t, l.Close())
+	e := <-received
+	require.Equal(t, AuditDeleted, e.Type)
+	require.Equal(t, "1", e.TicketID)
+}
+
+func TestNewAuditLogConfig(t *testing.T) {
+	l, err := NewAuditLog(viper.New())
+	require.NoError(t, err)
+	require.Nil(t, l)
+	// A nil audit log records nothing.
+	l.Deleted("1")
+	require.NoError(t, l.Close())
+
+	cfg := viper.New()
+	cfg.Set("audit.sink", "kafka")
+	_, err = NewAuditLog(cfg)
+	require.Error(t, err)
+
+	cfg.Set("audit.sink", "webhook")
+	_, err = NewAuditLog(cfg)
+	require.Error(t, err)
+}
+
+func readAuditFile(t *testing.T, path string) []*AuditEvent {
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	var events []*AuditEvent
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		e := &AuditEvent{}
+		require.NoError(t, json.Unmarshal([]byte(line), e))
+		events = append(events, e)
+	}
+	return events
+}
@@ -0,0 +1,106 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	assignmentEncryptionKey = "assignmentEncryptionKey"
+	// encryptedPrefix marks encrypted connection strings, so assignments
+	// written before encryption was enabled are still read as plaintext.
+	encryptedPrefix = "enc:v1:"
+)
+
+// AssignmentCipher encrypts the connection of Assignments before they are
+// written to state storage, and decrypts them when read back.  A nil
+// AssignmentCipher leaves Assignments in plaintext.
+type AssignmentCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAssignmentCipher returns the cipher using the base64 encoded AES key
+// configured by assignmentEncryptionKey, or nil if no key is configured.
+func NewAssignmentCipher(cfg config.View) (*AssignmentCipher, error) {
+	if !cfg.IsSet(assignmentEncryptionKey) || cfg.GetString(assignmentEncryptionKey) == "" {
+		return nil, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(cfg.GetString(assignmentEncryptionKey))
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s is not valid base64", assignmentEncryptionKey)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s is not a valid AES key", assignmentEncryptionKey)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the assignment cipher")
+	}
+	return &AssignmentCipher{aead: aead}, nil
+}
+
+// Encrypt returns a copy of the Assignment with its connection encrypted.
+func (c *AssignmentCipher) Encrypt(a *pb.Assignment) (*pb.Assignment, error) {
+	if c == nil || a == nil || a.Connection == "" {
+		return a, nil
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate nonce: %s", err.Error())
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(a.Connection), nil)
+
+	encrypted := proto.Clone(a).(*pb.Assignment)
+	encrypted.Connection = encryptedPrefix + base64.StdEncoding.EncodeToString(sealed)
+	return encrypted, nil
+}
+
+// Decrypt returns a copy of the Assignment with its connection decrypted.
+// Connections which were not encrypted are returned as is.
+func (c *AssignmentCipher) Decrypt(a *pb.Assignment) (*pb.Assignment, error) {
+	if c == nil || a == nil || !strings.HasPrefix(a.Connection, encryptedPrefix) {
+		return a, nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(a.Connection, encryptedPrefix))
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return nil, status.Error(codes.Internal, "failed to decode the encrypted assignment connection")
+	}
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	connection, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decrypt the assignment connection: %s", err.Error())
+	}
+
+	decrypted := proto.Clone(a).(*pb.Assignment)
+	decrypted.Connection = string(connection)
+	return decrypted, nil
+}
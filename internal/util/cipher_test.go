@@ -0,0 +1,78 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestAssignmentCipher(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("assignmentEncryptionKey", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	c, err := NewAssignmentCipher(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+
+	a := &pb.Assignment{Connection: "1.2.3.4:5678?token=secret"}
+	encrypted, err := c.Encrypt(a)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(encrypted.Connection, encryptedPrefix))
+	require.NotContains(t, encrypted.Connection, "secret")
+	// The input is left untouched.
+	require.Equal(t, "1.2.3.4:5678?token=secret", a.Connection)
+
+	decrypted, err := c.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, a.Connection, decrypted.Connection)
+
+	// Assignments stored before encryption was enabled read as plaintext.
+	plain, err := c.Decrypt(a)
+	require.NoError(t, err)
+	require.Equal(t, a.Connection, plain.Connection)
+
+	// A different key cannot read the connection.
+	cfg.Set("assignmentEncryptionKey", base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	other, err := NewAssignmentCipher(cfg)
+	require.NoError(t, err)
+	_, err = other.Decrypt(encrypted)
+	require.Error(t, err)
+}
+
+func TestNewAssignmentCipher(t *testing.T) {
+	cfg := viper.New()
+	c, err := NewAssignmentCipher(cfg)
+	require.NoError(t, err)
+	require.Nil(t, c)
+
+	// A nil cipher leaves assignments in plaintext.
+	a := &pb.Assignment{Connection: "1.2.3.4"}
+	encrypted, err := c.Encrypt(a)
+	require.NoError(t, err)
+	require.Equal(t, a, encrypted)
+
+	cfg.Set("assignmentEncryptionKey", "not base64!")
+	_, err = NewAssignmentCipher(cfg)
+	require.Error(t, err)
+
+	cfg.Set("assignmentEncryptionKey", base64.StdEncoding.EncodeToString([]byte("short")))
+	_, err = NewAssignmentCipher(cfg)
+	require.Error(t, err)
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backfill reads and writes the Backfill extensions Open Match gives
+// a meaning to, so that match functions and Open Match agree on them.
+package backfill
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// OpenSlotsKey is the Backfill extension holding the number of open slots, as
+// a google.protobuf.Int32Value.  The Backend decrements it by the number of
+// Tickets of each Match filling the Backfill.
+const OpenSlotsKey = "open-slots"
+
+// ErrNoOpenSlots is returned by OpenSlots for a Backfill without open slots
+// recorded.
+var ErrNoOpenSlots = errors.New("backfill has no open slots recorded")
+
+// OpenSlots returns the number of open slots recorded on the Backfill.  It
+// returns ErrNoOpenSlots if the Backfill has none recorded, and fails if the
+// Backfill is nil or the recorded value is malformed or negative.
+func OpenSlots(b *pb.Backfill) (int32, error) {
+	if b == nil {
+		return 0, fmt.Errorf("backfill is required")
+	}
+
+	a, ok := b.GetExtensions()[OpenSlotsKey]
+	if !ok {
+		return 0, ErrNoOpenSlots
+	}
+
+	var val wrappers.Int32Value
+	if err := ptypes.UnmarshalAny(a, &val); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal open slots: %w", err)
+	}
+	if val.Value < 0 {
+		return 0, fmt.Errorf("open slots must not be negative, got %d", val.Value)
+	}
+
+	return val.Value, nil
+}
+
+// SetOpenSlots records the number of open slots on the Backfill.  It fails if
+// the Backfill is nil or the number is negative.
+func SetOpenSlots(b *pb.Backfill, n int32) error {
+	if b == nil {
+		return fmt.Errorf("backfill is required")
+	}
+	if n < 0 {
+		return fmt.Errorf("open slots must not be negative, got %d", n)
+	}
+
+	a, err := ptypes.MarshalAny(&wrappers.Int32Value{Value: n})
+	if err != nil {
+		return err
+	}
+
+	if b.Extensions == nil {
+		b.Extensions = make(map[string]*any.Any)
+	}
+	b.Extensions[OpenSlotsKey] = a
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backfill
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestOpenSlots(t *testing.T) {
+	b := &pb.Backfill{}
+	require.NoError(t, SetOpenSlots(b, 3))
+	n, err := OpenSlots(b)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), n)
+
+	require.NoError(t, SetOpenSlots(b, 0))
+	n, err = OpenSlots(b)
+	require.NoError(t, err)
+	require.Equal(t, int32(0), n)
+
+	// Stored as an Int32Value, as Open Match reads it.
+	var val wrappers.Int32Value
+	require.NoError(t, ptypes.UnmarshalAny(b.Extensions[OpenSlotsKey], &val))
+	require.Equal(t, int32(0), val.Value)
+}
+
+func TestOpenSlotsInvalid(t *testing.T) {
+	_, err := OpenSlots(nil)
+	require.Error(t, err)
+
+	_, err = OpenSlots(&pb.Backfill{})
+	require.Equal(t, ErrNoOpenSlots, err)
+
+	malformed, err := ptypes.MarshalAny(&wrappers.StringValue{Value: "3"})
+	require.NoError(t, err)
+	_, err = OpenSlots(&pb.Backfill{Extensions: map[string]*any.Any{OpenSlotsKey: malformed}})
+	require.Error(t, err)
+
+	negative, err := ptypes.MarshalAny(&wrappers.Int32Value{Value: -1})
+	require.NoError(t, err)
+	_, err = OpenSlots(&pb.Backfill{Extensions: map[string]*any.Any{OpenSlotsKey: negative}})
+	require.Error(t, err)
+}
+
+func TestSetOpenSlotsInvalid(t *testing.T) {
+	require.Error(t, SetOpenSlots(nil, 1))
+
+	b := &pb.Backfill{}
+	require.Error(t, SetOpenSlots(b, -1))
+	require.Empty(t, b.Extensions)
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
 
 	"google.golang.org/grpc"
 	"open-match.dev/open-match/pkg/pb"
@@ -26,24 +27,66 @@ import (
 
 // QueryPool queries queryService and returns the tickets that belong to the specified pool.
 func QueryPool(ctx context.Context, queryClient pb.QueryServiceClient, pool *pb.Pool, opts ...grpc.CallOption) ([]*pb.Ticket, error) {
+	var tickets []*pb.Ticket
+	err := QueryPoolPaged(ctx, queryClient, pool, 0, func(page []*pb.Ticket) error {
+		tickets = append(tickets, page...)
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+// QueryPoolPaged queries queryService and calls fn with the tickets that belong to the specified pool, pageSize
+// tickets at a time, as they are received.  Only the last page may hold fewer tickets.  A pageSize which is not
+// positive passes on the pages streamed by queryService, whose size is set by its queryPageSize.  Match functions
+// which process tickets incrementally don't need to hold every ticket of large pools in memory at once.
+//
+// The query stops at the first error returned by fn, which QueryPoolPaged returns.
+func QueryPoolPaged(ctx context.Context, queryClient pb.QueryServiceClient, pool *pb.Pool, pageSize int, fn func([]*pb.Ticket) error, opts ...grpc.CallOption) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	query, err := queryClient.QueryTickets(ctx, &pb.QueryTicketsRequest{Pool: pool}, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("error calling queryService.QueryTickets: %w", err)
+		return fmt.Errorf("error calling queryService.QueryTickets: %w", err)
 	}
 
-	var tickets []*pb.Ticket
+	var page []*pb.Ticket
 	for {
 		resp, err := query.Recv()
 		if err == io.EOF {
-			return tickets, nil
+			break
 		}
 
 		if err != nil {
-			return nil, fmt.Errorf("error receiving tickets from queryService.QueryTickets: %w", err)
+			return fmt.Errorf("error receiving tickets from queryService.QueryTickets: %w", err)
+		}
+
+		if pageSize <= 0 {
+			if len(resp.Tickets) == 0 {
+				continue
+			}
+			if err = fn(resp.Tickets); err != nil {
+				return err
+			}
+			continue
 		}
 
-		tickets = append(tickets, resp.Tickets...)
+		page = append(page, resp.Tickets...)
+		for len(page) >= pageSize {
+			if err = fn(page[:pageSize:pageSize]); err != nil {
+				return err
+			}
+			page = page[pageSize:]
+		}
 	}
+
+	if len(page) > 0 {
+		return fn(page)
+	}
+	return nil
 }
 
 // QueryPools queries queryService and returns a map of pool names to the tickets belonging to those pools.
@@ -89,7 +132,23 @@ func QueryPools(ctx context.Context, queryClient pb.QueryServiceClient, pools []
 
 // QueryBackfillPool queries queryService and returns the backfills that belong to the specified pool.
 func QueryBackfillPool(ctx context.Context, queryClient pb.QueryServiceClient, pool *pb.Pool, opts ...grpc.CallOption) ([]*pb.Backfill, error) {
-	query, err := queryClient.QueryBackfills(ctx, &pb.QueryBackfillsRequest{Pool: pool}, opts...)
+	return QueryBackfills(ctx, queryClient, &pb.QueryBackfillsRequest{Pool: pool}, opts...)
+}
+
+// QueryBackfillPoolSince queries queryService and returns the backfills that belong to the specified pool, and which
+// have a generation greater than the supplied one.  Match functions re-querying in a loop can pass the generation they
+// last processed, to skip backfills which have not been updated since.
+func QueryBackfillPoolSince(ctx context.Context, queryClient pb.QueryServiceClient, pool *pb.Pool, generation int64, opts ...grpc.CallOption) ([]*pb.Backfill, error) {
+	return QueryBackfills(ctx, queryClient, &pb.QueryBackfillsRequest{
+		Pool:       pool,
+		Generation: &pb.GenerationRange{Min: generation + 1, Max: math.MaxInt64},
+	}, opts...)
+}
+
+// QueryBackfills queries queryService and returns the backfills matching the request, such as the backfills of a pool
+// within a generation range.
+func QueryBackfills(ctx context.Context, queryClient pb.QueryServiceClient, req *pb.QueryBackfillsRequest, opts ...grpc.CallOption) ([]*pb.Backfill, error) {
+	query, err := queryClient.QueryBackfills(ctx, req, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("error calling queryService.QueryBackfills: %w", err)
 	}
@@ -0,0 +1,117 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matchfunction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// fakeQueryClient streams back the responses of QueryTickets.
+type fakeQueryClient struct {
+	pb.QueryServiceClient
+	responses []*pb.QueryTicketsResponse
+}
+
+func (c *fakeQueryClient) QueryTickets(ctx context.Context, req *pb.QueryTicketsRequest, opts ...grpc.CallOption) (pb.QueryService_QueryTicketsClient, error) {
+	return &fakeQueryTicketsClient{responses: c.responses}, nil
+}
+
+type fakeQueryTicketsClient struct {
+	grpc.ClientStream
+	responses []*pb.QueryTicketsResponse
+}
+
+func (s *fakeQueryTicketsClient) Recv() (*pb.QueryTicketsResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+	resp := s.responses[0]
+	s.responses = s.responses[1:]
+	return resp, nil
+}
+
+// streamedTickets returns a client streaming the tickets in responses of the
+// given sizes.
+func streamedTickets(sizes ...int) (*fakeQueryClient, []*pb.Ticket) {
+	c := &fakeQueryClient{}
+	var tickets []*pb.Ticket
+	for _, size := range sizes {
+		resp := &pb.QueryTicketsResponse{}
+		for i := 0; i < size; i++ {
+			t := &pb.Ticket{Id: fmt.Sprint(len(tickets))}
+			resp.Tickets = append(resp.Tickets, t)
+			tickets = append(tickets, t)
+		}
+		c.responses = append(c.responses, resp)
+	}
+	return c, tickets
+}
+
+func TestQueryPoolPaged(t *testing.T) {
+	for _, tc := range []struct {
+		pageSize int
+		want     []int
+	}{
+		{pageSize: 4, want: []int{4, 4, 2}},
+		{pageSize: 5, want: []int{5, 5}},
+		{pageSize: 20, want: []int{10}},
+		// Pages are streamed as queryService sent them.
+		{pageSize: 0, want: []int{3, 3, 4}},
+	} {
+		tc := tc
+		t.Run(fmt.Sprintf("page size %d", tc.pageSize), func(t *testing.T) {
+			client, tickets := streamedTickets(3, 3, 0, 4)
+
+			var sizes []int
+			var got []*pb.Ticket
+			err := QueryPoolPaged(context.Background(), client, &pb.Pool{}, tc.pageSize, func(page []*pb.Ticket) error {
+				sizes = append(sizes, len(page))
+				got = append(got, page...)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.want, sizes)
+			require.Equal(t, tickets, got)
+		})
+	}
+}
+
+func TestQueryPoolPagedStopsOnError(t *testing.T) {
+	client, _ := streamedTickets(3, 3, 4)
+	stop := errors.New("stop")
+
+	calls := 0
+	err := QueryPoolPaged(context.Background(), client, &pb.Pool{}, 2, func(page []*pb.Ticket) error {
+		calls++
+		return stop
+	})
+	require.Equal(t, stop, err)
+	require.Equal(t, 1, calls)
+}
+
+func TestQueryPool(t *testing.T) {
+	client, tickets := streamedTickets(3, 0, 4)
+	got, err := QueryPool(context.Background(), client, &pb.Pool{})
+	require.NoError(t, err)
+	require.Equal(t, tickets, got)
+}
@@ -0,0 +1,92 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matchfunction
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// swapEpsilon is the least improvement worth a swap, so rounding errors can't
+// make BalanceTeams swap back and forth.
+const swapEpsilon = 1e-9
+
+// BalanceTeams partitions tickets into teams of teamSize tickets each, such
+// that the teams' average values of the doubleArg search field, such as MMR,
+// are as close as it can find.  Tickets without the doubleArg count as zero.
+// The number of tickets must be a multiple of teamSize.
+//
+// Finding the best partition is expensive for large teams, so the tickets are
+// first dealt to the team with the lowest total, strongest first, and then
+// tickets are swapped between pairs of teams while that narrows their gap.
+func BalanceTeams(tickets []*pb.Ticket, teamSize int, doubleArg string) ([][]*pb.Ticket, error) {
+	if teamSize <= 0 {
+		return nil, fmt.Errorf("team size must be positive, got %d", teamSize)
+	}
+	if len(tickets)%teamSize != 0 {
+		return nil, fmt.Errorf("%d tickets can't be split into teams of %d", len(tickets), teamSize)
+	}
+
+	value := func(t *pb.Ticket) float64 {
+		return t.GetSearchFields().GetDoubleArgs()[doubleArg]
+	}
+
+	sorted := make([]*pb.Ticket, len(tickets))
+	copy(sorted, tickets)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return value(sorted[i]) > value(sorted[j])
+	})
+
+	teams := make([][]*pb.Ticket, len(tickets)/teamSize)
+	totals := make([]float64, len(teams))
+	for _, t := range sorted {
+		best := -1
+		for i := range teams {
+			if len(teams[i]) < teamSize && (best < 0 || totals[i] < totals[best]) {
+				best = i
+			}
+		}
+		teams[best] = append(teams[best], t)
+		totals[best] += value(t)
+	}
+
+	// Every accepted swap narrows the gap between two teams, which
+	// reduces the sum of squared totals, so this terminates.
+	for improved := true; improved; {
+		improved = false
+		for a := range teams {
+			for b := a + 1; b < len(teams); b++ {
+				for i := range teams[a] {
+					for j := range teams[b] {
+						d := value(teams[a][i]) - value(teams[b][j])
+						gap := totals[a] - totals[b]
+						if math.Abs(gap-2*d) >= math.Abs(gap)-swapEpsilon {
+							continue
+						}
+						teams[a][i], teams[b][j] = teams[b][j], teams[a][i]
+						totals[a] -= d
+						totals[b] += d
+						improved = true
+					}
+				}
+			}
+		}
+	}
+
+	return teams, nil
+}
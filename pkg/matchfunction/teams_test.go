@@ -0,0 +1,136 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matchfunction
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func mmrTickets(mmrs ...float64) []*pb.Ticket {
+	var tickets []*pb.Ticket
+	for i, mmr := range mmrs {
+		tickets = append(tickets, &pb.Ticket{
+			Id:           fmt.Sprintf("%d", i),
+			SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": mmr}},
+		})
+	}
+	return tickets
+}
+
+func teamAverages(teams [][]*pb.Ticket) []float64 {
+	var averages []float64
+	for _, team := range teams {
+		total := 0.0
+		for _, t := range team {
+			total += t.GetSearchFields().GetDoubleArgs()["mmr"]
+		}
+		averages = append(averages, total/float64(len(team)))
+	}
+	return averages
+}
+
+func TestBalanceTeams(t *testing.T) {
+	tests := []struct {
+		name       string
+		mmrs       []float64
+		teamSize   int
+		maxSpread  float64
+		numOfTeams int
+	}{
+		{
+			name:       "strongest and weakest together",
+			mmrs:       []float64{2000, 1900, 1100, 1000},
+			teamSize:   2,
+			maxSpread:  0,
+			numOfTeams: 2,
+		},
+		{
+			name:       "greedy dealing alone is unbalanced",
+			mmrs:       []float64{8, 7, 6, 5, 4, 3, 2, 1},
+			teamSize:   4,
+			maxSpread:  0,
+			numOfTeams: 2,
+		},
+		{
+			name:       "three teams",
+			mmrs:       []float64{1500, 1400, 1300, 1200, 1100, 1000},
+			teamSize:   2,
+			maxSpread:  0,
+			numOfTeams: 3,
+		},
+		{
+			name:       "unbalanceable",
+			mmrs:       []float64{3000, 1000},
+			teamSize:   1,
+			maxSpread:  2000,
+			numOfTeams: 2,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			tickets := mmrTickets(test.mmrs...)
+			teams, err := BalanceTeams(tickets, test.teamSize, "mmr")
+			require.NoError(t, err)
+			require.Len(t, teams, test.numOfTeams)
+
+			seen := map[string]bool{}
+			for _, team := range teams {
+				require.Len(t, team, test.teamSize)
+				for _, ticket := range team {
+					require.False(t, seen[ticket.Id])
+					seen[ticket.Id] = true
+				}
+			}
+			require.Len(t, seen, len(tickets))
+
+			averages := teamAverages(teams)
+			for _, a := range averages {
+				require.InDelta(t, averages[0], a, test.maxSpread)
+			}
+		})
+	}
+}
+
+func TestBalanceTeamsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 100; n++ {
+		var mmrs []float64
+		for i := 0; i < 10; i++ {
+			mmrs = append(mmrs, 1000+r.Float64()*1000)
+		}
+
+		teams, err := BalanceTeams(mmrTickets(mmrs...), 5, "mmr")
+		require.NoError(t, err)
+
+		// A random 5v5 split is off by about 150 on average.
+		averages := teamAverages(teams)
+		require.InDelta(t, averages[0], averages[1], 50)
+	}
+}
+
+func TestBalanceTeamsInvalid(t *testing.T) {
+	_, err := BalanceTeams(mmrTickets(1, 2, 3), 2, "mmr")
+	require.Error(t, err)
+
+	_, err = BalanceTeams(mmrTickets(1, 2), 0, "mmr")
+	require.Error(t, err)
+}
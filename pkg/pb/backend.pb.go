@@ -23,6 +23,8 @@ package pb
 import (
 	context "context"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
@@ -134,7 +136,7 @@ func (x AssignmentFailure_Cause) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AssignmentFailure_Cause.Descriptor instead.
 func (AssignmentFailure_Cause) EnumDescriptor() ([]byte, []int) {
-	return file_api_backend_proto_rawDescGZIP(), []int{8, 0}
+	return file_api_backend_proto_rawDescGZIP(), []int{9, 0}
 }
 
 // FunctionConfig specifies a MMF address and client type for Backend to establish connections with the MMF
@@ -210,6 +212,12 @@ type FetchMatchesRequest struct {
 	Config *FunctionConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
 	// A MatchProfile that will be sent to the MatchFunction server of this FetchMatches call.
 	Profile *MatchProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	// If set, a call which returns no matches sends a final response carrying
+	// only a NoMatchSummary before the stream ends.
+	//
+	// BETA FEATURE WARNING: This field is not finalized and still subject to
+	// possible change or removal.
+	IncludeNoMatchSummary bool `protobuf:"varint,3,opt,name=include_no_match_summary,json=includeNoMatchSummary,proto3" json:"include_no_match_summary,omitempty"`
 }
 
 func (x *FetchMatchesRequest) Reset() {
@@ -258,6 +266,13 @@ func (x *FetchMatchesRequest) GetProfile() *MatchProfile {
 	return nil
 }
 
+func (x *FetchMatchesRequest) GetIncludeNoMatchSummary() bool {
+	if x != nil {
+		return x.IncludeNoMatchSummary
+	}
+	return false
+}
+
 type FetchMatchesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -266,6 +281,12 @@ type FetchMatchesResponse struct {
 	// A Match generated by the user-defined MMF with the specified MatchProfiles.
 	// A valid Match response will contain at least one ticket.
 	Match *Match `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	// Set only on the final response of a call which returned no matches, when
+	// include_no_match_summary was requested. Match is unset on that response.
+	//
+	// BETA FEATURE WARNING: This field is not finalized and still subject to
+	// possible change or removal.
+	NoMatchSummary *NoMatchSummary `protobuf:"bytes,2,opt,name=no_match_summary,json=noMatchSummary,proto3" json:"no_match_summary,omitempty"`
 }
 
 func (x *FetchMatchesResponse) Reset() {
@@ -307,6 +328,83 @@ func (x *FetchMatchesResponse) GetMatch() *Match {
 	return nil
 }
 
+func (x *FetchMatchesResponse) GetNoMatchSummary() *NoMatchSummary {
+	if x != nil {
+		return x.NoMatchSummary
+	}
+	return nil
+}
+
+// NoMatchSummary describes a FetchMatches call which returned no matches.
+//
+// BETA FEATURE WARNING: This message is not finalized and still subject to
+// possible change or removal.
+type NoMatchSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Proposals is the number of match proposals returned by the MatchFunction.
+	Proposals int32 `protobuf:"varint,1,opt,name=proposals,proto3" json:"proposals,omitempty"`
+	// TicketsConsidered is the number of Tickets in those proposals.
+	TicketsConsidered int32 `protobuf:"varint,2,opt,name=tickets_considered,json=ticketsConsidered,proto3" json:"tickets_considered,omitempty"`
+	// Reason describes why none of the proposals were returned as matches.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *NoMatchSummary) Reset() {
+	*x = NoMatchSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NoMatchSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoMatchSummary) ProtoMessage() {}
+
+func (x *NoMatchSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoMatchSummary.ProtoReflect.Descriptor instead.
+func (*NoMatchSummary) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NoMatchSummary) GetProposals() int32 {
+	if x != nil {
+		return x.Proposals
+	}
+	return 0
+}
+
+func (x *NoMatchSummary) GetTicketsConsidered() int32 {
+	if x != nil {
+		return x.TicketsConsidered
+	}
+	return 0
+}
+
+func (x *NoMatchSummary) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type ReleaseTicketsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -320,7 +418,7 @@ type ReleaseTicketsRequest struct {
 func (x *ReleaseTicketsRequest) Reset() {
 	*x = ReleaseTicketsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_backend_proto_msgTypes[3]
+		mi := &file_api_backend_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -333,7 +431,7 @@ func (x *ReleaseTicketsRequest) String() string {
 func (*ReleaseTicketsRequest) ProtoMessage() {}
 
 func (x *ReleaseTicketsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_backend_proto_msgTypes[3]
+	mi := &file_api_backend_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -346,7 +444,7 @@ func (x *ReleaseTicketsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseTicketsRequest.ProtoReflect.Descriptor instead.
 func (*ReleaseTicketsRequest) Descriptor() ([]byte, []int) {
-	return file_api_backend_proto_rawDescGZIP(), []int{3}
+	return file_api_backend_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ReleaseTicketsRequest) GetTicketIds() []string {
@@ -365,7 +463,7 @@ type ReleaseTicketsResponse struct {
 func (x *ReleaseTicketsResponse) Reset() {
 	*x = ReleaseTicketsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_backend_proto_msgTypes[4]
+		mi := &file_api_backend_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -378,7 +476,7 @@ func (x *ReleaseTicketsResponse) String() string {
 func (*ReleaseTicketsResponse) ProtoMessage() {}
 
 func (x *ReleaseTicketsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_backend_proto_msgTypes[4]
+	mi := &file_api_backend_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,7 +489,7 @@ func (x *ReleaseTicketsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseTicketsResponse.ProtoReflect.Descriptor instead.
 func (*ReleaseTicketsResponse) Descriptor() ([]byte, []int) {
-	return file_api_backend_proto_rawDescGZIP(), []int{4}
+	return file_api_backend_proto_rawDescGZIP(), []int{5}
 }
 
 type ReleaseAllTicketsRequest struct {
@@ -403,7 +501,7 @@ type ReleaseAllTicketsRequest struct {
 func (x *ReleaseAllTicketsRequest) Reset() {
 	*x = ReleaseAllTicketsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_backend_proto_msgTypes[5]
+		mi := &file_api_backend_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -416,7 +514,7 @@ func (x *ReleaseAllTicketsRequest) String() string {
 func (*ReleaseAllTicketsRequest) ProtoMessage() {}
 
 func (x *ReleaseAllTicketsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_backend_proto_msgTypes[5]
+	mi := &file_api_backend_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -429,7 +527,7 @@ func (x *ReleaseAllTicketsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseAllTicketsRequest.ProtoReflect.Descriptor instead.
 func (*ReleaseAllTicketsRequest) Descriptor() ([]byte, []int) {
-	return file_api_backend_proto_rawDescGZIP(), []int{5}
+	return file_api_backend_proto_rawDescGZIP(), []int{6}
 }
 
 type ReleaseAllTicketsResponse struct {
@@ -441,7 +539,7 @@ type ReleaseAllTicketsResponse struct {
 func (x *ReleaseAllTicketsResponse) Reset() {
 	*x = ReleaseAllTicketsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_backend_proto_msgTypes[6]
+		mi := &file_api_backend_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -454,7 +552,7 @@ func (x *ReleaseAllTicketsResponse) String() string {
 func (*ReleaseAllTicketsResponse) ProtoMessage() {}
 
 func (x *ReleaseAllTicketsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_backend_proto_msgTypes[6]
+	mi := &file_api_backend_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -467,7 +565,7 @@ func (x *ReleaseAllTicketsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseAllTicketsResponse.ProtoReflect.Descriptor instead.
 func (*ReleaseAllTicketsResponse) Descriptor() ([]byte, []int) {
-	return file_api_backend_proto_rawDescGZIP(), []int{6}
+	return file_api_backend_proto_rawDescGZIP(), []int{7}
 }
 
 // AssignmentGroup contains an Assignment and the Tickets to which it should be applied.
@@ -485,7 +583,7 @@ type AssignmentGroup struct {
 func (x *AssignmentGroup) Reset() {
 	*x = AssignmentGroup{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_backend_proto_msgTypes[7]
+		mi := &file_api_backend_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -498,7 +596,7 @@ func (x *AssignmentGroup) String() string {
 func (*AssignmentGroup) ProtoMessage() {}
 
 func (x *AssignmentGroup) ProtoReflect() protoreflect.Message {
-	mi := &file_api_backend_proto_msgTypes[7]
+	mi := &file_api_backend_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -511,7 +609,7 @@ func (x *AssignmentGroup) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignmentGroup.ProtoReflect.Descriptor instead.
 func (*AssignmentGroup) Descriptor() ([]byte, []int) {
-	return file_api_backend_proto_rawDescGZIP(), []int{7}
+	return file_api_backend_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AssignmentGroup) GetTicketIds() []string {
@@ -541,7 +639,7 @@ type AssignmentFailure struct {
 func (x *AssignmentFailure) Reset() {
 	*x = AssignmentFailure{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_backend_proto_msgTypes[8]
+		mi := &file_api_backend_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -554,7 +652,7 @@ func (x *AssignmentFailure) String() string {
 func (*AssignmentFailure) ProtoMessage() {}
 
 func (x *AssignmentFailure) ProtoReflect() protoreflect.Message {
-	mi := &file_api_backend_proto_msgTypes[8]
+	mi := &file_api_backend_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -567,7 +665,7 @@ func (x *AssignmentFailure) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignmentFailure.ProtoReflect.Descriptor instead.
 func (*AssignmentFailure) Descriptor() ([]byte, []int) {
-	return file_api_backend_proto_rawDescGZIP(), []int{8}
+	return file_api_backend_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AssignmentFailure) GetTicketId() string {
@@ -596,7 +694,7 @@ type AssignTicketsRequest struct {
 func (x *AssignTicketsRequest) Reset() {
 	*x = AssignTicketsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_backend_proto_msgTypes[9]
+		mi := &file_api_backend_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -609,7 +707,7 @@ func (x *AssignTicketsRequest) String() string {
 func (*AssignTicketsRequest) ProtoMessage() {}
 
 func (x *AssignTicketsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_backend_proto_msgTypes[9]
+	mi := &file_api_backend_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -622,7 +720,7 @@ func (x *AssignTicketsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignTicketsRequest.ProtoReflect.Descriptor instead.
 func (*AssignTicketsRequest) Descriptor() ([]byte, []int) {
-	return file_api_backend_proto_rawDescGZIP(), []int{9}
+	return file_api_backend_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AssignTicketsRequest) GetAssignments() []*AssignmentGroup {
@@ -644,7 +742,7 @@ type AssignTicketsResponse struct {
 func (x *AssignTicketsResponse) Reset() {
 	*x = AssignTicketsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_backend_proto_msgTypes[10]
+		mi := &file_api_backend_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -657,7 +755,7 @@ func (x *AssignTicketsResponse) String() string {
 func (*AssignTicketsResponse) ProtoMessage() {}
 
 func (x *AssignTicketsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_backend_proto_msgTypes[10]
+	mi := &file_api_backend_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -670,7 +768,7 @@ func (x *AssignTicketsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignTicketsResponse.ProtoReflect.Descriptor instead.
 func (*AssignTicketsResponse) Descriptor() ([]byte, []int) {
-	return file_api_backend_proto_rawDescGZIP(), []int{10}
+	return file_api_backend_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *AssignTicketsResponse) GetFailures() []*AssignmentFailure {
@@ -680,249 +778,1312 @@ func (x *AssignTicketsResponse) GetFailures() []*AssignmentFailure {
 	return nil
 }
 
-var File_api_backend_proto protoreflect.FileDescriptor
+// BETA FEATURE WARNING: This Request message is not finalized and still subject
+// to possible change or removal.
+type StreamAssignTicketsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_api_backend_proto_rawDesc = []byte{
-	0x0a, 0x11, 0x61, 0x70, 0x69, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x1a, 0x12,
-	0x61, 0x70, 0x69, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61,
-	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x1a, 0x2c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x73, 0x77, 0x61,
-	0x67, 0x67, 0x65, 0x72, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e,
-	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x88,
-	0x01, 0x0a, 0x0e, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x32, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61,
-	0x74, 0x63, 0x68, 0x2e, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x1a, 0x0a,
-	0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x52, 0x50, 0x43, 0x10, 0x00, 0x12,
-	0x08, 0x0a, 0x04, 0x52, 0x45, 0x53, 0x54, 0x10, 0x01, 0x22, 0x7b, 0x0a, 0x13, 0x46, 0x65, 0x74,
-	0x63, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x31, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x46, 0x75, 0x6e,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x12, 0x31, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68,
-	0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70,
-	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x3e, 0x0a, 0x14, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4d,
-	0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26,
-	0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52,
-	0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x22, 0x36, 0x0a, 0x15, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73,
-	0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1d, 0x0a, 0x0a, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x64, 0x73, 0x22, 0x18,
-	0x0a, 0x16, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x0a, 0x18, 0x52, 0x65, 0x6c, 0x65,
-	0x61, 0x73, 0x65, 0x41, 0x6c, 0x6c, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x22, 0x1b, 0x0a, 0x19, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x41,
-	0x6c, 0x6c, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x67, 0x0a, 0x0f, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x69,
-	0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74,
-	0x49, 0x64, 0x73, 0x12, 0x35, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61,
-	0x74, 0x63, 0x68, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0a,
-	0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x96, 0x01, 0x0a, 0x11, 0x41,
-	0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
-	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x64, 0x12, 0x38, 0x0a,
-	0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x6f,
-	0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d,
-	0x65, 0x6e, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x2e, 0x43, 0x61, 0x75, 0x73, 0x65,
-	0x52, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x22, 0x2a, 0x0a, 0x05, 0x43, 0x61, 0x75, 0x73, 0x65,
-	0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x14, 0x0a,
-	0x10, 0x54, 0x49, 0x43, 0x4b, 0x45, 0x54, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e,
-	0x44, 0x10, 0x01, 0x22, 0x54, 0x0a, 0x14, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x63,
-	0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0b, 0x61,
-	0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x41, 0x73, 0x73,
-	0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x0b, 0x61, 0x73,
-	0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x51, 0x0a, 0x15, 0x41, 0x73, 0x73,
-	0x69, 0x67, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68,
-	0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75,
-	0x72, 0x65, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x32, 0xad, 0x04, 0x0a,
-	0x0e, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x7e, 0x0a, 0x0c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12,
-	0x1e, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x46, 0x65, 0x74, 0x63,
-	0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1f, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x46, 0x65, 0x74, 0x63,
-	0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x2b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x25, 0x22, 0x20, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x61,
-	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x6d, 0x61, 0x74,
-	0x63, 0x68, 0x65, 0x73, 0x3a, 0x66, 0x65, 0x74, 0x63, 0x68, 0x3a, 0x01, 0x2a, 0x30, 0x01, 0x12,
-	0x80, 0x01, 0x0a, 0x0d, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74,
-	0x73, 0x12, 0x1f, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x41, 0x73,
-	0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x41,
-	0x73, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x26, 0x22, 0x21, 0x2f, 0x76,
-	0x31, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x2f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x3a, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x3a,
-	0x01, 0x2a, 0x12, 0x84, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54, 0x69,
-	0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x20, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63,
-	0x68, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61,
-	0x74, 0x63, 0x68, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65,
-	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2d, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x27, 0x22, 0x22, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x3a, 0x72,
-	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x90, 0x01, 0x0a, 0x11, 0x52, 0x65,
-	0x6c, 0x65, 0x61, 0x73, 0x65, 0x41, 0x6c, 0x6c, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12,
-	0x23, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x52, 0x65, 0x6c, 0x65,
-	0x61, 0x73, 0x65, 0x41, 0x6c, 0x6c, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68,
-	0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x41, 0x6c, 0x6c, 0x54, 0x69, 0x63, 0x6b, 0x65,
-	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x30, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x2a, 0x22, 0x25, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x3a, 0x72,
-	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x61, 0x6c, 0x6c, 0x3a, 0x01, 0x2a, 0x42, 0x8a, 0x03, 0x5a,
-	0x20, 0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x64, 0x65, 0x76, 0x2f,
-	0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70,
-	0x62, 0xaa, 0x02, 0x09, 0x4f, 0x70, 0x65, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x92, 0x41, 0xd8,
-	0x02, 0x12, 0xb1, 0x01, 0x0a, 0x07, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x22, 0x49, 0x0a,
-	0x0a, 0x4f, 0x70, 0x65, 0x6e, 0x20, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x68, 0x74, 0x74,
-	0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e,
-	0x64, 0x65, 0x76, 0x1a, 0x23, 0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2d,
-	0x64, 0x69, 0x73, 0x63, 0x75, 0x73, 0x73, 0x40, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x67, 0x72,
-	0x6f, 0x75, 0x70, 0x73, 0x2e, 0x63, 0x6f, 0x6d, 0x2a, 0x56, 0x0a, 0x12, 0x41, 0x70, 0x61, 0x63,
-	0x68, 0x65, 0x20, 0x32, 0x2e, 0x30, 0x20, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x40,
-	0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x66, 0x6f, 0x72, 0x67, 0x61, 0x6d, 0x65,
-	0x73, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2f, 0x62, 0x6c, 0x6f,
-	0x62, 0x2f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x2f, 0x4c, 0x49, 0x43, 0x45, 0x4e, 0x53, 0x45,
-	0x32, 0x03, 0x31, 0x2e, 0x30, 0x2a, 0x02, 0x01, 0x02, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70,
-	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x52, 0x3b, 0x0a,
-	0x03, 0x34, 0x30, 0x34, 0x12, 0x34, 0x0a, 0x2a, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x65, 0x64,
-	0x20, 0x77, 0x68, 0x65, 0x6e, 0x20, 0x74, 0x68, 0x65, 0x20, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x20, 0x64, 0x6f, 0x65, 0x73, 0x20, 0x6e, 0x6f, 0x74, 0x20, 0x65, 0x78, 0x69, 0x73,
-	0x74, 0x2e, 0x12, 0x06, 0x0a, 0x04, 0x9a, 0x02, 0x01, 0x07, 0x72, 0x3d, 0x0a, 0x18, 0x4f, 0x70,
-	0x65, 0x6e, 0x20, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x20, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
-	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f,
-	0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x73,
-	0x69, 0x74, 0x65, 0x2f, 0x64, 0x6f, 0x63, 0x73, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	// The assignment group to commit.
+	Assignment *AssignmentGroup `protobuf:"bytes,1,opt,name=assignment,proto3" json:"assignment,omitempty"`
 }
 
-var (
-	file_api_backend_proto_rawDescOnce sync.Once
-	file_api_backend_proto_rawDescData = file_api_backend_proto_rawDesc
-)
+func (x *StreamAssignTicketsRequest) Reset() {
+	*x = StreamAssignTicketsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_api_backend_proto_rawDescGZIP() []byte {
-	file_api_backend_proto_rawDescOnce.Do(func() {
-		file_api_backend_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_backend_proto_rawDescData)
-	})
-	return file_api_backend_proto_rawDescData
+func (x *StreamAssignTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_api_backend_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_backend_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
-var file_api_backend_proto_goTypes = []interface{}{
-	(FunctionConfig_Type)(0),          // 0: openmatch.FunctionConfig.Type
-	(AssignmentFailure_Cause)(0),      // 1: openmatch.AssignmentFailure.Cause
-	(*FunctionConfig)(nil),            // 2: openmatch.FunctionConfig
-	(*FetchMatchesRequest)(nil),       // 3: openmatch.FetchMatchesRequest
-	(*FetchMatchesResponse)(nil),      // 4: openmatch.FetchMatchesResponse
-	(*ReleaseTicketsRequest)(nil),     // 5: openmatch.ReleaseTicketsRequest
-	(*ReleaseTicketsResponse)(nil),    // 6: openmatch.ReleaseTicketsResponse
-	(*ReleaseAllTicketsRequest)(nil),  // 7: openmatch.ReleaseAllTicketsRequest
-	(*ReleaseAllTicketsResponse)(nil), // 8: openmatch.ReleaseAllTicketsResponse
-	(*AssignmentGroup)(nil),           // 9: openmatch.AssignmentGroup
-	(*AssignmentFailure)(nil),         // 10: openmatch.AssignmentFailure
-	(*AssignTicketsRequest)(nil),      // 11: openmatch.AssignTicketsRequest
-	(*AssignTicketsResponse)(nil),     // 12: openmatch.AssignTicketsResponse
-	(*MatchProfile)(nil),              // 13: openmatch.MatchProfile
-	(*Match)(nil),                     // 14: openmatch.Match
-	(*Assignment)(nil),                // 15: openmatch.Assignment
+func (*StreamAssignTicketsRequest) ProtoMessage() {}
+
+func (x *StreamAssignTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_api_backend_proto_depIdxs = []int32{
-	0,  // 0: openmatch.FunctionConfig.type:type_name -> openmatch.FunctionConfig.Type
-	2,  // 1: openmatch.FetchMatchesRequest.config:type_name -> openmatch.FunctionConfig
-	13, // 2: openmatch.FetchMatchesRequest.profile:type_name -> openmatch.MatchProfile
-	14, // 3: openmatch.FetchMatchesResponse.match:type_name -> openmatch.Match
-	15, // 4: openmatch.AssignmentGroup.assignment:type_name -> openmatch.Assignment
-	1,  // 5: openmatch.AssignmentFailure.cause:type_name -> openmatch.AssignmentFailure.Cause
-	9,  // 6: openmatch.AssignTicketsRequest.assignments:type_name -> openmatch.AssignmentGroup
-	10, // 7: openmatch.AssignTicketsResponse.failures:type_name -> openmatch.AssignmentFailure
-	3,  // 8: openmatch.BackendService.FetchMatches:input_type -> openmatch.FetchMatchesRequest
-	11, // 9: openmatch.BackendService.AssignTickets:input_type -> openmatch.AssignTicketsRequest
-	5,  // 10: openmatch.BackendService.ReleaseTickets:input_type -> openmatch.ReleaseTicketsRequest
-	7,  // 11: openmatch.BackendService.ReleaseAllTickets:input_type -> openmatch.ReleaseAllTicketsRequest
-	4,  // 12: openmatch.BackendService.FetchMatches:output_type -> openmatch.FetchMatchesResponse
-	12, // 13: openmatch.BackendService.AssignTickets:output_type -> openmatch.AssignTicketsResponse
-	6,  // 14: openmatch.BackendService.ReleaseTickets:output_type -> openmatch.ReleaseTicketsResponse
-	8,  // 15: openmatch.BackendService.ReleaseAllTickets:output_type -> openmatch.ReleaseAllTicketsResponse
-	12, // [12:16] is the sub-list for method output_type
-	8,  // [8:12] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+
+// Deprecated: Use StreamAssignTicketsRequest.ProtoReflect.Descriptor instead.
+func (*StreamAssignTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{12}
 }
 
-func init() { file_api_backend_proto_init() }
-func file_api_backend_proto_init() {
-	if File_api_backend_proto != nil {
-		return
+func (x *StreamAssignTicketsRequest) GetAssignment() *AssignmentGroup {
+	if x != nil {
+		return x.Assignment
 	}
-	file_api_messages_proto_init()
-	if !protoimpl.UnsafeEnabled {
-		file_api_backend_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FunctionConfig); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_backend_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchMatchesRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return nil
+}
+
+// BETA FEATURE WARNING: This Response message is not finalized and still
+// subject to possible change or removal.
+type StreamAssignTicketsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Failures is a list of the Tickets of the corresponding assignment group that failed assignment along with the cause of failure.
+	Failures []*AssignmentFailure `protobuf:"bytes,1,rep,name=failures,proto3" json:"failures,omitempty"`
+}
+
+func (x *StreamAssignTicketsResponse) Reset() {
+	*x = StreamAssignTicketsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamAssignTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAssignTicketsResponse) ProtoMessage() {}
+
+func (x *StreamAssignTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_api_backend_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchMatchesResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAssignTicketsResponse.ProtoReflect.Descriptor instead.
+func (*StreamAssignTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StreamAssignTicketsResponse) GetFailures() []*AssignmentFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+// BETA FEATURE WARNING: This Request message is not finalized and still subject
+// to possible change or removal.
+type StatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_api_backend_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReleaseTicketsRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{14}
+}
+
+// StatsResponse contains aggregate matchmaking numbers, suitable for status
+// pages. They are read from maintained counters and are approximate.
+//
+// BETA FEATURE WARNING: This Response message is not finalized and still subject
+// to possible change or removal.
+type StatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ActiveTickets is the number of Tickets currently indexed for matchmaking,
+	// including Tickets pending release.
+	ActiveTickets int64 `protobuf:"varint,1,opt,name=active_tickets,json=activeTickets,proto3" json:"active_tickets,omitempty"`
+	// Backfills is the number of Backfills currently indexed.
+	Backfills int64 `protobuf:"varint,2,opt,name=backfills,proto3" json:"backfills,omitempty"`
+	// MatchesLastMinute is the number of matches returned by FetchMatches in the
+	// last minute.
+	MatchesLastMinute int64 `protobuf:"varint,3,opt,name=matches_last_minute,json=matchesLastMinute,proto3" json:"matches_last_minute,omitempty"`
+	// TicketsAssignedLastMinute is the number of Tickets assigned by
+	// AssignTickets in the last minute.
+	TicketsAssignedLastMinute int64 `protobuf:"varint,4,opt,name=tickets_assigned_last_minute,json=ticketsAssignedLastMinute,proto3" json:"tickets_assigned_last_minute,omitempty"`
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StatsResponse) GetActiveTickets() int64 {
+	if x != nil {
+		return x.ActiveTickets
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetBackfills() int64 {
+	if x != nil {
+		return x.Backfills
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetMatchesLastMinute() int64 {
+	if x != nil {
+		return x.MatchesLastMinute
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetTicketsAssignedLastMinute() int64 {
+	if x != nil {
+		return x.TicketsAssignedLastMinute
+	}
+	return 0
+}
+
+// BETA FEATURE WARNING: This Request message is not finalized and still subject
+// to possible change or removal.
+type MatchAndAssignRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A configuration for the MatchFunction server of this MatchAndAssign call.
+	Config *FunctionConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	// A MatchProfile that will be sent to the MatchFunction server of this MatchAndAssign call.
+	Profile *MatchProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	// An Assignment to apply to every Ticket of the selected Match.
+	Assignment *Assignment `protobuf:"bytes,3,opt,name=assignment,proto3" json:"assignment,omitempty"`
+}
+
+func (x *MatchAndAssignRequest) Reset() {
+	*x = MatchAndAssignRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchAndAssignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchAndAssignRequest) ProtoMessage() {}
+
+func (x *MatchAndAssignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchAndAssignRequest.ProtoReflect.Descriptor instead.
+func (*MatchAndAssignRequest) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MatchAndAssignRequest) GetConfig() *FunctionConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *MatchAndAssignRequest) GetProfile() *MatchProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *MatchAndAssignRequest) GetAssignment() *Assignment {
+	if x != nil {
+		return x.Assignment
+	}
+	return nil
+}
+
+// BETA FEATURE WARNING: This Response message is not finalized and still subject
+// to possible change or removal.
+type MatchAndAssignResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The Match selected among the MatchFunction's proposals. Its Tickets carry
+	// the applied Assignment.
+	Match *Match `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	// The Assignment applied to the Tickets of the Match.
+	Assignment *Assignment `protobuf:"bytes,2,opt,name=assignment,proto3" json:"assignment,omitempty"`
+}
+
+func (x *MatchAndAssignResponse) Reset() {
+	*x = MatchAndAssignResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchAndAssignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchAndAssignResponse) ProtoMessage() {}
+
+func (x *MatchAndAssignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchAndAssignResponse.ProtoReflect.Descriptor instead.
+func (*MatchAndAssignResponse) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MatchAndAssignResponse) GetMatch() *Match {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *MatchAndAssignResponse) GetAssignment() *Assignment {
+	if x != nil {
+		return x.Assignment
+	}
+	return nil
+}
+
+// BETA FEATURE WARNING: This Request message is not finalized and still subject
+// to possible change or removal.
+type PreviewMatchesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A configuration for the MatchFunction server of this PreviewMatches call.
+	Config *FunctionConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	// A MatchProfile that will be sent to the MatchFunction server of this PreviewMatches call.
+	Profile *MatchProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+}
+
+func (x *PreviewMatchesRequest) Reset() {
+	*x = PreviewMatchesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreviewMatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewMatchesRequest) ProtoMessage() {}
+
+func (x *PreviewMatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewMatchesRequest.ProtoReflect.Descriptor instead.
+func (*PreviewMatchesRequest) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PreviewMatchesRequest) GetConfig() *FunctionConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *PreviewMatchesRequest) GetProfile() *MatchProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+// BETA FEATURE WARNING: This Response message is not finalized and still subject
+// to possible change or removal.
+type PreviewMatchesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The proposals returned by the MatchFunction, in the order it returned them.
+	Previews []*MatchPreview `protobuf:"bytes,1,rep,name=previews,proto3" json:"previews,omitempty"`
+}
+
+func (x *PreviewMatchesResponse) Reset() {
+	*x = PreviewMatchesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreviewMatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewMatchesResponse) ProtoMessage() {}
+
+func (x *PreviewMatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewMatchesResponse.ProtoReflect.Descriptor instead.
+func (*PreviewMatchesResponse) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PreviewMatchesResponse) GetPreviews() []*MatchPreview {
+	if x != nil {
+		return x.Previews
+	}
+	return nil
+}
+
+// A MatchPreview is a proposal of a PreviewMatches call, with how the default
+// evaluator judged it.
+type MatchPreview struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A Match proposed by the MatchFunction.
+	Match *Match `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	// The DefaultEvaluationCriteria score of the Match, or 0 if the Match does
+	// not set evaluation_input.
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// Selected is true if the default evaluator would return the Match, that is
+	// it does not collide with a higher scored Match.
+	Selected bool `protobuf:"varint,3,opt,name=selected,proto3" json:"selected,omitempty"`
+}
+
+func (x *MatchPreview) Reset() {
+	*x = MatchPreview{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchPreview) ProtoMessage() {}
+
+func (x *MatchPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchPreview.ProtoReflect.Descriptor instead.
+func (*MatchPreview) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MatchPreview) GetMatch() *Match {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *MatchPreview) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *MatchPreview) GetSelected() bool {
+	if x != nil {
+		return x.Selected
+	}
+	return false
+}
+
+// BETA FEATURE WARNING: This Request message is not finalized and still subject
+// to possible change or removal.
+type GetTicketPendingReleaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A TicketId of a generated Ticket.
+	TicketId string `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+}
+
+func (x *GetTicketPendingReleaseRequest) Reset() {
+	*x = GetTicketPendingReleaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTicketPendingReleaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTicketPendingReleaseRequest) ProtoMessage() {}
+
+func (x *GetTicketPendingReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTicketPendingReleaseRequest.ProtoReflect.Descriptor instead.
+func (*GetTicketPendingReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetTicketPendingReleaseRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+// BETA FEATURE WARNING: This Response message is not finalized and still subject
+// to possible change or removal.
+type GetTicketPendingReleaseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// PendingRelease is true if the Ticket was returned in a match and is hidden
+	// from queries until it is assigned or released, or its pending release
+	// times out.
+	PendingRelease bool `protobuf:"varint,1,opt,name=pending_release,json=pendingRelease,proto3" json:"pending_release,omitempty"`
+	// ReleaseTime is when the pending release of the Ticket times out. It is
+	// unset if the Ticket is not pending release.
+	ReleaseTime *timestamp.Timestamp `protobuf:"bytes,2,opt,name=release_time,json=releaseTime,proto3" json:"release_time,omitempty"`
+}
+
+func (x *GetTicketPendingReleaseResponse) Reset() {
+	*x = GetTicketPendingReleaseResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTicketPendingReleaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTicketPendingReleaseResponse) ProtoMessage() {}
+
+func (x *GetTicketPendingReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTicketPendingReleaseResponse.ProtoReflect.Descriptor instead.
+func (*GetTicketPendingReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetTicketPendingReleaseResponse) GetPendingRelease() bool {
+	if x != nil {
+		return x.PendingRelease
+	}
+	return false
+}
+
+func (x *GetTicketPendingReleaseResponse) GetReleaseTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.ReleaseTime
+	}
+	return nil
+}
+
+// BETA FEATURE WARNING: This Request message is not finalized and still subject
+// to possible change or removal.
+type ReserveTicketsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// TicketIds is a list of string representing Open Match generated Ids to be reserved.
+	TicketIds []string `protobuf:"bytes,1,rep,name=ticket_ids,json=ticketIds,proto3" json:"ticket_ids,omitempty"`
+	// Lease is how long the Tickets stay reserved. It defaults to the configured
+	// ticketReservationLease, or to the pending release timeout if that is unset.
+	Lease *duration.Duration `protobuf:"bytes,2,opt,name=lease,proto3" json:"lease,omitempty"`
+}
+
+func (x *ReserveTicketsRequest) Reset() {
+	*x = ReserveTicketsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReserveTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveTicketsRequest) ProtoMessage() {}
+
+func (x *ReserveTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveTicketsRequest.ProtoReflect.Descriptor instead.
+func (*ReserveTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReserveTicketsRequest) GetTicketIds() []string {
+	if x != nil {
+		return x.TicketIds
+	}
+	return nil
+}
+
+func (x *ReserveTicketsRequest) GetLease() *duration.Duration {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
+// BETA FEATURE WARNING: This Response message is not finalized and still subject
+// to possible change or removal.
+type ReserveTicketsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ReleaseTime is when the lease ends and the Tickets return to the pool,
+	// unless they are assigned or released before.
+	ReleaseTime *timestamp.Timestamp `protobuf:"bytes,1,opt,name=release_time,json=releaseTime,proto3" json:"release_time,omitempty"`
+}
+
+func (x *ReserveTicketsResponse) Reset() {
+	*x = ReserveTicketsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_backend_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReserveTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveTicketsResponse) ProtoMessage() {}
+
+func (x *ReserveTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_backend_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveTicketsResponse.ProtoReflect.Descriptor instead.
+func (*ReserveTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_api_backend_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReserveTicketsResponse) GetReleaseTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.ReleaseTime
+	}
+	return nil
+}
+
+var File_api_backend_proto protoreflect.FileDescriptor
+
+var file_api_backend_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x61, 0x70, 0x69, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x1a, 0x12,
+	0x61, 0x70, 0x69, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x2c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x73, 0x77,
+	0x61, 0x67, 0x67, 0x65, 0x72, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e,
+	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x88, 0x01, 0x0a, 0x0e, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x32, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x2e, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x1a,
+	0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x52, 0x50, 0x43, 0x10, 0x00,
+	0x12, 0x08, 0x0a, 0x04, 0x52, 0x45, 0x53, 0x54, 0x10, 0x01, 0x22, 0xb4, 0x01, 0x0a, 0x13, 0x46,
+	0x65, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x46,
+	0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x31, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52,
+	0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x37, 0x0a, 0x18, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x5f, 0x6e, 0x6f, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x4e, 0x6f, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x22, 0x83, 0x01, 0x0a, 0x14, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x43, 0x0a, 0x10, 0x6e, 0x6f, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x4e, 0x6f, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x0e, 0x6e, 0x6f, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x22, 0x75, 0x0a, 0x0e, 0x4e, 0x6f, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x72,
+	0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x11, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x43, 0x6f, 0x6e, 0x73,
+	0x69, 0x64, 0x65, 0x72, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x36,
+	0x0a, 0x15, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x49, 0x64, 0x73, 0x22, 0x18, 0x0a, 0x16, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x1a, 0x0a, 0x18, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x41, 0x6c, 0x6c, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x1b, 0x0a, 0x19,
+	0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x41, 0x6c, 0x6c, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x67, 0x0a, 0x0f, 0x41, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1d, 0x0a, 0x0a,
+	0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x09, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x64, 0x73, 0x12, 0x35, 0x0a, 0x0a, 0x61,
+	0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x41, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65,
+	0x6e, 0x74, 0x22, 0x96, 0x01, 0x0a, 0x11, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x63, 0x6b,
+	0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x2e, 0x43, 0x61, 0x75, 0x73, 0x65, 0x52, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x22,
+	0x2a, 0x0a, 0x05, 0x43, 0x61, 0x75, 0x73, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x49, 0x43, 0x4b, 0x45, 0x54, 0x5f,
+	0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x01, 0x22, 0x54, 0x0a, 0x14, 0x41,
+	0x73, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0b, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x0b, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x22, 0x51, 0x0a, 0x15, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x66, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x73, 0x22, 0x58, 0x0a, 0x1a, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x52, 0x0a, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x57,
+	0x0a, 0x1b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a,
+	0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x41, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x08, 0x66,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x22, 0x0e, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc5, 0x01, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x2e,
+	0x0a, 0x13, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6d,
+	0x69, 0x6e, 0x75, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x73, 0x4c, 0x61, 0x73, 0x74, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x12, 0x3f,
+	0x0a, 0x1c, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x5f, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x19, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x41, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x4c, 0x61, 0x73, 0x74, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x22,
+	0xb4, 0x01, 0x0a, 0x15, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x41, 0x6e, 0x64, 0x41, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x31, 0x0a, 0x07,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12,
+	0x35, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e,
+	0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x61, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x77, 0x0a, 0x16, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x41,
+	0x6e, 0x64, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x26, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x35, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x0a, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x22,
+	0x7d, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x2e, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x31, 0x0a, 0x07, 0x70,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x4d,
+	0x0a, 0x16, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x76,
+	0x69, 0x65, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x70, 0x65,
+	0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65, 0x76,
+	0x69, 0x65, 0x77, 0x52, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x73, 0x22, 0x68, 0x0a,
+	0x0c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x26, 0x0a,
+	0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0x3d, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x49, 0x64, 0x22, 0x89, 0x01, 0x0a, 0x1f, 0x47, 0x65, 0x74, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x22, 0x67, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x54, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x09, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x64, 0x73, 0x12, 0x2f, 0x0a, 0x05, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0x57, 0x0a, 0x16, 0x52,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x32, 0xf0, 0x0a, 0x0a, 0x0e, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x7e, 0x0a, 0x0c, 0x46, 0x65, 0x74, 0x63, 0x68,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x25,
+	0x22, 0x20, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x3a, 0x66, 0x65, 0x74,
+	0x63, 0x68, 0x3a, 0x01, 0x2a, 0x30, 0x01, 0x12, 0x80, 0x01, 0x0a, 0x0d, 0x41, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6f, 0x70, 0x65,
+	0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2c, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x26, 0x22, 0x21, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x3a, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x3a, 0x01, 0x2a, 0x12, 0x9c, 0x01, 0x0a, 0x13, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x12, 0x25, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x32, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2c, 0x22, 0x27, 0x2f, 0x76, 0x31, 0x2f, 0x62,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x74, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x3a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x61, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x3a, 0x01, 0x2a, 0x28, 0x01, 0x30, 0x01, 0x12, 0x84, 0x01, 0x0a, 0x0e, 0x52, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x20, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x2d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x22, 0x22, 0x2f, 0x76, 0x31, 0x2f, 0x62,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x74, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x3a, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x3a, 0x01, 0x2a,
+	0x12, 0x90, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x41, 0x6c, 0x6c, 0x54,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x23, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x41, 0x6c, 0x6c, 0x54, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6f, 0x70,
+	0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x41,
+	0x6c, 0x6c, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x30, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2a, 0x22, 0x25, 0x2f, 0x76, 0x31, 0x2f, 0x62,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x74, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x3a, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x61, 0x6c, 0x6c,
+	0x3a, 0x01, 0x2a, 0x12, 0x5c, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x12, 0x18, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x83, 0x01, 0x0a, 0x0e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x41, 0x6e, 0x64, 0x41, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x12, 0x20, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x41, 0x6e, 0x64, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x41, 0x6e, 0x64, 0x41, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2c, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x26, 0x22, 0x21, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x3a, 0x61, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x3a, 0x01, 0x2a, 0x12, 0x84, 0x01, 0x0a, 0x0e, 0x50, 0x72, 0x65, 0x76,
+	0x69, 0x65, 0x77, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x6f, 0x70, 0x65,
+	0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x2d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x22, 0x22, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x3a, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x3a, 0x01, 0x2a, 0x12, 0xaf,
+	0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x29, 0x2e, 0x6f, 0x70, 0x65,
+	0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74,
+	0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x3d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x37, 0x12, 0x35, 0x2f, 0x76, 0x31, 0x2f, 0x62,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x74, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2f, 0x7b, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x69, 0x64,
+	0x7d, 0x2f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x12, 0x84, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x54, 0x69, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x12, 0x20, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e,
+	0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27,
+	0x22, 0x22, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x3a, 0x72, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x3a, 0x01, 0x2a, 0x42, 0x8a, 0x03, 0x5a, 0x20, 0x6f, 0x70, 0x65, 0x6e,
+	0x2d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x2d,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0xaa, 0x02, 0x09, 0x4f,
+	0x70, 0x65, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x92, 0x41, 0xd8, 0x02, 0x12, 0xb1, 0x01, 0x0a,
+	0x07, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x22, 0x49, 0x0a, 0x0a, 0x4f, 0x70, 0x65, 0x6e,
+	0x20, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f,
+	0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x64, 0x65, 0x76, 0x1a, 0x23,
+	0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2d, 0x64, 0x69, 0x73, 0x63, 0x75,
+	0x73, 0x73, 0x40, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2a, 0x56, 0x0a, 0x12, 0x41, 0x70, 0x61, 0x63, 0x68, 0x65, 0x20, 0x32, 0x2e,
+	0x30, 0x20, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x68, 0x74, 0x74, 0x70, 0x73,
+	0x3a, 0x2f, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x66, 0x6f, 0x72, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x2f, 0x6f, 0x70, 0x65,
+	0x6e, 0x2d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2f, 0x62, 0x6c, 0x6f, 0x62, 0x2f, 0x6d, 0x61, 0x73,
+	0x74, 0x65, 0x72, 0x2f, 0x4c, 0x49, 0x43, 0x45, 0x4e, 0x53, 0x45, 0x32, 0x03, 0x31, 0x2e, 0x30,
+	0x2a, 0x02, 0x01, 0x02, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x52, 0x3b, 0x0a, 0x03, 0x34, 0x30, 0x34, 0x12,
+	0x34, 0x0a, 0x2a, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x65, 0x64, 0x20, 0x77, 0x68, 0x65, 0x6e,
+	0x20, 0x74, 0x68, 0x65, 0x20, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x20, 0x64, 0x6f,
+	0x65, 0x73, 0x20, 0x6e, 0x6f, 0x74, 0x20, 0x65, 0x78, 0x69, 0x73, 0x74, 0x2e, 0x12, 0x06, 0x0a,
+	0x04, 0x9a, 0x02, 0x01, 0x07, 0x72, 0x3d, 0x0a, 0x18, 0x4f, 0x70, 0x65, 0x6e, 0x20, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x20, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x21, 0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x2d,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x73, 0x69, 0x74, 0x65, 0x2f, 0x64,
+	0x6f, 0x63, 0x73, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_backend_proto_rawDescOnce sync.Once
+	file_api_backend_proto_rawDescData = file_api_backend_proto_rawDesc
+)
+
+func file_api_backend_proto_rawDescGZIP() []byte {
+	file_api_backend_proto_rawDescOnce.Do(func() {
+		file_api_backend_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_backend_proto_rawDescData)
+	})
+	return file_api_backend_proto_rawDescData
+}
+
+var file_api_backend_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_backend_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_api_backend_proto_goTypes = []interface{}{
+	(FunctionConfig_Type)(0),                // 0: openmatch.FunctionConfig.Type
+	(AssignmentFailure_Cause)(0),            // 1: openmatch.AssignmentFailure.Cause
+	(*FunctionConfig)(nil),                  // 2: openmatch.FunctionConfig
+	(*FetchMatchesRequest)(nil),             // 3: openmatch.FetchMatchesRequest
+	(*FetchMatchesResponse)(nil),            // 4: openmatch.FetchMatchesResponse
+	(*NoMatchSummary)(nil),                  // 5: openmatch.NoMatchSummary
+	(*ReleaseTicketsRequest)(nil),           // 6: openmatch.ReleaseTicketsRequest
+	(*ReleaseTicketsResponse)(nil),          // 7: openmatch.ReleaseTicketsResponse
+	(*ReleaseAllTicketsRequest)(nil),        // 8: openmatch.ReleaseAllTicketsRequest
+	(*ReleaseAllTicketsResponse)(nil),       // 9: openmatch.ReleaseAllTicketsResponse
+	(*AssignmentGroup)(nil),                 // 10: openmatch.AssignmentGroup
+	(*AssignmentFailure)(nil),               // 11: openmatch.AssignmentFailure
+	(*AssignTicketsRequest)(nil),            // 12: openmatch.AssignTicketsRequest
+	(*AssignTicketsResponse)(nil),           // 13: openmatch.AssignTicketsResponse
+	(*StreamAssignTicketsRequest)(nil),      // 14: openmatch.StreamAssignTicketsRequest
+	(*StreamAssignTicketsResponse)(nil),     // 15: openmatch.StreamAssignTicketsResponse
+	(*StatsRequest)(nil),                    // 16: openmatch.StatsRequest
+	(*StatsResponse)(nil),                   // 17: openmatch.StatsResponse
+	(*MatchAndAssignRequest)(nil),           // 18: openmatch.MatchAndAssignRequest
+	(*MatchAndAssignResponse)(nil),          // 19: openmatch.MatchAndAssignResponse
+	(*PreviewMatchesRequest)(nil),           // 20: openmatch.PreviewMatchesRequest
+	(*PreviewMatchesResponse)(nil),          // 21: openmatch.PreviewMatchesResponse
+	(*MatchPreview)(nil),                    // 22: openmatch.MatchPreview
+	(*GetTicketPendingReleaseRequest)(nil),  // 23: openmatch.GetTicketPendingReleaseRequest
+	(*GetTicketPendingReleaseResponse)(nil), // 24: openmatch.GetTicketPendingReleaseResponse
+	(*ReserveTicketsRequest)(nil),           // 25: openmatch.ReserveTicketsRequest
+	(*ReserveTicketsResponse)(nil),          // 26: openmatch.ReserveTicketsResponse
+	(*MatchProfile)(nil),                    // 27: openmatch.MatchProfile
+	(*Match)(nil),                           // 28: openmatch.Match
+	(*Assignment)(nil),                      // 29: openmatch.Assignment
+	(*timestamp.Timestamp)(nil),             // 30: google.protobuf.Timestamp
+	(*duration.Duration)(nil),               // 31: google.protobuf.Duration
+}
+var file_api_backend_proto_depIdxs = []int32{
+	0,  // 0: openmatch.FunctionConfig.type:type_name -> openmatch.FunctionConfig.Type
+	2,  // 1: openmatch.FetchMatchesRequest.config:type_name -> openmatch.FunctionConfig
+	27, // 2: openmatch.FetchMatchesRequest.profile:type_name -> openmatch.MatchProfile
+	28, // 3: openmatch.FetchMatchesResponse.match:type_name -> openmatch.Match
+	5,  // 4: openmatch.FetchMatchesResponse.no_match_summary:type_name -> openmatch.NoMatchSummary
+	29, // 5: openmatch.AssignmentGroup.assignment:type_name -> openmatch.Assignment
+	1,  // 6: openmatch.AssignmentFailure.cause:type_name -> openmatch.AssignmentFailure.Cause
+	10, // 7: openmatch.AssignTicketsRequest.assignments:type_name -> openmatch.AssignmentGroup
+	11, // 8: openmatch.AssignTicketsResponse.failures:type_name -> openmatch.AssignmentFailure
+	10, // 9: openmatch.StreamAssignTicketsRequest.assignment:type_name -> openmatch.AssignmentGroup
+	11, // 10: openmatch.StreamAssignTicketsResponse.failures:type_name -> openmatch.AssignmentFailure
+	2,  // 11: openmatch.MatchAndAssignRequest.config:type_name -> openmatch.FunctionConfig
+	27, // 12: openmatch.MatchAndAssignRequest.profile:type_name -> openmatch.MatchProfile
+	29, // 13: openmatch.MatchAndAssignRequest.assignment:type_name -> openmatch.Assignment
+	28, // 14: openmatch.MatchAndAssignResponse.match:type_name -> openmatch.Match
+	29, // 15: openmatch.MatchAndAssignResponse.assignment:type_name -> openmatch.Assignment
+	2,  // 16: openmatch.PreviewMatchesRequest.config:type_name -> openmatch.FunctionConfig
+	27, // 17: openmatch.PreviewMatchesRequest.profile:type_name -> openmatch.MatchProfile
+	22, // 18: openmatch.PreviewMatchesResponse.previews:type_name -> openmatch.MatchPreview
+	28, // 19: openmatch.MatchPreview.match:type_name -> openmatch.Match
+	30, // 20: openmatch.GetTicketPendingReleaseResponse.release_time:type_name -> google.protobuf.Timestamp
+	31, // 21: openmatch.ReserveTicketsRequest.lease:type_name -> google.protobuf.Duration
+	30, // 22: openmatch.ReserveTicketsResponse.release_time:type_name -> google.protobuf.Timestamp
+	3,  // 23: openmatch.BackendService.FetchMatches:input_type -> openmatch.FetchMatchesRequest
+	12, // 24: openmatch.BackendService.AssignTickets:input_type -> openmatch.AssignTicketsRequest
+	14, // 25: openmatch.BackendService.StreamAssignTickets:input_type -> openmatch.StreamAssignTicketsRequest
+	6,  // 26: openmatch.BackendService.ReleaseTickets:input_type -> openmatch.ReleaseTicketsRequest
+	8,  // 27: openmatch.BackendService.ReleaseAllTickets:input_type -> openmatch.ReleaseAllTicketsRequest
+	16, // 28: openmatch.BackendService.Stats:input_type -> openmatch.StatsRequest
+	18, // 29: openmatch.BackendService.MatchAndAssign:input_type -> openmatch.MatchAndAssignRequest
+	20, // 30: openmatch.BackendService.PreviewMatches:input_type -> openmatch.PreviewMatchesRequest
+	23, // 31: openmatch.BackendService.GetTicketPendingRelease:input_type -> openmatch.GetTicketPendingReleaseRequest
+	25, // 32: openmatch.BackendService.ReserveTickets:input_type -> openmatch.ReserveTicketsRequest
+	4,  // 33: openmatch.BackendService.FetchMatches:output_type -> openmatch.FetchMatchesResponse
+	13, // 34: openmatch.BackendService.AssignTickets:output_type -> openmatch.AssignTicketsResponse
+	15, // 35: openmatch.BackendService.StreamAssignTickets:output_type -> openmatch.StreamAssignTicketsResponse
+	7,  // 36: openmatch.BackendService.ReleaseTickets:output_type -> openmatch.ReleaseTicketsResponse
+	9,  // 37: openmatch.BackendService.ReleaseAllTickets:output_type -> openmatch.ReleaseAllTicketsResponse
+	17, // 38: openmatch.BackendService.Stats:output_type -> openmatch.StatsResponse
+	19, // 39: openmatch.BackendService.MatchAndAssign:output_type -> openmatch.MatchAndAssignResponse
+	21, // 40: openmatch.BackendService.PreviewMatches:output_type -> openmatch.PreviewMatchesResponse
+	24, // 41: openmatch.BackendService.GetTicketPendingRelease:output_type -> openmatch.GetTicketPendingReleaseResponse
+	26, // 42: openmatch.BackendService.ReserveTickets:output_type -> openmatch.ReserveTicketsResponse
+	33, // [33:43] is the sub-list for method output_type
+	23, // [23:33] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
+}
+
+func init() { file_api_backend_proto_init() }
+func file_api_backend_proto_init() {
+	if File_api_backend_proto != nil {
+		return
+	}
+	file_api_messages_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_api_backend_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FunctionConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchMatchesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchMatchesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NoMatchSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseTicketsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseTicketsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseAllTicketsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseAllTicketsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssignmentGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssignmentFailure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssignTicketsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssignTicketsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamAssignTicketsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		file_api_backend_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReleaseTicketsResponse); i {
+		file_api_backend_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamAssignTicketsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -933,8 +2094,8 @@ func file_api_backend_proto_init() {
 				return nil
 			}
 		}
-		file_api_backend_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReleaseAllTicketsRequest); i {
+		file_api_backend_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -945,8 +2106,8 @@ func file_api_backend_proto_init() {
 				return nil
 			}
 		}
-		file_api_backend_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReleaseAllTicketsResponse); i {
+		file_api_backend_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -957,8 +2118,8 @@ func file_api_backend_proto_init() {
 				return nil
 			}
 		}
-		file_api_backend_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AssignmentGroup); i {
+		file_api_backend_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatchAndAssignRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -969,8 +2130,8 @@ func file_api_backend_proto_init() {
 				return nil
 			}
 		}
-		file_api_backend_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AssignmentFailure); i {
+		file_api_backend_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatchAndAssignResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -981,8 +2142,8 @@ func file_api_backend_proto_init() {
 				return nil
 			}
 		}
-		file_api_backend_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AssignTicketsRequest); i {
+		file_api_backend_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreviewMatchesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -993,8 +2154,68 @@ func file_api_backend_proto_init() {
 				return nil
 			}
 		}
-		file_api_backend_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AssignTicketsResponse); i {
+		file_api_backend_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreviewMatchesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatchPreview); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTicketPendingReleaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTicketPendingReleaseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReserveTicketsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_backend_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReserveTicketsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1012,7 +2233,7 @@ func file_api_backend_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_backend_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   11,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1046,7 +2267,18 @@ type BackendServiceClient interface {
 	// pending, and will not be returned by query.
 	FetchMatches(ctx context.Context, in *FetchMatchesRequest, opts ...grpc.CallOption) (BackendService_FetchMatchesClient, error)
 	// AssignTickets overwrites the Assignment field of the input TicketIds.
+	// A Ticket id may only appear once across all AssignmentGroups of a request,
+	// otherwise the request fails with InvalidArgument and nothing is assigned.
 	AssignTickets(ctx context.Context, in *AssignTicketsRequest, opts ...grpc.CallOption) (*AssignTicketsResponse, error)
+	// StreamAssignTickets is AssignTickets for batches too large for a single
+	// request. Each assignment group sent is committed on its own, in the order
+	// sent, and answered with the failures of that group before the next one is
+	// read. A group which fails as a whole, such as one repeating a Ticket id,
+	// ends the stream with its error, and earlier groups stay committed.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	StreamAssignTickets(ctx context.Context, opts ...grpc.CallOption) (BackendService_StreamAssignTicketsClient, error)
 	// ReleaseTickets moves tickets from the pending state, to the active state.
 	// This enables them to be returned by query, and find different matches.
 	//
@@ -1057,9 +2289,57 @@ type BackendServiceClient interface {
 	// state. This enables them to be returned by query, and find different
 	// matches.
 	//
+	// If pendingReleaseScope is configured, only the tickets pending in that
+	// scope are moved.
+	//
 	// BETA FEATURE WARNING:  This call and the associated Request and Response
 	// messages are not finalized and still subject to possible change or removal.
 	ReleaseAllTickets(ctx context.Context, in *ReleaseAllTicketsRequest, opts ...grpc.CallOption) (*ReleaseAllTicketsResponse, error)
+	// Stats returns aggregate matchmaking numbers across all Open Match
+	// instances sharing the state storage.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	// MatchAndAssign runs the MatchFunction for a single MatchProfile, selects
+	// the best proposal using the default evaluator's scoring, and assigns its
+	// Tickets to the provided Assignment, all in one call.
+	// It is a convenience for simple integrations, and does not go through the
+	// synchronizer: proposals are not deduplicated against concurrent
+	// FetchMatches calls beyond Tickets already pending release.
+	// Returns NotFound if the MatchFunction returned no usable proposal, and
+	// Aborted if any Ticket of the selected proposal is already pending release.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	MatchAndAssign(ctx context.Context, in *MatchAndAssignRequest, opts ...grpc.CallOption) (*MatchAndAssignResponse, error)
+	// PreviewMatches runs the MatchFunction for a single MatchProfile and returns
+	// its proposals, scored by the default evaluator, without side effects: the
+	// proposals do not go through the synchronizer, no Tickets are added to the
+	// pending release, and no Backfills are created or updated. It lets game
+	// designers inspect the matches a MatchProfile would make.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	PreviewMatches(ctx context.Context, in *PreviewMatchesRequest, opts ...grpc.CallOption) (*PreviewMatchesResponse, error)
+	// GetTicketPendingRelease reports whether a Ticket is pending release, and so
+	// hidden from queries, and when its pending release times out. It is meant
+	// for diagnosing Tickets which are not being matched, and does not change
+	// any state.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	GetTicketPendingRelease(ctx context.Context, in *GetTicketPendingReleaseRequest, opts ...grpc.CallOption) (*GetTicketPendingReleaseResponse, error)
+	// ReserveTickets hides Tickets from queries for a lease, so that no other
+	// backend matches them while, for example, a game server is being allocated.
+	// Assigning the Tickets confirms the reservation, and ReleaseTickets cancels
+	// it. Tickets which are neither return to the pool when the lease ends.
+	// Reserving Tickets which are already pending release replaces their
+	// release time with the end of the lease.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	ReserveTickets(ctx context.Context, in *ReserveTicketsRequest, opts ...grpc.CallOption) (*ReserveTicketsResponse, error)
 }
 
 type backendServiceClient struct {
@@ -1111,6 +2391,37 @@ func (c *backendServiceClient) AssignTickets(ctx context.Context, in *AssignTick
 	return out, nil
 }
 
+func (c *backendServiceClient) StreamAssignTickets(ctx context.Context, opts ...grpc.CallOption) (BackendService_StreamAssignTicketsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BackendService_serviceDesc.Streams[1], "/openmatch.BackendService/StreamAssignTickets", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backendServiceStreamAssignTicketsClient{stream}
+	return x, nil
+}
+
+type BackendService_StreamAssignTicketsClient interface {
+	Send(*StreamAssignTicketsRequest) error
+	Recv() (*StreamAssignTicketsResponse, error)
+	grpc.ClientStream
+}
+
+type backendServiceStreamAssignTicketsClient struct {
+	grpc.ClientStream
+}
+
+func (x *backendServiceStreamAssignTicketsClient) Send(m *StreamAssignTicketsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *backendServiceStreamAssignTicketsClient) Recv() (*StreamAssignTicketsResponse, error) {
+	m := new(StreamAssignTicketsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *backendServiceClient) ReleaseTickets(ctx context.Context, in *ReleaseTicketsRequest, opts ...grpc.CallOption) (*ReleaseTicketsResponse, error) {
 	out := new(ReleaseTicketsResponse)
 	err := c.cc.Invoke(ctx, "/openmatch.BackendService/ReleaseTickets", in, out, opts...)
@@ -1129,6 +2440,51 @@ func (c *backendServiceClient) ReleaseAllTickets(ctx context.Context, in *Releas
 	return out, nil
 }
 
+func (c *backendServiceClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.BackendService/Stats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendServiceClient) MatchAndAssign(ctx context.Context, in *MatchAndAssignRequest, opts ...grpc.CallOption) (*MatchAndAssignResponse, error) {
+	out := new(MatchAndAssignResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.BackendService/MatchAndAssign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendServiceClient) PreviewMatches(ctx context.Context, in *PreviewMatchesRequest, opts ...grpc.CallOption) (*PreviewMatchesResponse, error) {
+	out := new(PreviewMatchesResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.BackendService/PreviewMatches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendServiceClient) GetTicketPendingRelease(ctx context.Context, in *GetTicketPendingReleaseRequest, opts ...grpc.CallOption) (*GetTicketPendingReleaseResponse, error) {
+	out := new(GetTicketPendingReleaseResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.BackendService/GetTicketPendingRelease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendServiceClient) ReserveTickets(ctx context.Context, in *ReserveTicketsRequest, opts ...grpc.CallOption) (*ReserveTicketsResponse, error) {
+	out := new(ReserveTicketsResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.BackendService/ReserveTickets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BackendServiceServer is the server API for BackendService service.
 type BackendServiceServer interface {
 	// FetchMatches triggers a MatchFunction with the specified MatchProfile and
@@ -1138,7 +2494,18 @@ type BackendServiceServer interface {
 	// pending, and will not be returned by query.
 	FetchMatches(*FetchMatchesRequest, BackendService_FetchMatchesServer) error
 	// AssignTickets overwrites the Assignment field of the input TicketIds.
+	// A Ticket id may only appear once across all AssignmentGroups of a request,
+	// otherwise the request fails with InvalidArgument and nothing is assigned.
 	AssignTickets(context.Context, *AssignTicketsRequest) (*AssignTicketsResponse, error)
+	// StreamAssignTickets is AssignTickets for batches too large for a single
+	// request. Each assignment group sent is committed on its own, in the order
+	// sent, and answered with the failures of that group before the next one is
+	// read. A group which fails as a whole, such as one repeating a Ticket id,
+	// ends the stream with its error, and earlier groups stay committed.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	StreamAssignTickets(BackendService_StreamAssignTicketsServer) error
 	// ReleaseTickets moves tickets from the pending state, to the active state.
 	// This enables them to be returned by query, and find different matches.
 	//
@@ -1149,9 +2516,57 @@ type BackendServiceServer interface {
 	// state. This enables them to be returned by query, and find different
 	// matches.
 	//
+	// If pendingReleaseScope is configured, only the tickets pending in that
+	// scope are moved.
+	//
 	// BETA FEATURE WARNING:  This call and the associated Request and Response
 	// messages are not finalized and still subject to possible change or removal.
 	ReleaseAllTickets(context.Context, *ReleaseAllTicketsRequest) (*ReleaseAllTicketsResponse, error)
+	// Stats returns aggregate matchmaking numbers across all Open Match
+	// instances sharing the state storage.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	// MatchAndAssign runs the MatchFunction for a single MatchProfile, selects
+	// the best proposal using the default evaluator's scoring, and assigns its
+	// Tickets to the provided Assignment, all in one call.
+	// It is a convenience for simple integrations, and does not go through the
+	// synchronizer: proposals are not deduplicated against concurrent
+	// FetchMatches calls beyond Tickets already pending release.
+	// Returns NotFound if the MatchFunction returned no usable proposal, and
+	// Aborted if any Ticket of the selected proposal is already pending release.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	MatchAndAssign(context.Context, *MatchAndAssignRequest) (*MatchAndAssignResponse, error)
+	// PreviewMatches runs the MatchFunction for a single MatchProfile and returns
+	// its proposals, scored by the default evaluator, without side effects: the
+	// proposals do not go through the synchronizer, no Tickets are added to the
+	// pending release, and no Backfills are created or updated. It lets game
+	// designers inspect the matches a MatchProfile would make.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	PreviewMatches(context.Context, *PreviewMatchesRequest) (*PreviewMatchesResponse, error)
+	// GetTicketPendingRelease reports whether a Ticket is pending release, and so
+	// hidden from queries, and when its pending release times out. It is meant
+	// for diagnosing Tickets which are not being matched, and does not change
+	// any state.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	GetTicketPendingRelease(context.Context, *GetTicketPendingReleaseRequest) (*GetTicketPendingReleaseResponse, error)
+	// ReserveTickets hides Tickets from queries for a lease, so that no other
+	// backend matches them while, for example, a game server is being allocated.
+	// Assigning the Tickets confirms the reservation, and ReleaseTickets cancels
+	// it. Tickets which are neither return to the pool when the lease ends.
+	// Reserving Tickets which are already pending release replaces their
+	// release time with the end of the lease.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	ReserveTickets(context.Context, *ReserveTicketsRequest) (*ReserveTicketsResponse, error)
 }
 
 // UnimplementedBackendServiceServer can be embedded to have forward compatible implementations.
@@ -1164,12 +2579,30 @@ func (*UnimplementedBackendServiceServer) FetchMatches(*FetchMatchesRequest, Bac
 func (*UnimplementedBackendServiceServer) AssignTickets(context.Context, *AssignTicketsRequest) (*AssignTicketsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AssignTickets not implemented")
 }
+func (*UnimplementedBackendServiceServer) StreamAssignTickets(BackendService_StreamAssignTicketsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAssignTickets not implemented")
+}
 func (*UnimplementedBackendServiceServer) ReleaseTickets(context.Context, *ReleaseTicketsRequest) (*ReleaseTicketsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReleaseTickets not implemented")
 }
 func (*UnimplementedBackendServiceServer) ReleaseAllTickets(context.Context, *ReleaseAllTicketsRequest) (*ReleaseAllTicketsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReleaseAllTickets not implemented")
 }
+func (*UnimplementedBackendServiceServer) Stats(context.Context, *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (*UnimplementedBackendServiceServer) MatchAndAssign(context.Context, *MatchAndAssignRequest) (*MatchAndAssignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MatchAndAssign not implemented")
+}
+func (*UnimplementedBackendServiceServer) PreviewMatches(context.Context, *PreviewMatchesRequest) (*PreviewMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewMatches not implemented")
+}
+func (*UnimplementedBackendServiceServer) GetTicketPendingRelease(context.Context, *GetTicketPendingReleaseRequest) (*GetTicketPendingReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTicketPendingRelease not implemented")
+}
+func (*UnimplementedBackendServiceServer) ReserveTickets(context.Context, *ReserveTicketsRequest) (*ReserveTicketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveTickets not implemented")
+}
 
 func RegisterBackendServiceServer(s *grpc.Server, srv BackendServiceServer) {
 	s.RegisterService(&_BackendService_serviceDesc, srv)
@@ -1214,6 +2647,32 @@ func _BackendService_AssignTickets_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BackendService_StreamAssignTickets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BackendServiceServer).StreamAssignTickets(&backendServiceStreamAssignTicketsServer{stream})
+}
+
+type BackendService_StreamAssignTicketsServer interface {
+	Send(*StreamAssignTicketsResponse) error
+	Recv() (*StreamAssignTicketsRequest, error)
+	grpc.ServerStream
+}
+
+type backendServiceStreamAssignTicketsServer struct {
+	grpc.ServerStream
+}
+
+func (x *backendServiceStreamAssignTicketsServer) Send(m *StreamAssignTicketsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *backendServiceStreamAssignTicketsServer) Recv() (*StreamAssignTicketsRequest, error) {
+	m := new(StreamAssignTicketsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _BackendService_ReleaseTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReleaseTicketsRequest)
 	if err := dec(in); err != nil {
@@ -1250,6 +2709,96 @@ func _BackendService_ReleaseAllTickets_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BackendService_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.BackendService/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendService_MatchAndAssign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchAndAssignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).MatchAndAssign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.BackendService/MatchAndAssign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).MatchAndAssign(ctx, req.(*MatchAndAssignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendService_PreviewMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).PreviewMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.BackendService/PreviewMatches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).PreviewMatches(ctx, req.(*PreviewMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendService_GetTicketPendingRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTicketPendingReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).GetTicketPendingRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.BackendService/GetTicketPendingRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).GetTicketPendingRelease(ctx, req.(*GetTicketPendingReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendService_ReserveTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).ReserveTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.BackendService/ReserveTickets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).ReserveTickets(ctx, req.(*ReserveTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _BackendService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "openmatch.BackendService",
 	HandlerType: (*BackendServiceServer)(nil),
@@ -1266,6 +2815,26 @@ var _BackendService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReleaseAllTickets",
 			Handler:    _BackendService_ReleaseAllTickets_Handler,
 		},
+		{
+			MethodName: "Stats",
+			Handler:    _BackendService_Stats_Handler,
+		},
+		{
+			MethodName: "MatchAndAssign",
+			Handler:    _BackendService_MatchAndAssign_Handler,
+		},
+		{
+			MethodName: "PreviewMatches",
+			Handler:    _BackendService_PreviewMatches_Handler,
+		},
+		{
+			MethodName: "GetTicketPendingRelease",
+			Handler:    _BackendService_GetTicketPendingRelease_Handler,
+		},
+		{
+			MethodName: "ReserveTickets",
+			Handler:    _BackendService_ReserveTickets_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1273,6 +2842,12 @@ var _BackendService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _BackendService_FetchMatches_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamAssignTickets",
+			Handler:       _BackendService_StreamAssignTickets_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "api/backend.proto",
 }
@@ -0,0 +1,46 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pools lets clients evaluate Pools the same way Open Match does, for
+// example to show the estimated population of the pools a Ticket would join
+// before it is created.
+package pools
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// Matching returns the pools, in the given order, which a Ticket with the
+// SearchFields would belong to if it were created now.  It fails with
+// InvalidArgument if a pool is invalid.
+func Matching(searchFields *pb.SearchFields, pools []*pb.Pool) ([]*pb.Pool, error) {
+	ticket := &pb.Ticket{
+		SearchFields: searchFields,
+		CreateTime:   ptypes.TimestampNow(),
+	}
+
+	var matching []*pb.Pool
+	for _, pool := range pools {
+		pf, err := filter.NewPoolFilter(pool)
+		if err != nil {
+			return nil, err
+		}
+		if pf.In(ticket) {
+			matching = append(matching, pool)
+		}
+	}
+	return matching, nil
+}
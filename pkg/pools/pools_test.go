@@ -0,0 +1,116 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pools
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/filter/testcases"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestMatchingFilterCases(t *testing.T) {
+	for _, tc := range testcases.IncludedTestCases() {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			matching, err := Matching(tc.SearchFields, []*pb.Pool{tc.Pool})
+			require.NoError(t, err)
+			require.Equal(t, []*pb.Pool{tc.Pool}, matching)
+		})
+	}
+
+	for _, tc := range testcases.ExcludedTestCases() {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			matching, err := Matching(tc.SearchFields, []*pb.Pool{tc.Pool})
+			require.NoError(t, err)
+			require.Empty(t, matching)
+		})
+	}
+}
+
+func TestMatching(t *testing.T) {
+	mmrRange := func(name string, min, max float64, exclude pb.DoubleRangeFilter_Exclude) *pb.Pool {
+		return &pb.Pool{
+			Name: name,
+			DoubleRangeFilters: []*pb.DoubleRangeFilter{
+				{DoubleArg: "mmr", Min: min, Max: max, Exclude: exclude},
+			},
+		}
+	}
+	pools := []*pb.Pool{
+		mmrRange("low", 0, 1000, pb.DoubleRangeFilter_MAX),
+		mmrRange("mid", 1000, 2000, pb.DoubleRangeFilter_NONE),
+		mmrRange("high", 2000, 3000, pb.DoubleRangeFilter_MIN),
+		mmrRange("open", 1000, 2000, pb.DoubleRangeFilter_BOTH),
+		{
+			Name:                "europe",
+			StringEqualsFilters: []*pb.StringEqualsFilter{{StringArg: "region", Value: "europe"}},
+		},
+		{
+			Name:              "ranked",
+			TagPresentFilters: []*pb.TagPresentFilter{{Tag: "mode.ranked"}},
+		},
+	}
+
+	names := func(pools []*pb.Pool) []string {
+		var names []string
+		for _, p := range pools {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+
+	for _, tt := range []struct {
+		name         string
+		searchFields *pb.SearchFields
+		expected     []string
+	}{
+		{"no fields", nil, nil},
+		{"lower bound included", &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 0}}, []string{"low"}},
+		{"upper bound excluded", &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 1000}}, []string{"mid"}},
+		{"both bounds included", &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 2000}}, []string{"mid"}},
+		{"inside of open range", &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 1500}}, []string{"mid", "open"}},
+		{"upper bound of high", &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 3000}}, []string{"high"}},
+		{"above every range", &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 3000.5}}, nil},
+		{
+			"several kinds of filters",
+			&pb.SearchFields{
+				DoubleArgs: map[string]float64{"mmr": 500},
+				StringArgs: map[string]string{"region": "europe"},
+				Tags:       []string{"mode.ranked"},
+			},
+			[]string{"low", "europe", "ranked"},
+		},
+		{"string value differs", &pb.SearchFields{StringArgs: map[string]string{"region": "asia"}}, nil},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			matching, err := Matching(tt.searchFields, pools)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, names(matching))
+		})
+	}
+}
+
+func TestMatchingInvalidPool(t *testing.T) {
+	pool := &pb.Pool{CreatedBefore: &timestamp.Timestamp{Nanos: -1}}
+	_, err := Matching(&pb.SearchFields{}, []*pb.Pool{pool})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}